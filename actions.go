@@ -1,78 +1,443 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
 	"os/exec"
-	"path/filepath"
+	"sync"
+	"time"
+
+	"quicklaunch/internal/actions"
+	"quicklaunch/internal/config"
+	"quicklaunch/internal/guard"
+	"quicklaunch/internal/launcher"
+	"quicklaunch/internal/procs"
+	"quicklaunch/internal/runlog"
+	"quicklaunch/internal/runner"
+	"quicklaunch/internal/script"
+	"quicklaunch/internal/service"
+	"quicklaunch/internal/vars"
+	"quicklaunch/internal/workflow"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
 )
 
-// executeAction executes an action based on type
-func executeAction(actionType, target, path string) error {
-	switch actionType {
-	case "app":
-		return launchApp(target, path)
-	case "folder":
-		return openFolder(path)
-	case "url":
-		return openURL(target)
-	case "powershell":
-		return runPowerShell(target, path)
-	default:
-		return nil
-	}
-}
-
-// launchApp launches an application
-func launchApp(target, path string) error {
-	if path != "" {
-		// Launch app with path as argument
-		cmd := exec.Command("cmd", "/c", "start", "", target, path)
-		return cmd.Start()
-	}
-	cmd := exec.Command("cmd", "/c", "start", "", target)
-	return cmd.Start()
-}
-
-// openFolder opens a folder in Windows Explorer
-func openFolder(path string) error {
-	cmd := exec.Command("explorer", path)
-	return cmd.Start()
-}
-
-// openURL opens a URL in the default browser
-func openURL(url string) error {
-	cmd := exec.Command("cmd", "/c", "start", "", url)
-	return cmd.Start()
-}
-
-// runPowerShell runs a PowerShell command
-func runPowerShell(command, path string) error {
-	// Special handling for claude command
-	if command == "claude" {
-		if path != "" {
-			// Open Windows Terminal with claude in the specified directory
-			absPath, err := filepath.Abs(path)
-			if err != nil {
-				absPath = path
+// runHistoryLimit is the number of background runs kept in memory
+const runHistoryLimit = 50
+
+// stopTimeout is how long StopTile waits for processes to exit before
+// killing them
+const stopTimeout = 5 * time.Second
+
+// appRunner starts action processes for the app. Background runs go
+// through the app's runner so their output ends up in the run history.
+type appRunner struct {
+	app *App
+}
+
+func (r appRunner) Start(cmd *exec.Cmd, req actions.Request) error {
+	return r.app.procs.Start(requestContext(req), req.TileID, cmd, req.Wait)
+}
+
+func (r appRunner) Run(cmd *exec.Cmd, req actions.Request) error {
+	procs.Prepare(cmd)
+	id, err := r.app.runner.Run(runner.Job{
+		TileID: req.TileID,
+		Action: req.Action,
+		Target: req.Target,
+		Path:   req.Path,
+	}, cmd)
+	if err != nil || !req.Wait {
+		return err
+	}
+
+	ctx := requestContext(req)
+	res, ok := r.app.runner.Wait(ctx, id)
+	if ok && res.Running {
+		return ctx.Err()
+	}
+	if !ok {
+		return fmt.Errorf("result of run %s of %s is no longer available", id, req.Target)
+	}
+	if !res.Success {
+		return fmt.Errorf("%s exited with code %d", req.Target, res.ExitCode)
+	}
+	return nil
+}
+
+// requestContext returns the context that ends waiting for a request
+func requestContext(req actions.Request) context.Context {
+	if req.Context == nil {
+		return context.Background()
+	}
+	return req.Context
+}
+
+func (r appRunner) Supervise(req actions.Request, command func() (*exec.Cmd, error)) error {
+	name := req.Target
+	if tile, ok := r.app.findTile(req.TileID); ok {
+		name = tile.Name
+	}
+	id := req.TileID
+	if id == "" {
+		id = req.Target
+	}
+	return r.app.services.Start(service.Service{ID: id, Name: name, Command: command})
+}
+
+// startServices starts the service tiles marked to start with the app
+func (a *App) startServices() {
+	if a.config == nil {
+		return
+	}
+	for _, tile := range a.config.Tiles {
+		if tile.Action != "service" || !tile.StartWithApp || !tile.Enabled {
+			continue
+		}
+		if err := a.executeTile(tile, launchInput{}); err != nil {
+			println("Failed to start service", tile.Name+":", err.Error())
+		}
+	}
+}
+
+// serviceChanged publishes the state of a service to the frontend
+func (a *App) serviceChanged(state service.State) {
+	if a.ctx != nil {
+		runtime.EventsEmit(a.ctx, "service:changed", state)
+	}
+}
+
+// processesChanged publishes the live processes to the frontend
+func (a *App) processesChanged() {
+	if a.ctx != nil {
+		runtime.EventsEmit(a.ctx, "processes:changed", a.procs.List(""))
+	}
+}
+
+// runFinished publishes the result of a background run to the frontend
+// and shows a notification
+func (a *App) runFinished(res runner.Result) {
+	if a.ctx != nil {
+		runtime.EventsEmit(a.ctx, "run:finished", res)
+	}
+
+	name := res.Command
+	tileName := ""
+	if tile, ok := a.findTile(res.TileID); ok {
+		name = tile.Name
+		tileName = tile.Name
+	}
+
+	status := runlog.StatusSucceeded
+	if !res.Success {
+		status = runlog.StatusFailed
+	}
+	exitCode := res.ExitCode
+	a.appendRunLog(runlog.Entry{
+		TileID:     res.TileID,
+		TileName:   tileName,
+		Action:     res.Action,
+		Target:     res.Target,
+		Path:       res.Path,
+		Started:    res.Started,
+		DurationMs: res.DurationMs,
+		Status:     status,
+		ExitCode:   &exitCode,
+		Error:      res.Error,
+		RunID:      res.ID,
+	})
+
+	if err := a.toast.ShowRunFinished(name, res.Success, res.ExitCode); err != nil {
+		println("Failed to show run notification:", err.Error())
+	}
+}
+
+// configureExecutor applies launcher settings from the configuration
+func configureExecutor(terminal string) {
+	actions.Default.SetExecutor(launcher.New(launcher.Options{Terminal: terminal}))
+}
+
+// configurePolicy applies the command policy from the configuration
+func configurePolicy(p config.CommandPolicy) {
+	actions.Default.SetPolicy(guard.Policy{Allow: p.Allow, Deny: p.Deny})
+}
+
+// ConfirmRequest is shown in the confirmation dialog of a guarded tile.
+// Token confirms exactly one execution of the tile until ExpiresAt.
+type ConfirmRequest struct {
+	Token     string    `json:"token"`
+	TileID    string    `json:"tileId"`
+	Name      string    `json:"name"`
+	Action    string    `json:"action"`
+	Target    string    `json:"target"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// launchInput holds the values a tile is launched with
+type launchInput struct {
+	// Path is the folder chosen in the submenu
+	Path string
+	// Query is the text typed after the tile's keyword
+	Query string
+	// Inputs holds the values for {input:...} placeholders
+	Inputs map[string]string
+	// ConfirmToken confirms tiles with RequireConfirm
+	ConfirmToken string
+}
+
+// executeTile executes a tile with its arguments, working directory and
+// terminal profile. Unknown action types return an
+// *actions.UnknownActionError.
+func (a *App) executeTile(tile config.Tile, in launchInput) error {
+	// Ask for all inputs up front, workflows must not stop halfway
+	var missing []string
+	for _, prompt := range tilePrompts(tile) {
+		if _, ok := in.Inputs[prompt]; !ok {
+			missing = append(missing, prompt)
+		}
+	}
+	if len(missing) > 0 {
+		return &vars.MissingInputError{Prompts: missing}
+	}
+	vc := a.varsContext(in)
+
+	profile := a.terminalProfile(tile.TerminalProfile)
+	if tile.Shell != "" {
+		profile.Shell = tile.Shell
+	}
+
+	// Workflows run until StopTile cancels them or they end
+	var ctx context.Context
+	endWorkflow := func() {}
+	if tile.Action == "workflow" {
+		ctx, endWorkflow = a.startWorkflow(tile.ID)
+	}
+
+	background := tile.RunMode == config.RunModeBackground
+	started := time.Now()
+	err := actions.Default.Execute(tile.Action, actions.Request{
+		Context:        ctx,
+		TileID:         tile.ID,
+		Target:         tile.Target,
+		Args:           tile.Args,
+		Path:           in.Path,
+		WorkDir:        tile.WorkDir,
+		Profile:        profile,
+		Background:     background,
+		Elevated:       tile.Elevated,
+		SingleInstance: tile.SingleInstance,
+		WindowClass:    tile.WindowClass,
+		Vars:           vc,
+		Steps:          a.workflowSteps(tile, vc),
+		RequireConfirm: tile.RequireConfirm,
+		ConfirmToken:   in.ConfirmToken,
+		Progress: func(p workflow.Progress) {
+			if p.Status == workflow.StatusFinished || p.Status == workflow.StatusAborted {
+				endWorkflow()
+			}
+			if a.ctx != nil {
+				runtime.EventsEmit(a.ctx, "workflow:progress", tile.ID, p)
+			}
+		},
+	})
+	if err != nil {
+		endWorkflow()
+	}
+
+	// Background runs and scripts are logged by runFinished and
+	// scriptFinished once their outcome is known; asking for confirmation
+	// is not a launch
+	if errors.Is(err, guard.ErrConfirmationRequired) {
+		return err
+	}
+	if err != nil || !background && tile.Action != script.ActionType {
+		a.logLaunch(tile, in, started, err)
+	}
+	if err == nil {
+		a.touchTile(tile.ID)
+	}
+	return err
+}
+
+// workflowRun is a running workflow of a tile
+type workflowRun struct {
+	cancel context.CancelFunc
+}
+
+// startWorkflow registers a workflow run of a tile. The returned context
+// is cancelled by StopTile; end releases the run and may be called more
+// than once.
+func (a *App) startWorkflow(tileID string) (context.Context, func()) {
+	ctx, cancel := context.WithCancel(context.Background())
+	run := &workflowRun{cancel: cancel}
+	a.workflowsMu.Lock()
+	a.workflows[tileID] = append(a.workflows[tileID], run)
+	a.workflowsMu.Unlock()
+
+	return ctx, func() {
+		cancel()
+		a.workflowsMu.Lock()
+		defer a.workflowsMu.Unlock()
+		runs := a.workflows[tileID]
+		for i, r := range runs {
+			if r == run {
+				runs = append(runs[:i], runs[i+1:]...)
+				break
 			}
-			cmd := exec.Command("wt", "-d", absPath, "claude")
-			return cmd.Start()
 		}
-		// Open Windows Terminal with claude in current directory
-		cmd := exec.Command("wt", "claude")
-		return cmd.Start()
+		if len(runs) == 0 {
+			delete(a.workflows, tileID)
+		} else {
+			a.workflows[tileID] = runs
+		}
+	}
+}
+
+// cancelWorkflows cancels the running workflows of a tile and reports
+// whether there were any
+func (a *App) cancelWorkflows(tileID string) bool {
+	a.workflowsMu.Lock()
+	runs := a.workflows[tileID]
+	delete(a.workflows, tileID)
+	a.workflowsMu.Unlock()
+
+	for _, run := range runs {
+		run.cancel()
 	}
+	return len(runs) > 0
+}
 
-	// Generic PowerShell command
-	if path != "" {
-		absPath, err := filepath.Abs(path)
-		if err != nil {
-			absPath = path
+// workflowSteps converts the steps of a workflow tile to action steps.
+// Steps without their own terminal profile use the tile's profile.
+func (a *App) workflowSteps(tile config.Tile, vc *vars.Context) []actions.Step {
+	steps := make([]actions.Step, 0, len(tile.Steps))
+	for _, s := range tile.Steps {
+		profileID := s.TerminalProfile
+		if profileID == "" {
+			profileID = tile.TerminalProfile
 		}
-		cmd := exec.Command("powershell", "-NoExit", "-Command",
-			"Set-Location '"+absPath+"'; "+command)
-		return cmd.Start()
+		profile := a.terminalProfile(profileID)
+		if s.Shell != "" {
+			profile.Shell = s.Shell
+		}
+
+		steps = append(steps, actions.Step{
+			Request: actions.Request{
+				Action:     s.Action,
+				TileID:     tile.ID,
+				Target:     s.Target,
+				Args:       s.Args,
+				Path:       s.Path,
+				WorkDir:    s.WorkDir,
+				Profile:    profile,
+				Background: s.RunMode == config.RunModeBackground,
+				Wait:       s.Wait,
+				Elevated:   s.Elevated,
+				Vars:       vc,
+			},
+			Name:            s.Name,
+			Delay:           time.Duration(s.DelayMs) * time.Millisecond,
+			ContinueOnError: s.ContinueOnError,
+		})
 	}
+	return steps
+}
+
+// varsContext returns the placeholder values for a launch. The clipboard
+// is read at most once, and only if a placeholder needs it.
+func (a *App) varsContext(in launchInput) *vars.Context {
+	home, _ := os.UserHomeDir()
 
-	cmd := exec.Command("powershell", "-NoExit", "-Command", command)
-	return cmd.Start()
+	var once sync.Once
+	var clip string
+	var clipErr error
+
+	return &vars.Context{
+		Path:   in.Path,
+		Query:  in.Query,
+		Home:   home,
+		Now:    time.Now(),
+		Getenv: os.Getenv,
+		Clipboard: func() (string, error) {
+			once.Do(func() {
+				if a.ctx == nil {
+					clipErr = errors.New("clipboard not available")
+					return
+				}
+				clip, clipErr = runtime.ClipboardGetText(a.ctx)
+			})
+			return clip, clipErr
+		},
+		Inputs: in.Inputs,
+	}
+}
+
+// tilePrompts returns the input prompts of a tile and its workflow steps
+func tilePrompts(tile config.Tile) []string {
+	values := append([]string{tile.Target, tile.WorkDir}, tile.Args...)
+	for _, s := range tile.Steps {
+		values = append(values, s.Target, s.WorkDir)
+		values = append(values, s.Args...)
+	}
+	return vars.Prompts(values...)
+}
+
+// logLaunch records a tile launch in the run log
+func (a *App) logLaunch(tile config.Tile, in launchInput, started time.Time, err error) {
+	entry := runlog.Entry{
+		TileID:     tile.ID,
+		TileName:   tile.Name,
+		Action:     tile.Action,
+		Target:     tile.Target,
+		Path:       in.Path,
+		Query:      in.Query,
+		Started:    started,
+		DurationMs: time.Since(started).Milliseconds(),
+		Status:     runlog.StatusStarted,
+	}
+	if h, lookupErr := actions.Default.Lookup(tile.Action); lookupErr == nil {
+		entry.Action = h.Describe().Type
+	}
+	if err != nil {
+		entry.Status = runlog.StatusFailed
+		entry.Error = err.Error()
+	}
+	if errors.Is(err, launcher.ErrElevationCancelled) {
+		entry.Status = runlog.StatusCancelled
+	}
+	a.appendRunLog(entry)
+}
+
+// appendRunLog writes an entry to the run log and notifies the frontend
+func (a *App) appendRunLog(entry runlog.Entry) {
+	if a.runLog == nil {
+		return
+	}
+	entry, err := a.runLog.Append(entry)
+	if err != nil {
+		println("Failed to write run log:", err.Error())
+		return
+	}
+	if a.ctx != nil {
+		runtime.EventsEmit(a.ctx, "runlog:added", entry)
+	}
+}
+
+// terminalProfile resolves a terminal profile by ID, falling back to the
+// default profile and finally to the platform default terminal
+func (a *App) terminalProfile(id string) launcher.Profile {
+	if a.config == nil {
+		return launcher.Profile{}
+	}
+	p, ok := a.config.FindTerminalProfile(id)
+	if !ok {
+		return launcher.Profile{}
+	}
+	return launcher.Profile{
+		Terminal: p.Terminal,
+		Args:     p.Args,
+		Shell:    p.Shell,
+	}
 }
@@ -2,19 +2,38 @@ package main
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"os"
 	"os/exec"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/wailsapp/wails/v2/pkg/runtime"
 	"golang.design/x/hotkey"
 	"golang.design/x/hotkey/mainthread"
 
+	"quicklaunch/internal/actions"
 	"quicklaunch/internal/config"
+	"quicklaunch/internal/fileindex"
 	"quicklaunch/internal/focus"
+	"quicklaunch/internal/frecency"
+	"quicklaunch/internal/guard"
+	"quicklaunch/internal/hotkeys"
+	"quicklaunch/internal/keywords"
+	"quicklaunch/internal/launcher"
 	"quicklaunch/internal/notification"
+	"quicklaunch/internal/plugins"
+	"quicklaunch/internal/procs"
+	"quicklaunch/internal/providers"
+	"quicklaunch/internal/runlog"
+	"quicklaunch/internal/runner"
+	"quicklaunch/internal/search"
+	"quicklaunch/internal/service"
 	"quicklaunch/internal/tray"
 	"quicklaunch/internal/updater"
+	"quicklaunch/internal/vars"
 	"quicklaunch/internal/version"
 )
 
@@ -23,28 +42,82 @@ type App struct {
 	ctx          context.Context
 	isVisible    bool
 	hk           *hotkey.Hotkey
+	hkMu         sync.Mutex
+	hkCombo      string
+	hkReady      bool
+	tileHotkeys  map[string]*tileHotkey
+	hkConflicts  []hotkeys.Conflict
 	trayManager  *tray.Manager
 	config       *config.Config
 	focusMonitor *focus.Monitor
 	updater      *updater.Updater
 	toast        *notification.Toast
+	runner       *runner.Runner
+	procs        *procs.Tracker
+	services     *service.Supervisor
+	searchIndex  *search.Index
+	appIndex     *search.Index
+	files        *fileindex.Index
+	filesMu      sync.Mutex
+	providers    *providers.Registry
+	plugins      *plugins.Host
+	pluginsMu    sync.Mutex
+	workflows    map[string][]*workflowRun
+	workflowsMu  sync.Mutex
+	frecency     *frecency.Store
+	runLog       *runlog.Log
 }
 
 // NewApp creates a new App application struct
 func NewApp() *App {
 	// Load configuration
 	cfg, _ := config.Load()
-
-	return &App{
-		config:  cfg,
-		updater: updater.New(),
-		toast:   notification.NewToast(),
+	configureExecutor(cfg.Terminal)
+	configurePolicy(cfg.CommandPolicy)
+
+	a := &App{
+		config:      cfg,
+		updater:     updater.New(),
+		toast:       notification.NewToast(),
+		tileHotkeys: make(map[string]*tileHotkey),
+		workflows:   make(map[string][]*workflowRun),
+		searchIndex: search.NewIndex(),
+		appIndex:    search.NewIndex(),
+	}
+	a.runner = runner.New(runHistoryLimit, a.runFinished)
+	a.procs = procs.NewTracker(a.processesChanged)
+	a.runner.SetTrack(func(job runner.Job, cmd *exec.Cmd) func() {
+		return a.procs.Add(job.TileID, cmd)
+	})
+	a.services = service.New(a.procs, service.DefaultOptions, a.serviceChanged)
+	dir, err := config.GetConfigDir()
+	if err == nil {
+		a.runLog = runlog.New(dir, runlog.DefaultMaxSize, runlog.DefaultBackups)
+	} else {
+		dir = ""
 	}
+	a.frecency, err = frecency.Load(dir, frecency.DefaultHalfLife)
+	if err != nil {
+		println("Failed to load frecency:", err.Error())
+	}
+	a.files = a.newFileIndex(cfg.FileIndex)
+	a.providers = providers.NewRegistry()
+	a.plugins = a.newPluginHost()
+	a.registerProviders()
+	a.providers.Configure(providerSettings(cfg.SearchProviders))
+	actions.Default.SetRunner(appRunner{a})
+	actions.Default.SetSupervisor(appRunner{a})
+	actions.Default.SetScripter(appRunner{a})
+
+	return a
 }
 
 // SetTrayManager sets the tray manager reference
 func (a *App) SetTrayManager(tm *tray.Manager) {
 	a.trayManager = tm
+	if a.config != nil && a.config.Hotkey != "" {
+		tm.SetHotkey(a.config.Hotkey)
+	}
 }
 
 // startup is called when the app starts
@@ -54,6 +127,9 @@ func (a *App) startup(ctx context.Context) {
 	// Start hotkey registration on main thread
 	go mainthread.Init(func() {
 		a.registerHotkey()
+		a.syncTileHotkeys()
+		// Keep the main thread loop alive for later re-registrations
+		<-ctx.Done()
 	})
 
 	// Start focus monitor to hide panel when focus is lost
@@ -70,9 +146,13 @@ func (a *App) startup(ctx context.Context) {
 	if a.config != nil && a.config.CheckForUpdatesOnStartup {
 		go a.checkForUpdateOnStartup()
 	}
+
+	go a.startServices()
+	a.fileIndex().Start()
+	a.pluginHost().Start()
 }
 
-// initializeToast sets up Windows toast notifications
+// initializeToast sets up desktop notifications
 func (a *App) initializeToast() {
 	exe, err := os.Executable()
 	if err != nil {
@@ -91,6 +171,8 @@ func (a *App) initializeToast() {
 			a.ShowPanelWithView("settings")
 		} else if action == "restart-app" {
 			a.RestartApp()
+		} else if action == "show-runs" {
+			a.ShowPanelWithView("runs")
 		}
 	})
 }
@@ -118,8 +200,14 @@ func (a *App) domReady(ctx context.Context) {
 
 // shutdown is called when the app is shutting down
 func (a *App) shutdown(ctx context.Context) {
-	if a.hk != nil {
-		a.hk.Unregister()
+	a.unregisterHotkeys()
+
+	// Services must not outlive the app
+	a.services.StopAll(stopTimeout)
+	a.pluginHost().Close()
+
+	if err := a.fileIndex().Close(); err != nil {
+		println("Failed to save file index:", err.Error())
 	}
 
 	// Stop focus monitor
@@ -140,28 +228,177 @@ func (a *App) shutdown(ctx context.Context) {
 
 // QuitApp quits the application
 func (a *App) QuitApp() {
-	if a.hk != nil {
-		a.hk.Unregister()
-	}
+	a.unregisterHotkeys()
 	if a.config != nil {
 		a.config.Save()
 	}
 	runtime.Quit(a.ctx)
 }
 
-// registerHotkey registers the global hotkey (Ctrl+Space)
+// registerHotkey registers the global hotkey from the configuration,
+// falling back to Ctrl+Space if the configured one cannot be used
 func (a *App) registerHotkey() {
-	a.hk = hotkey.New([]hotkey.Modifier{hotkey.ModCtrl}, hotkey.KeySpace)
-	if err := a.hk.Register(); err != nil {
+	combo := defaultHotkey
+	if a.config != nil && a.config.Hotkey != "" {
+		combo = a.config.Hotkey
+	}
+
+	if _, err := a.setPanelHotkey(combo); err != nil {
 		println("Failed to register hotkey:", err.Error())
+		if combo != defaultHotkey {
+			if _, err := a.setPanelHotkey(defaultHotkey); err != nil {
+				println("Failed to register default hotkey:", err.Error())
+			}
+		}
+	}
+
+	a.hkMu.Lock()
+	a.hkReady = true
+	a.hkMu.Unlock()
+}
+
+// setPanelHotkey replaces the panel hotkey with the given combo and returns
+// its canonical form. The previous hotkey stays active if registration fails.
+func (a *App) setPanelHotkey(s string) (string, error) {
+	combo, err := hotkeys.Parse(s)
+	if err != nil {
+		return "", err
+	}
+	hk, err := newHotkey(combo)
+	if err != nil {
+		return "", err
+	}
+
+	a.hkMu.Lock()
+	defer a.hkMu.Unlock()
+
+	old := a.hk
+	if old != nil {
+		unregisterOnMainThread(old)
+	}
+
+	if err := registerOnMainThread(hk); err != nil {
+		a.hk = nil
+		if old != nil && registerOnMainThread(old) == nil {
+			a.hk = old
+			go listenHotkey(old, a.TogglePanel)
+		}
+		return "", fmt.Errorf("failed to register hotkey %s: %w", combo, err)
+	}
+
+	a.hk = hk
+	a.hkCombo = combo.String()
+	go listenHotkey(hk, a.TogglePanel)
+	return a.hkCombo, nil
+}
+
+// syncTileHotkeys brings the registered tile hotkeys in line with the
+// configured tiles. Unchanged registrations are kept, conflicts are
+// stored and emitted to the frontend as "hotkeys:conflicts".
+func (a *App) syncTileHotkeys() {
+	a.hkMu.Lock()
+	if !a.hkReady {
+		a.hkMu.Unlock()
 		return
 	}
 
-	for range a.hk.Keydown() {
-		a.TogglePanel()
+	var assignments []hotkeys.Assignment
+	if a.config != nil {
+		for _, t := range a.config.Tiles {
+			if t.Enabled {
+				assignments = append(assignments, hotkeys.Assignment{Owner: t.ID, Hotkey: t.Hotkey})
+			}
+		}
+	}
+
+	reserved := map[string]string{}
+	if a.hk != nil {
+		reserved["the panel hotkey"] = a.hkCombo
+	}
+	resolved, conflicts := hotkeys.Resolve(reserved, assignments)
+
+	wanted := make(map[string]hotkeys.Combo, len(resolved))
+	for _, r := range resolved {
+		wanted[r.Owner] = r.Combo
+	}
+
+	// Release registrations that were removed or changed
+	for id, th := range a.tileHotkeys {
+		if combo, ok := wanted[id]; !ok || combo != th.combo {
+			unregisterOnMainThread(th.hk)
+			delete(a.tileHotkeys, id)
+		}
+	}
+
+	for _, r := range resolved {
+		if _, ok := a.tileHotkeys[r.Owner]; ok {
+			continue
+		}
+
+		hk, err := newHotkey(r.Combo)
+		if err == nil {
+			err = registerOnMainThread(hk)
+		}
+		if err != nil {
+			conflicts = append(conflicts, hotkeys.Conflict{
+				Owner:  r.Owner,
+				Hotkey: r.Combo.String(),
+				Reason: err.Error(),
+			})
+			continue
+		}
+
+		a.tileHotkeys[r.Owner] = &tileHotkey{combo: r.Combo, hk: hk}
+		tileID := r.Owner
+		go listenHotkey(hk, func() { a.runTileHotkey(tileID) })
+	}
+
+	a.hkConflicts = conflicts
+	a.hkMu.Unlock()
+
+	if a.ctx != nil {
+		runtime.EventsEmit(a.ctx, "hotkeys:conflicts", conflicts)
 	}
 }
 
+// runTileHotkey executes a tile directly without opening the panel
+func (a *App) runTileHotkey(tileID string) {
+	err := a.ExecuteTile(tileID, "")
+
+	// Tiles that need input open the panel to ask for it
+	var missing *vars.MissingInputError
+	if errors.As(err, &missing) {
+		a.ShowPanel()
+		runtime.EventsEmit(a.ctx, "tile:input-required", tileID, missing.Prompts)
+		return
+	}
+	// Guarded tiles open the panel for the confirmation dialog
+	if errors.Is(err, guard.ErrConfirmationRequired) {
+		a.ShowPanel()
+		runtime.EventsEmit(a.ctx, "tile:confirm-required", tileID)
+		return
+	}
+	if err != nil {
+		println("Failed to execute tile", tileID+":", err.Error())
+	}
+}
+
+// unregisterHotkeys releases all global hotkeys
+func (a *App) unregisterHotkeys() {
+	a.hkMu.Lock()
+	defer a.hkMu.Unlock()
+
+	if a.hk != nil {
+		a.hk.Unregister()
+		a.hk = nil
+	}
+	for id, th := range a.tileHotkeys {
+		th.hk.Unregister()
+		delete(a.tileHotkeys, id)
+	}
+	a.hkReady = false
+}
+
 // positionWindow positions the window on the left edge of the primary screen
 func (a *App) positionWindow() {
 	// Position window on the left edge with fixed height
@@ -246,14 +483,107 @@ func (a *App) IsVisible() bool {
 	return a.isVisible
 }
 
-// ExecuteAction executes an action based on type
-func (a *App) ExecuteAction(actionType, target string) error {
-	return executeAction(actionType, target, "")
+// ExecuteTile executes a configured tile by ID, including its Args and
+// WorkDir. path is the optional folder chosen in the submenu. Tiles with
+// {input:...} placeholders return a *vars.MissingInputError; use
+// GetTilePrompts and ExecuteTileWithInputs for them.
+func (a *App) ExecuteTile(tileID, path string) error {
+	return a.ExecuteTileWithInputs(tileID, path, nil)
+}
+
+// ExecuteTileWithInputs executes a tile with the values entered for its
+// {input:...} placeholders, keyed by prompt
+func (a *App) ExecuteTileWithInputs(tileID, path string, inputs map[string]string) error {
+	return a.ExecuteTileConfirmed(tileID, path, inputs, "")
 }
 
-// ExecuteActionWithPath executes an action with a specific path
-func (a *App) ExecuteActionWithPath(actionType, target, path string) error {
-	return executeAction(actionType, target, path)
+// ExecuteTileConfirmed executes a tile with RequireConfirm using a token
+// from RequestConfirmation
+func (a *App) ExecuteTileConfirmed(tileID, path string, inputs map[string]string, token string) error {
+	tile, ok := a.findTile(tileID)
+	if !ok {
+		return fmt.Errorf("tile %q not found", tileID)
+	}
+	return a.executeTile(tile, launchInput{Path: path, Inputs: inputs, ConfirmToken: token})
+}
+
+// RequestConfirmation issues a token for one execution of a guarded tile.
+// The frontend requests it when the user confirms the dialog.
+func (a *App) RequestConfirmation(tileID string) (ConfirmRequest, error) {
+	tile, ok := a.findTile(tileID)
+	if !ok {
+		return ConfirmRequest{}, fmt.Errorf("tile %q not found", tileID)
+	}
+	token, expires, err := actions.Default.IssueConfirmation(tile.ID)
+	if err != nil {
+		return ConfirmRequest{}, err
+	}
+	return ConfirmRequest{
+		Token:     token,
+		TileID:    tile.ID,
+		Name:      tile.Name,
+		Action:    tile.Action,
+		Target:    tile.Target,
+		ExpiresAt: expires,
+	}, nil
+}
+
+// MatchKeyword matches search bar input like "gh quicklaunch" against the
+// keywords of the enabled tiles. It returns nil if no keyword matches.
+func (a *App) MatchKeyword(input string) *keywords.Match {
+	if a.config == nil {
+		return nil
+	}
+
+	var list []keywords.Keyword
+	for _, t := range a.config.Tiles {
+		if t.Enabled && t.Keyword != "" {
+			list = append(list, keywords.Keyword{Owner: t.ID, Keyword: t.Keyword})
+		}
+	}
+	m, ok := keywords.Find(list, input)
+	if !ok {
+		return nil
+	}
+	return &m
+}
+
+// ExecuteKeyword executes the tile whose keyword starts the search bar
+// input, with the rest of the input as {query}. inputs holds the values
+// for {input:...} placeholders of that tile and token confirms it if the
+// tile requires confirmation.
+func (a *App) ExecuteKeyword(input string, inputs map[string]string, token string) error {
+	m := a.MatchKeyword(input)
+	if m == nil {
+		return fmt.Errorf("no tile keyword matches %q", input)
+	}
+	tile, ok := a.findTile(m.Owner)
+	if !ok {
+		return fmt.Errorf("tile %q not found", m.Owner)
+	}
+	return a.executeTile(tile, launchInput{Query: m.Query, Inputs: inputs, ConfirmToken: token})
+}
+
+// GetTilePrompts returns the prompts of the {input:...} placeholders of a
+// tile, so the panel can ask for them before executing
+func (a *App) GetTilePrompts(tileID string) ([]string, error) {
+	tile, ok := a.findTile(tileID)
+	if !ok {
+		return nil, fmt.Errorf("tile %q not found", tileID)
+	}
+	return tilePrompts(tile), nil
+}
+
+// findTile returns the tile with the given ID
+func (a *App) findTile(id string) (config.Tile, bool) {
+	if a.config != nil {
+		for _, t := range a.config.Tiles {
+			if t.ID == id {
+				return t, true
+			}
+		}
+	}
+	return config.Tile{}, false
 }
 
 // OpenFolderDialog opens a native folder selection dialog
@@ -308,6 +638,224 @@ func (a *App) UpdateConfig(theme, position string, animation, blur bool, recentF
 	return nil
 }
 
+// --- Hotkey Methods ---
+
+// GetHotkey returns the configured global hotkey
+func (a *App) GetHotkey() string {
+	if a.config != nil && a.config.Hotkey != "" {
+		return a.config.Hotkey
+	}
+	return defaultHotkey
+}
+
+// ValidateHotkey checks a hotkey string and returns its canonical form
+func (a *App) ValidateHotkey(combo string) (string, error) {
+	return hotkeys.Normalize(combo)
+}
+
+// SetHotkey registers a new global hotkey and saves it to config.
+// The old hotkey is released without restarting the app.
+func (a *App) SetHotkey(combo string) error {
+	if parsed, err := hotkeys.Parse(combo); err == nil && a.config != nil {
+		for _, t := range a.config.Tiles {
+			if other, err := hotkeys.Parse(t.Hotkey); err == nil && other == parsed {
+				return fmt.Errorf("%s is already used by tile %q", parsed, t.Name)
+			}
+		}
+	}
+
+	normalized, err := a.setPanelHotkey(combo)
+	if err != nil {
+		return err
+	}
+
+	if a.trayManager != nil {
+		a.trayManager.SetHotkey(normalized)
+	}
+
+	// Tile hotkeys may collide with the new panel hotkey
+	a.syncTileHotkeys()
+
+	if a.config != nil {
+		a.config.Hotkey = normalized
+		return a.config.Save()
+	}
+	return nil
+}
+
+// GetHotkeyConflicts returns tile hotkeys that could not be registered
+func (a *App) GetHotkeyConflicts() []hotkeys.Conflict {
+	a.hkMu.Lock()
+	defer a.hkMu.Unlock()
+
+	if a.hkConflicts == nil {
+		return []hotkeys.Conflict{}
+	}
+	return a.hkConflicts
+}
+
+// --- Launcher Methods ---
+
+// GetRunResults returns the results of background runs, newest first.
+// A non-empty tileID restricts the results to that tile.
+func (a *App) GetRunResults(tileID string) []runner.Result {
+	return a.runner.History(tileID)
+}
+
+// GetRunningProcesses returns the live processes started by tiles, oldest
+// first. A non-empty tileID restricts the list to that tile.
+func (a *App) GetRunningProcesses(tileID string) []procs.Process {
+	return a.procs.List(tileID)
+}
+
+// StopTile cancels the running workflows of a tile and terminates its
+// processes and their children. Processes still running after stopTimeout
+// are killed. Services are not restarted.
+func (a *App) StopTile(tileID string) error {
+	if a.services.Supervises(tileID) {
+		return a.services.Stop(tileID, stopTimeout)
+	}
+	cancelled := a.cancelWorkflows(tileID)
+	err := a.procs.Stop(tileID, stopTimeout)
+	if cancelled && errors.Is(err, procs.ErrNotRunning) {
+		return nil
+	}
+	return err
+}
+
+// GetServices returns the state of all services started since launch
+func (a *App) GetServices() []service.State {
+	return a.services.States()
+}
+
+// GetServiceLogs returns the recent output of a service tile
+func (a *App) GetServiceLogs(tileID string) ([]service.LogLine, error) {
+	return a.services.Logs(tileID)
+}
+
+// RestartService stops a service tile if it is running and starts it again
+func (a *App) RestartService(tileID string) error {
+	tile, ok := a.findTile(tileID)
+	if !ok {
+		return fmt.Errorf("tile %q not found", tileID)
+	}
+	if err := a.services.Stop(tileID, stopTimeout); err != nil && !errors.Is(err, service.ErrUnknownService) {
+		return err
+	}
+	return a.executeTile(tile, launchInput{})
+}
+
+// GetRunHistory returns logged launches matching the filter, newest first
+func (a *App) GetRunHistory(filter runlog.Filter) ([]runlog.Entry, error) {
+	if a.runLog == nil {
+		return nil, nil
+	}
+	return a.runLog.Query(filter)
+}
+
+// RerunHistoryEntry launches a logged entry again. Entries of tiles use the
+// current tile configuration and are refused once the tile is gone, since
+// its guard is gone with it; token confirms tiles that require confirmation.
+func (a *App) RerunHistoryEntry(id string, token string) error {
+	if a.runLog == nil {
+		return fmt.Errorf("run log not available")
+	}
+	entry, ok, err := a.runLog.Find(id)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("run log entry %q not found", id)
+	}
+
+	if entry.TileID != "" {
+		tile, ok := a.findTile(entry.TileID)
+		if !ok {
+			return fmt.Errorf("tile %q no longer exists", entry.TileID)
+		}
+		return a.executeTile(tile, launchInput{Path: entry.Path, Query: entry.Query, ConfirmToken: token})
+	}
+	return a.executeTile(config.Tile{Action: entry.Action, Target: entry.Target}, launchInput{Path: entry.Path, Query: entry.Query})
+}
+
+// GetCommandPolicy returns the allow- and denylist for commands
+func (a *App) GetCommandPolicy() config.CommandPolicy {
+	if a.config == nil {
+		return config.CommandPolicy{}
+	}
+	return a.config.CommandPolicy
+}
+
+// SaveCommandPolicy validates, applies and saves the command policy
+func (a *App) SaveCommandPolicy(policy config.CommandPolicy) error {
+	for _, p := range append(policy.Allow, policy.Deny...) {
+		if strings.TrimSpace(p) == "" {
+			return fmt.Errorf("empty command pattern")
+		}
+	}
+
+	configurePolicy(policy)
+	if a.config != nil {
+		a.config.CommandPolicy = policy
+		return a.config.Save()
+	}
+	return nil
+}
+
+// GetLaunchCapabilities reports which optional launch features, such as
+// elevated app and shell tiles, this system supports
+func (a *App) GetLaunchCapabilities() launcher.Capabilities {
+	return actions.Default.Capabilities()
+}
+
+// GetActionTypes returns the registered action types for the tile editor
+func (a *App) GetActionTypes() []actions.Description {
+	return actions.Default.Descriptions()
+}
+
+// SetTerminal sets the terminal emulator used for shell tiles on Linux
+func (a *App) SetTerminal(terminal string) error {
+	configureExecutor(terminal)
+	if a.config != nil {
+		a.config.Terminal = terminal
+		return a.config.Save()
+	}
+	return nil
+}
+
+// GetShellKinds returns the shell kinds that tiles and profiles may use
+func (a *App) GetShellKinds() []launcher.ShellKind {
+	return launcher.ShellKinds
+}
+
+// GetTerminalProfiles returns the configured terminal profiles
+func (a *App) GetTerminalProfiles() []config.TerminalProfile {
+	if a.config != nil {
+		return a.config.TerminalProfiles
+	}
+	return config.DefaultTerminalProfiles()
+}
+
+// SaveTerminalProfiles saves the terminal profiles and the default profile ID
+func (a *App) SaveTerminalProfiles(profiles []config.TerminalProfile, defaultID string) error {
+	if a.config != nil {
+		a.config.TerminalProfiles = profiles
+		a.config.DefaultTerminalProfile = defaultID
+		return a.config.Save()
+	}
+	return nil
+}
+
+// SetKeepTileOrder switches between the manual tile order and ranking
+// frequently used tiles first
+func (a *App) SetKeepTileOrder(keep bool) error {
+	if a.config == nil {
+		return nil
+	}
+	a.config.KeepTileOrder = keep
+	return a.config.Save()
+}
+
 // --- Tile Methods ---
 
 // GetTiles returns all tiles from config
@@ -322,6 +870,7 @@ func (a *App) GetTiles() []config.Tile {
 func (a *App) SaveTiles(tiles []config.Tile) error {
 	if a.config != nil {
 		a.config.Tiles = tiles
+		defer a.syncTileHotkeys()
 		return a.config.Save()
 	}
 	return nil
@@ -331,6 +880,7 @@ func (a *App) SaveTiles(tiles []config.Tile) error {
 func (a *App) AddTile(tile config.Tile) error {
 	if a.config != nil {
 		a.config.Tiles = append(a.config.Tiles, tile)
+		defer a.syncTileHotkeys()
 		return a.config.Save()
 	}
 	return nil
@@ -342,6 +892,7 @@ func (a *App) UpdateTile(id string, tile config.Tile) error {
 		for i, t := range a.config.Tiles {
 			if t.ID == id {
 				a.config.Tiles[i] = tile
+				defer a.syncTileHotkeys()
 				return a.config.Save()
 			}
 		}
@@ -355,6 +906,10 @@ func (a *App) RemoveTile(id string) error {
 		for i, t := range a.config.Tiles {
 			if t.ID == id {
 				a.config.Tiles = append(a.config.Tiles[:i], a.config.Tiles[i+1:]...)
+				a.frecency.Delete(tileKey(id))
+				a.frecency.Forget(pathPrefix(id))
+				a.saveFrecency()
+				defer a.syncTileHotkeys()
 				return a.config.Save()
 			}
 		}
@@ -380,18 +935,18 @@ func (a *App) AddRecentItem(tileID string, item config.RecentItem) error {
 				}
 			}
 
-			// Add new item at the beginning
-			a.config.Tiles[i].SubMenuItems = append([]config.RecentItem{item}, filtered...)
+			// Add new item at the beginning, then rank by frecency
+			a.frecency.Touch(pathKey(tileID, item.Path))
+			items := append([]config.RecentItem{item}, filtered...)
 
 			// Limit to RecentFoldersLimit
 			limit := a.config.RecentFoldersLimit
 			if limit <= 0 {
 				limit = 5
 			}
-			if len(a.config.Tiles[i].SubMenuItems) > limit {
-				a.config.Tiles[i].SubMenuItems = a.config.Tiles[i].SubMenuItems[:limit]
-			}
+			a.config.Tiles[i].SubMenuItems = a.sortRecentItems(tileID, items, item.Path, limit)
 
+			a.saveFrecency()
 			return a.config.Save()
 		}
 	}
@@ -421,6 +976,7 @@ func (a *App) ClearRecentItems(tileID string) error {
 		for i := range a.config.Tiles {
 			a.config.Tiles[i].SubMenuItems = []config.RecentItem{}
 		}
+		a.frecency.Forget(pathKeyPrefix)
 	} else {
 		// Clear specific tile
 		for i, t := range a.config.Tiles {
@@ -429,7 +985,9 @@ func (a *App) ClearRecentItems(tileID string) error {
 				break
 			}
 		}
+		a.frecency.Forget(pathPrefix(tileID))
 	}
+	a.saveFrecency()
 
 	return a.config.Save()
 }
@@ -487,9 +1045,7 @@ func (a *App) RestartApp() {
 	}
 
 	// Unregister hotkey before restart
-	if a.hk != nil {
-		a.hk.Unregister()
-	}
+	a.unregisterHotkeys()
 
 	// Stop focus monitor
 	if a.focusMonitor != nil {
@@ -1,18 +1,30 @@
 package main
 
 import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
+
+	"quicklaunch/internal/actions"
+	"quicklaunch/internal/config"
+	"quicklaunch/internal/guard"
+	"quicklaunch/internal/procs"
+	"quicklaunch/internal/runlog"
+	"quicklaunch/internal/vars"
 )
 
 func TestNewApp(t *testing.T) {
-	app := NewApp()
+	app := newTestApp(t)
 	if app == nil {
 		t.Error("NewApp() returned nil")
 	}
 }
 
 func TestTogglePanel(t *testing.T) {
-	app := NewApp()
+	app := newTestApp(t)
 
 	// Initially not visible
 	if app.IsVisible() {
@@ -31,11 +43,333 @@ func TestTogglePanel(t *testing.T) {
 	}
 }
 
-func TestExecuteAction(t *testing.T) {
-	// Test that executeAction doesn't panic with empty inputs
+func TestExecuteTileErrors(t *testing.T) {
+	// Unknown action types must be reported instead of silently ignored
 	// Note: actual execution would require OS interaction
-	err := executeAction("internal", "settings", "")
+	app := newTestApp(t)
+	app.config.Tiles = []config.Tile{
+		{ID: "settings", Action: "internal", Target: "settings"},
+		{ID: "empty", Action: "url"},
+	}
+	err := app.ExecuteTile("settings", "")
+	var unknown *actions.UnknownActionError
+	if !errors.As(err, &unknown) {
+		t.Errorf("executeAction for internal settings should return UnknownActionError, got %v", err)
+	}
+
+	// Validation runs before anything is launched
+	if err := app.ExecuteTile("empty", ""); !errors.Is(err, actions.ErrMissingTarget) {
+		t.Errorf("executeAction for url without target should return ErrMissingTarget, got %v", err)
+	}
+
+	// Failed launches end up in the run log
+	entries, err := app.GetRunHistory(runlog.Filter{Status: runlog.StatusFailed})
+	if err != nil {
+		t.Fatalf("GetRunHistory returned error: %v", err)
+	}
+	if len(entries) != 2 || entries[0].Action != "url" || entries[1].Action != "internal" {
+		t.Errorf("unexpected run log entries %+v", entries)
+	}
+}
+
+func TestExecuteTileNotFound(t *testing.T) {
+	app := newTestApp(t)
+	if err := app.ExecuteTile("does-not-exist", ""); err == nil {
+		t.Error("ExecuteTile for unknown tile should return an error")
+	}
+}
+
+func TestExecuteTileMissingInput(t *testing.T) {
+	app := newTestApp(t)
+	app.config.Tiles = append(app.config.Tiles, config.Tile{
+		ID:     "jira",
+		Action: "url",
+		Target: "https://jira/browse/{input:Ticket}",
+	})
+
+	prompts, err := app.GetTilePrompts("jira")
+	if err != nil || len(prompts) != 1 || prompts[0] != "Ticket" {
+		t.Fatalf("GetTilePrompts = %v, %v", prompts, err)
+	}
+
+	var missing *vars.MissingInputError
+	if err := app.ExecuteTile("jira", ""); !errors.As(err, &missing) {
+		t.Errorf("ExecuteTile without input should return MissingInputError, got %v", err)
+	}
+}
+
+func TestMatchKeyword(t *testing.T) {
+	app := newTestApp(t)
+	app.config.Tiles = append(app.config.Tiles,
+		config.Tile{ID: "gh-search", Action: "search", Target: "https://github.com/search?q={query}", Keyword: "gh", Enabled: true},
+		config.Tile{ID: "disabled", Action: "search", Target: "https://example.com/{query}", Keyword: "ex"},
+	)
+
+	m := app.MatchKeyword("gh quicklaunch")
+	if m == nil || m.Owner != "gh-search" || m.Query != "quicklaunch" {
+		t.Errorf("MatchKeyword = %+v", m)
+	}
+	if m := app.MatchKeyword("ex foo"); m != nil {
+		t.Errorf("disabled tiles should not match, got %+v", m)
+	}
+	if err := app.ExecuteKeyword("nothing here", nil, ""); err == nil {
+		t.Error("ExecuteKeyword without a matching keyword should return an error")
+	}
+}
+
+func TestRequireConfirm(t *testing.T) {
+	app := newTestApp(t)
+	app.config.Tiles = append(app.config.Tiles, config.Tile{
+		ID:             "deploy",
+		Name:           "Deploy",
+		Action:         "url",
+		Target:         "https://example.com/deploy",
+		RequireConfirm: true,
+	})
+
+	if err := app.ExecuteTile("deploy", ""); !errors.Is(err, guard.ErrConfirmationRequired) {
+		t.Fatalf("expected ErrConfirmationRequired, got %v", err)
+	}
+
+	req, err := app.RequestConfirmation("deploy")
+	if err != nil || req.Token == "" || req.Name != "Deploy" {
+		t.Fatalf("RequestConfirmation = %+v, %v", req, err)
+	}
+	if err := app.ExecuteTileConfirmed("deploy", "", nil, "forged"); !errors.Is(err, guard.ErrInvalidToken) {
+		t.Errorf("expected ErrInvalidToken for a forged token, got %v", err)
+	}
+
+	// Asking for confirmation is not logged as a launch
+	entries, _ := app.GetRunHistory(runlog.Filter{TileID: "deploy"})
+	if len(entries) != 1 {
+		t.Errorf("expected only the rejected token to be logged, got %+v", entries)
+	}
+}
+
+func TestRerunDeletedTile(t *testing.T) {
+	app := newTestApp(t)
+	app.config.Tiles = []config.Tile{{
+		ID:             "deploy",
+		Name:           "Deploy",
+		Action:         "url",
+		Target:         "https://example.com/deploy",
+		RequireConfirm: true,
+	}}
+
+	if err := app.ExecuteTileConfirmed("deploy", "", nil, "forged"); !errors.Is(err, guard.ErrInvalidToken) {
+		t.Fatalf("expected ErrInvalidToken, got %v", err)
+	}
+	entries, _ := app.GetRunHistory(runlog.Filter{TileID: "deploy"})
+	if len(entries) != 1 {
+		t.Fatalf("expected the rejected launch to be logged, got %+v", entries)
+	}
+
+	// Without the tile there is no guard left to ask, so nothing is run
+	app.config.Tiles = nil
+	err := app.RerunHistoryEntry(entries[0].ID, "")
+	if err == nil || !strings.Contains(err.Error(), "no longer exists") {
+		t.Errorf("expected the entry of a deleted tile to be refused, got %v", err)
+	}
+}
+
+func TestStopWorkflow(t *testing.T) {
+	app := newTestApp(t)
+	app.config.Tiles = []config.Tile{{
+		ID:     "morning",
+		Name:   "Morgenroutine",
+		Action: "workflow",
+		Steps:  []config.WorkflowStep{{Action: "url", Target: "https://example.com", DelayMs: 3600000}},
+	}}
+
+	if err := app.ExecuteTile("morning", ""); err != nil {
+		t.Fatalf("ExecuteTile returned error: %v", err)
+	}
+	if err := app.StopTile("morning"); err != nil {
+		t.Errorf("stopping a waiting workflow should succeed, got %v", err)
+	}
+	if err := app.StopTile("morning"); !errors.Is(err, procs.ErrNotRunning) {
+		t.Errorf("the workflow should be gone after stopping it, got %v", err)
+	}
+}
+
+func TestSearch(t *testing.T) {
+	app := newTestApp(t)
+	app.config.Tiles = []config.Tile{
+		{ID: "invoices", Name: "Rechnungen", Action: "folder", Tags: []string{"Büro"}, Enabled: true,
+			SubMenuItems: []config.RecentItem{{Name: "Übersicht 2025", Path: `D:\Büro\Übersicht 2025`}}},
+		{ID: "hidden", Name: "Übersicht", Action: "folder"},
+	}
+
+	results := app.Search("ubers")
+	if len(results) != 1 || results[0].Kind != SearchKindRecent || results[0].TileID != "invoices" {
+		t.Fatalf("expected the recent item only, got %+v", results)
+	}
+	if results := app.Search("buro"); len(results) != 2 || results[0].Kind != SearchKindTile {
+		t.Errorf("the tagged tile should rank before the recent item, got %+v", results)
+	}
+}
+
+// newTestApp creates an App with a temporary config directory, so tests
+// neither read nor write the user's configuration
+func newTestApp(t *testing.T) *App {
+	t.Helper()
+	// os.UserConfigDir looks at one of these depending on the OS
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	t.Setenv("APPDATA", dir)
+	t.Setenv("HOME", dir)
+	return NewApp()
+}
+
+func TestFrecencyOrder(t *testing.T) {
+	app := newTestApp(t)
+	app.config.KeepTileOrder = false
+	app.config.Tiles = []config.Tile{
+		{ID: "a", Name: "A", Order: 0, Enabled: true},
+		{ID: "b", Name: "B", Order: 1, Enabled: true},
+	}
+
+	app.frecency.Touch(tileKey("b"))
+	if results := app.Search(""); len(results) != 2 || results[0].TileID != "b" {
+		t.Errorf("the used tile should come first, got %+v", results)
+	}
+	app.config.KeepTileOrder = true
+	if results := app.Search(""); results[0].TileID != "a" {
+		t.Errorf("manual order should be kept, got %+v", results)
+	}
+
+	items := []config.RecentItem{{Path: "/new"}, {Path: "/often"}, {Path: "/once"}}
+	for i := 0; i < 3; i++ {
+		app.frecency.Touch(pathKey("a", "/often"))
+	}
+	app.frecency.Touch(pathKey("a", "/once"))
+	app.frecency.Touch(pathKey("a", "/once"))
+	app.frecency.Touch(pathKey("a", "/new"))
+
+	got := app.sortRecentItems("a", items, "/new", 2)
+	if len(got) != 2 || got[0].Path != "/often" || got[1].Path != "/new" {
+		t.Errorf("sortRecentItems = %+v", got)
+	}
+}
+
+func TestRemoveTileFrecency(t *testing.T) {
+	app := newTestApp(t)
+	app.config.Tiles = []config.Tile{{ID: "a", Name: "A"}, {ID: "ab", Name: "AB"}}
+	app.frecency.Touch(tileKey("a"))
+	app.frecency.Touch(tileKey("ab"))
+
+	if err := app.RemoveTile("a"); err != nil {
+		t.Fatalf("RemoveTile returned error: %v", err)
+	}
+	if app.frecency.Score(tileKey("a")) != 0 || app.frecency.Score(tileKey("ab")) == 0 {
+		t.Errorf("only the removed tile should lose its score, a=%v ab=%v",
+			app.frecency.Score(tileKey("a")), app.frecency.Score(tileKey("ab")))
+	}
+}
+
+func TestSearchAll(t *testing.T) {
+	app := newTestApp(t)
+	app.config.Tiles = []config.Tile{
+		{ID: "invoices", Name: "Rechnungen", Action: "folder", Icon: "Folder", Enabled: true,
+			SubMenuItems: []config.RecentItem{{Name: "Rechnungen 2025", Path: `D:\Rechnungen 2025`}}},
+	}
+
+	resp, err := app.SearchAll("rechnungen")
 	if err != nil {
-		t.Errorf("executeAction for internal settings should not return error: %v", err)
+		t.Fatalf("SearchAll returned error: %v", err)
+	}
+	if len(resp.Results) != 2 || resp.Results[0].Provider != providerTiles || resp.Results[0].Icon != "Folder" ||
+		resp.Results[1].Provider != providerRecent || resp.Results[1].Path != `D:\Rechnungen 2025` {
+		t.Fatalf("expected the tile and its recent item, got %+v", resp.Results)
+	}
+
+	resp, _ = app.SearchAll("5 km in mi")
+	if len(resp.Results) == 0 || resp.Results[0].Provider != providerCalc || resp.Results[0].ID != "3.106855961" {
+		t.Errorf("the conversion should come first, got %+v", resp.Results)
+	}
+
+	app.providers.Configure(providerSettings(map[string]config.SearchProvider{providerRecent: {Disabled: true}}))
+	if resp, _ := app.SearchAll("rechnungen"); len(resp.Results) != 1 {
+		t.Errorf("disabled providers should not answer, got %+v", resp.Results)
+	}
+	if err := app.SetSearchProviderEnabled("nope", true); err == nil {
+		t.Error("unknown providers should be rejected")
+	}
+}
+
+func TestExecuteResultConfirm(t *testing.T) {
+	app := newTestApp(t)
+	app.config.Tiles = []config.Tile{{
+		ID:             "deploy",
+		Name:           "Deploy",
+		Action:         "url",
+		Target:         "https://example.com/deploy?env={input:Umgebung}",
+		Enabled:        true,
+		RequireConfirm: true,
+	}}
+
+	resp, _ := app.SearchAll("deploy")
+	if len(resp.Results) == 0 || resp.Results[0].Provider != providerTiles {
+		t.Fatalf("expected the tile, got %+v", resp.Results)
+	}
+	result := resp.Results[0]
+
+	// The frontend can tell what is missing and execute again
+	var missing *vars.MissingInputError
+	if err := app.ExecuteResult(result, nil, ""); !errors.As(err, &missing) {
+		t.Errorf("expected MissingInputError, got %v", err)
+	}
+	inputs := map[string]string{"Umgebung": "prod"}
+	if err := app.ExecuteResult(result, inputs, ""); !errors.Is(err, guard.ErrConfirmationRequired) {
+		t.Errorf("expected ErrConfirmationRequired, got %v", err)
+	}
+	if err := app.ExecuteResult(result, inputs, "forged"); !errors.Is(err, guard.ErrInvalidToken) {
+		t.Errorf("expected the token to be checked, got %v", err)
+	}
+}
+
+func TestRunScript(t *testing.T) {
+	app := newTestApp(t)
+	dir := t.TempDir()
+	path := filepath.Join(dir, "vpn.star")
+	src := "if tile().name != \"VPN\":\n    fail(\"wrong tile\")\nrun_tile(\"Intranet\")\n"
+	if err := os.WriteFile(path, []byte(src), 0644); err != nil {
+		t.Fatal(err)
+	}
+	quiet := filepath.Join(dir, "quiet.star")
+	if err := os.WriteFile(quiet, []byte("x = 1\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	app.config.Tiles = []config.Tile{
+		{ID: "vpn", Name: "VPN", Action: "script", Target: path, Enabled: true},
+		{ID: "intranet", Name: "Intranet", Action: "script", Target: path, Enabled: true},
+		{ID: "quiet", Name: "Quiet", Action: "script", Target: quiet, Enabled: true},
+	}
+
+	err := actions.Default.Execute("script", actions.Request{TileID: "vpn", Target: path, Wait: true})
+	if err == nil || !strings.Contains(err.Error(), "scripts cannot start scripts") {
+		t.Errorf("expected the script to run and be refused starting another one, got %v", err)
+	}
+	if err := actions.Default.Execute("script", actions.Request{Target: filepath.Join(dir, "missing.star")}); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("expected a missing script to fail at once, got %v", err)
+	}
+
+	// Tiles start scripts in the background, which log once they ended
+	if err := app.ExecuteTile("quiet", ""); err != nil {
+		t.Fatalf("script tiles should start in the background, got %v", err)
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		entries, _ := app.GetRunHistory(runlog.Filter{TileID: "quiet"})
+		if len(entries) == 1 {
+			if entries[0].Status != runlog.StatusSucceeded {
+				t.Errorf("unexpected run log entry %+v", entries[0])
+			}
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("the script did not finish, run log %+v", entries)
+		}
+		time.Sleep(10 * time.Millisecond)
 	}
 }
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"quicklaunch/internal/actions"
+	"quicklaunch/internal/config"
+	"quicklaunch/internal/desktop"
+	"quicklaunch/internal/search"
+)
+
+// applicationTileIcon is the icon of tiles created from installed
+// applications
+const applicationTileIcon = "Monitor"
+
+// ApplicationResult is an installed application matching a search, with
+// a tile that launches it. The tile has no ID until it is added.
+type ApplicationResult struct {
+	App        desktop.Entry      `json:"app"`
+	Score      int                `json:"score"`
+	Highlights []search.Highlight `json:"highlights"`
+	Tile       config.Tile        `json:"tile"`
+}
+
+// SearchApplications searches the installed desktop applications by name,
+// generic name, keywords and comment. An empty query returns all of them
+// by name. Only Linux desktops have a catalog.
+func (a *App) SearchApplications(query string) []ApplicationResult {
+	apps := actions.Applications.Entries()
+
+	if strings.TrimSpace(query) == "" {
+		results := make([]ApplicationResult, 0, len(apps))
+		for _, app := range apps {
+			results = append(results, ApplicationResult{App: app, Highlights: []search.Highlight{}, Tile: applicationTile(app)})
+		}
+		return results
+	}
+
+	entries := make([]search.Entry, len(apps))
+	for i, app := range apps {
+		entries[i] = search.Entry{Fields: []search.Field{
+			{Name: "name", Text: app.Name, Weight: 100},
+			{Name: "genericName", Text: app.GenericName, Weight: 70},
+			{Name: "keywords", Text: strings.Join(app.Keywords, " "), Weight: 70},
+			{Name: "comment", Text: app.Comment, Weight: 40},
+		}}
+	}
+
+	hits := a.appIndex.Rank(query, entries, searchLimit)
+	results := make([]ApplicationResult, 0, len(hits))
+	for _, hit := range hits {
+		app := apps[hit.Index]
+		results = append(results, ApplicationResult{App: app, Score: hit.Score, Highlights: hit.Highlights, Tile: applicationTile(app)})
+	}
+	return results
+}
+
+// AddApplicationTile adds a tile for an installed application and returns
+// it
+func (a *App) AddApplicationTile(id string) (config.Tile, error) {
+	app, err := actions.Applications.Find(id)
+	if err != nil {
+		return config.Tile{}, fmt.Errorf("application %q: %w", id, err)
+	}
+
+	tile := applicationTile(app)
+	tile.ID = fmt.Sprintf("tile-%d", time.Now().UnixMilli())
+	if a.config != nil {
+		tile.Order = len(a.config.Tiles)
+	}
+	return tile, a.AddTile(tile)
+}
+
+// applicationTile returns a tile launching an installed application
+func applicationTile(app desktop.Entry) config.Tile {
+	return config.Tile{
+		Name:    app.Name,
+		Icon:    applicationTileIcon,
+		Action:  "desktop",
+		Target:  app.ID,
+		Tags:    app.Keywords,
+		Enabled: true,
+	}
+}
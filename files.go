@@ -0,0 +1,90 @@
+package main
+
+import (
+	"os"
+	"time"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+
+	"quicklaunch/internal/actions"
+	"quicklaunch/internal/config"
+	"quicklaunch/internal/fileindex"
+)
+
+// newFileIndex creates the file index of the configured roots
+func (a *App) newFileIndex(cfg config.FileIndex) *fileindex.Index {
+	dir, _ := config.GetConfigDir()
+	return fileindex.New(fileindex.Options{
+		Roots:  cfg.Roots,
+		Ignore: cfg.Ignore,
+		Dir:    dir,
+	}, a.fileIndexChanged)
+}
+
+// fileIndexChanged tells the frontend that indexing started or finished
+func (a *App) fileIndexChanged() {
+	if a.ctx != nil {
+		runtime.EventsEmit(a.ctx, "fileindex:changed")
+	}
+}
+
+// fileIndex returns the current file index
+func (a *App) fileIndex() *fileindex.Index {
+	a.filesMu.Lock()
+	defer a.filesMu.Unlock()
+	return a.files
+}
+
+// SearchFiles searches the indexed files and folders by name and path
+func (a *App) SearchFiles(query string) []fileindex.Result {
+	return a.fileIndex().Search(query, searchLimit)
+}
+
+// GetFileIndexStatus returns the roots and progress of the file index
+func (a *App) GetFileIndexStatus() fileindex.Status {
+	return a.fileIndex().Status()
+}
+
+// SaveFileIndexSettings changes the indexed roots and ignore patterns and
+// indexes them again
+func (a *App) SaveFileIndexSettings(settings config.FileIndex) error {
+	a.filesMu.Lock()
+	// The old index must be saved before the new one replaces the file
+	if err := a.files.Close(); err != nil {
+		println("Failed to save file index:", err.Error())
+	}
+	a.files = a.newFileIndex(settings)
+	a.files.Start()
+	a.filesMu.Unlock()
+
+	if a.config == nil {
+		return nil
+	}
+	a.config.FileIndex = settings
+	return a.config.Save()
+}
+
+// OpenFile opens a file or folder found by SearchFiles. Programs start
+// like app tiles and desktop entries like desktop tiles; everything else,
+// including scripts, opens like a folder tile, i.e. with the file manager
+// or the default program for the file type.
+func (a *App) OpenFile(path string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	tile := config.Tile{Action: "folder", Target: path}
+	switch {
+	case fileindex.IsProgram(path, info):
+		tile.Action = "app"
+	case fileindex.IsDesktopEntry(path, info):
+		tile.Action = "desktop"
+	}
+
+	// Without placeholder values the path is used as is, file names may
+	// contain braces
+	started := time.Now()
+	err = actions.Default.Execute(tile.Action, actions.Request{Target: path})
+	a.logLaunch(tile, launchInput{}, started, err)
+	return err
+}
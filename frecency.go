@@ -0,0 +1,94 @@
+package main
+
+import (
+	"math"
+	"sort"
+
+	"quicklaunch/internal/config"
+)
+
+// frecencyBoostWeight scales the search boost of frequently used tiles and
+// paths; frecencyBoostMax keeps it below a few matched characters
+const (
+	frecencyBoostWeight = 10
+	frecencyBoostMax    = 30
+)
+
+// tileKey is the frecency key of a tile
+func tileKey(tileID string) string {
+	return "tile:" + tileID
+}
+
+// pathKey is the frecency key of a recent path of a tile
+func pathKey(tileID, path string) string {
+	return pathPrefix(tileID) + path
+}
+
+// pathKeyPrefix starts the keys of all recent paths
+const pathKeyPrefix = "path:"
+
+// pathPrefix is the common prefix of the path keys of a tile
+func pathPrefix(tileID string) string {
+	return pathKeyPrefix + tileID + ":"
+}
+
+// touchTile records a launch of a tile
+func (a *App) touchTile(tileID string) {
+	a.frecency.Touch(tileKey(tileID))
+	a.saveFrecency()
+}
+
+// saveFrecency persists the frecency store, failures are only logged
+func (a *App) saveFrecency() {
+	if err := a.frecency.Save(); err != nil {
+		println("Failed to save frecency:", err.Error())
+	}
+}
+
+// frecencyBoost converts a frecency score to a search score boost
+func frecencyBoost(score float64) int {
+	return min(int(math.Round(frecencyBoostWeight*math.Log1p(score))), frecencyBoostMax)
+}
+
+// sortRecentItems orders recent items by frecency, most used first, and
+// truncates them to limit. keep is never dropped, so a new item survives
+// even if older items have a higher score.
+func (a *App) sortRecentItems(tileID string, items []config.RecentItem, keep string, limit int) []config.RecentItem {
+	scores := make(map[string]float64, len(items))
+	for _, item := range items {
+		scores[item.Path] = a.frecency.Score(pathKey(tileID, item.Path))
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		return scores[items[i].Path] > scores[items[j].Path]
+	})
+
+	if len(items) <= limit {
+		return items
+	}
+	for i := limit; i < len(items); i++ {
+		if items[i].Path == keep {
+			items[limit-1] = items[i]
+			break
+		}
+	}
+	return items[:limit]
+}
+
+// sortTiles orders tiles manually, and by frecency first unless the
+// configuration keeps the manual order
+func (a *App) sortTiles(tiles []config.Tile) {
+	sort.SliceStable(tiles, func(i, j int) bool {
+		return tiles[i].Order < tiles[j].Order
+	})
+	if a.config != nil && a.config.KeepTileOrder {
+		return
+	}
+
+	scores := make(map[string]float64, len(tiles))
+	for _, tile := range tiles {
+		scores[tile.ID] = a.frecency.Score(tileKey(tile.ID))
+	}
+	sort.SliceStable(tiles, func(i, j int) bool {
+		return scores[tiles[i].ID] > scores[tiles[j].ID]
+	})
+}
@@ -0,0 +1,128 @@
+import { useEffect, useState } from 'react'
+import { HardDrive, FolderPlus, X, AlertCircle, RefreshCw } from 'lucide-react'
+import { useFileIndexStore } from '@/stores/fileIndexStore'
+import { OpenFolderDialog } from '../../wailsjs/go/main/App'
+
+// Roots and ignore patterns of the file search with the indexing progress
+export function FileIndexSettings() {
+  const { status, ignore, error, loadSettings, saveSettings } = useFileIndexStore()
+  const [roots, setRoots] = useState<string[]>([])
+  const [ignoreText, setIgnoreText] = useState('')
+
+  useEffect(() => {
+    loadSettings()
+  }, [loadSettings])
+
+  // Take over the saved settings once they are loaded
+  const savedRoots = (status?.roots || []).join('\n')
+  useEffect(() => {
+    setRoots(savedRoots ? savedRoots.split('\n') : [])
+  }, [savedRoots])
+  useEffect(() => {
+    setIgnoreText(ignore.join('\n'))
+  }, [ignore])
+
+  const ignorePatterns = ignoreText
+    .split('\n')
+    .map((p) => p.trim())
+    .filter(Boolean)
+  const changed = roots.join('\n') !== savedRoots || ignorePatterns.join('\n') !== ignore.join('\n')
+
+  const handleAddRoot = async () => {
+    try {
+      const path = await OpenFolderDialog()
+      if (path && !roots.includes(path)) setRoots([...roots, path])
+    } catch (err) {
+      console.error('Error selecting folder:', err)
+    }
+  }
+
+  return (
+    <div>
+      <label
+        className="flex items-center text-xs font-medium text-[var(--text-secondary)]"
+        style={{ gap: '8px', marginBottom: '8px' }}
+      >
+        <HardDrive size={14} /> Dateisuche
+      </label>
+
+      {/* Indexed folders */}
+      <div className="flex flex-col" style={{ gap: '4px' }}>
+        {roots.map((root) => (
+          <div
+            key={root}
+            className="flex items-center bg-[var(--bg-secondary)] rounded-lg"
+            style={{ gap: '8px', padding: '8px 10px' }}
+          >
+            <span className="text-xs text-[var(--text-primary)] truncate flex-1" title={root}>
+              {root}
+            </span>
+            <button
+              onClick={() => setRoots(roots.filter((r) => r !== root))}
+              className="shrink-0 text-[var(--text-secondary)] hover:text-[var(--text-primary)] transition-colors"
+              title="Ordner entfernen"
+            >
+              <X size={14} />
+            </button>
+          </div>
+        ))}
+        <button
+          onClick={handleAddRoot}
+          className="flex items-center justify-center bg-[var(--bg-secondary)] hover:bg-[var(--bg-tertiary)] text-[var(--text-primary)] rounded-lg text-xs font-medium transition-colors"
+          style={{ gap: '6px', padding: '8px' }}
+        >
+          <FolderPlus size={12} />
+          Ordner hinzufügen
+        </button>
+      </div>
+
+      {/* Ignore patterns, one per line */}
+      <textarea
+        value={ignoreText}
+        onChange={(e) => setIgnoreText(e.target.value)}
+        placeholder={'Ausschließen, ein Muster pro Zeile\nz.B. node_modules'}
+        rows={3}
+        className="w-full bg-[var(--bg-secondary)] border border-[var(--border-default)] rounded-lg text-xs font-mono text-[var(--text-primary)] placeholder:text-[var(--text-tertiary)] focus:outline-none focus:ring-2 focus:ring-[var(--color-accent)]/50"
+        style={{ padding: '8px 10px', marginTop: '8px' }}
+      />
+
+      {changed && (
+        <button
+          onClick={() => saveSettings(roots, ignorePatterns)}
+          className="w-full flex items-center justify-center bg-[var(--color-accent)] hover:bg-[var(--color-accent-hover)] text-white rounded-lg text-xs font-medium transition-colors"
+          style={{ gap: '6px', padding: '8px', marginTop: '8px' }}
+        >
+          Speichern und neu indizieren
+        </button>
+      )}
+
+      {/* Progress */}
+      {status && (
+        <p
+          className="flex items-center text-xs text-[var(--text-tertiary)]"
+          style={{ gap: '6px', marginTop: '6px' }}
+        >
+          {status.indexing && <RefreshCw size={10} className="animate-spin" />}
+          {status.indexing
+            ? `Indiziere… ${status.items.toLocaleString('de-DE')} Einträge`
+            : `${status.items.toLocaleString('de-DE')} Einträge indiziert`}
+          {status.truncated && ' (gekürzt)'}
+        </p>
+      )}
+      {status && !status.indexing && status.roots?.length > 0 && !status.watching && (
+        <p className="text-xs text-[var(--text-tertiary)]" style={{ marginTop: '2px' }}>
+          Nicht alle Ordner werden überwacht, sie werden alle 15 Minuten neu eingelesen
+        </p>
+      )}
+      {(error || status?.error) && (
+        <div
+          className="flex items-start bg-red-500/10 text-red-400 rounded-lg"
+          style={{ gap: '8px', padding: '10px', marginTop: '8px' }}
+        >
+          <AlertCircle size={14} className="shrink-0" style={{ marginTop: '1px' }} />
+          <span className="text-xs flex-1">{error || status?.error}</span>
+        </div>
+      )}
+    </div>
+  )
+}
@@ -0,0 +1,96 @@
+import { useEffect } from 'react'
+import { Puzzle, RefreshCw, FolderOpen, AlertCircle } from 'lucide-react'
+import { usePluginsStore } from '@/stores/pluginsStore'
+
+const statusLabels: Record<string, string> = {
+  starting: 'Startet',
+  running: 'Läuft',
+  restarting: 'Neustart',
+  failed: 'Fehler',
+  stopped: 'Gestoppt',
+}
+
+// Installed plugins with their state, reloading and the plugins folder
+export function PluginSettings() {
+  const { plugins, isReloading, loadPlugins, reloadPlugins, openFolder } = usePluginsStore()
+
+  useEffect(() => {
+    loadPlugins()
+  }, [loadPlugins])
+
+  return (
+    <div>
+      <label
+        className="flex items-center text-xs font-medium text-[var(--text-secondary)]"
+        style={{ gap: '8px', marginBottom: '8px' }}
+      >
+        <Puzzle size={14} /> Plugins
+      </label>
+
+      {plugins.length === 0 && (
+        <p className="text-xs text-[var(--text-tertiary)]" style={{ marginBottom: '8px' }}>
+          Keine Plugins installiert
+        </p>
+      )}
+
+      <div className="flex flex-col" style={{ gap: '4px' }}>
+        {plugins.map((p) => (
+          <div key={p.id} className="bg-[var(--bg-secondary)] rounded-lg" style={{ padding: '8px 10px' }}>
+            <div className="flex items-center" style={{ gap: '8px' }}>
+              <span className="text-sm text-[var(--text-primary)] truncate flex-1" title={p.path}>
+                {p.name}
+                {p.keyword && <span className="font-mono text-xs text-[var(--text-tertiary)]"> {p.keyword}</span>}
+              </span>
+              <span
+                className={`shrink-0 text-[11px] ${
+                  p.status === 'running'
+                    ? 'text-green-400'
+                    : p.status === 'failed' || p.status === 'restarting'
+                      ? 'text-red-400'
+                      : 'text-[var(--text-tertiary)]'
+                }`}
+              >
+                {statusLabels[p.status] || p.status}
+              </span>
+            </div>
+            {p.description && (
+              <p className="text-xs text-[var(--text-secondary)] truncate" style={{ marginTop: '2px' }}>
+                {p.description}
+              </p>
+            )}
+            {p.lastError && (
+              <p
+                className="flex items-start text-xs text-red-400"
+                style={{ gap: '6px', marginTop: '4px' }}
+                title={p.stderr?.join('\n')}
+              >
+                <AlertCircle size={12} className="shrink-0" style={{ marginTop: '1px' }} />
+                <span className="flex-1 break-words">{p.lastError}</span>
+              </p>
+            )}
+          </div>
+        ))}
+      </div>
+
+      <div className="flex" style={{ gap: '8px', marginTop: '8px' }}>
+        <button
+          onClick={reloadPlugins}
+          disabled={isReloading}
+          className="flex-1 flex items-center justify-center bg-[var(--bg-secondary)] hover:bg-[var(--bg-tertiary)] text-[var(--text-primary)] rounded-lg text-xs font-medium transition-colors disabled:opacity-50"
+          style={{ gap: '6px', padding: '8px' }}
+        >
+          <RefreshCw size={12} className={isReloading ? 'animate-spin' : ''} />
+          Neu laden
+        </button>
+        <button
+          onClick={openFolder}
+          className="flex-1 flex items-center justify-center bg-[var(--bg-secondary)] hover:bg-[var(--bg-tertiary)] text-[var(--text-primary)] rounded-lg text-xs font-medium transition-colors"
+          style={{ gap: '6px', padding: '8px' }}
+        >
+          <FolderOpen size={12} />
+          Ordner öffnen
+        </button>
+      </div>
+    </div>
+  )
+}
@@ -22,10 +22,12 @@ export function SearchBar() {
   const handleKeyDown = useCallback((e: React.KeyboardEvent<HTMLInputElement>) => {
     if (e.key === 'ArrowDown') {
       e.preventDefault()
-      // Focus first tile
+      // Focus the first search result or tile
       setSelectedTileIndex(0)
-      const firstTile = document.querySelector<HTMLButtonElement>('[data-tile-index="0"]')
-      firstTile?.focus()
+      const first = document.querySelector<HTMLButtonElement>(
+        '[data-result-index="0"], [data-tile-index="0"]'
+      )
+      first?.focus()
     } else if (e.key === 'Escape') {
       e.preventDefault()
       HidePanel()
@@ -0,0 +1,197 @@
+import { useCallback, useEffect, useMemo, useState } from 'react'
+import * as Icons from 'lucide-react'
+import { useAppStore } from '@/stores/appStore'
+import { useTilesStore } from '@/stores/tilesStore'
+import { launchResult } from '@/lib/launchResult'
+import { HidePanel } from '../../wailsjs/go/main/App'
+import { providers } from '../../wailsjs/go/models'
+import type { search } from '../../wailsjs/go/models'
+
+interface SearchResultsProps {
+  results: providers.Result[]
+  loading: boolean
+  onOpenSubMenu: (tileId: string) => void
+}
+
+// Title with the matched parts in bold. Ranges are code point offsets.
+function HighlightedTitle({ title, highlights }: { title: string; highlights: search.Highlight[] }) {
+  const ranges = highlights?.find((h) => h.field === 'name')?.ranges || []
+  if (ranges.length === 0) return <>{title}</>
+
+  const chars = Array.from(title)
+  const parts: React.ReactNode[] = []
+  let pos = 0
+  ranges.forEach((r, i) => {
+    if (r.start > pos) parts.push(chars.slice(pos, r.start).join(''))
+    parts.push(
+      <strong key={i} className="font-semibold">
+        {chars.slice(r.start, r.end).join('')}
+      </strong>
+    )
+    pos = r.end
+  })
+  if (pos < chars.length) parts.push(chars.slice(pos).join(''))
+  return <>{parts}</>
+}
+
+export function SearchResults({ results: found, loading, onOpenSubMenu }: SearchResultsProps) {
+  const { filterText, selectedTileIndex, setSelectedTileIndex } = useAppStore()
+  const { tiles } = useTilesStore()
+  const [copied, setCopied] = useState<string | null>(null)
+
+  // A calculation is always the top entry, so Enter copies its value
+  const results = useMemo(
+    () => [...found.filter((r) => r.provider === 'calc'), ...found.filter((r) => r.provider !== 'calc')],
+    [found]
+  )
+
+  const handleExecute = useCallback(
+    async (result: providers.Result) => {
+      // Tiles with submenus ask for the folder first, like in the grid
+      if (result.provider === 'tiles') {
+        const tile = tiles.find((t) => t.id === result.id)
+        if (tile?.hasSubMenu) {
+          onOpenSubMenu(tile.id)
+          return
+        }
+      }
+
+      try {
+        if (!(await launchResult(result))) return
+        // Calculations are copied, the panel stays open to show it
+        if (result.provider === 'calc') {
+          setCopied(result.id)
+          return
+        }
+        HidePanel()
+      } catch (err) {
+        console.error('Error executing result:', err)
+      }
+    },
+    [tiles, onOpenSubMenu]
+  )
+
+  // The result changes with the query, so does what was copied
+  useEffect(() => {
+    setCopied(null)
+  }, [filterText])
+
+  // Arrow keys move between results, Enter and Space click the button.
+  // Enter in the search field runs the top result.
+  useEffect(() => {
+    const handleKeyDown = (e: KeyboardEvent) => {
+      const activeElement = document.activeElement as HTMLElement
+      if (e.key === 'Enter' && activeElement?.matches('input[type="text"]')) {
+        e.preventDefault()
+        if (results[0]) handleExecute(results[0])
+        return
+      }
+      if (!activeElement?.hasAttribute('data-result-index')) return
+
+      const currentIndex = parseInt(activeElement.getAttribute('data-result-index') || '0')
+      let newIndex = currentIndex
+      if (e.key === 'ArrowUp') {
+        e.preventDefault()
+        if (currentIndex === 0) {
+          const searchInput = document.querySelector<HTMLInputElement>('input[type="text"]')
+          searchInput?.focus()
+          return
+        }
+        newIndex = currentIndex - 1
+      } else if (e.key === 'ArrowDown') {
+        e.preventDefault()
+        if (currentIndex >= results.length - 1) return
+        newIndex = currentIndex + 1
+      } else {
+        return
+      }
+
+      setSelectedTileIndex(newIndex)
+      document.querySelector<HTMLButtonElement>(`[data-result-index="${newIndex}"]`)?.focus()
+    }
+
+    window.addEventListener('keydown', handleKeyDown)
+    return () => window.removeEventListener('keydown', handleKeyDown)
+  }, [results, handleExecute, setSelectedTileIndex])
+
+  if (results.length === 0) {
+    return (
+      <div className="text-center py-8 text-[var(--text-secondary)]">
+        <p className="text-sm">{loading ? 'Suche…' : `Keine Ergebnisse für "${filterText}"`}</p>
+      </div>
+    )
+  }
+
+  return (
+    <div className="flex flex-col overflow-y-auto" style={{ gap: '2px' }}>
+      {results.map((result, index) => {
+        const IconComponent = Icons[result.icon as keyof typeof Icons] as
+          | React.ComponentType<{ className?: string; size?: number }>
+          | undefined
+        const isSelected = index === selectedTileIndex
+        const isCalc = result.provider === 'calc'
+
+        return (
+          <div key={`${result.provider}:${result.id}:${result.path || ''}`} className="flex flex-col">
+            <button
+              data-result-index={index}
+              onClick={() => {
+                setSelectedTileIndex(index)
+                handleExecute(result)
+              }}
+              onFocus={() => setSelectedTileIndex(index)}
+              className={`
+                flex items-center w-full text-left rounded-lg transition-colors
+                ${
+                  isSelected
+                    ? 'bg-[var(--color-accent)] text-white'
+                    : 'text-[var(--text-primary)] hover:bg-[var(--bg-secondary)]'
+                }
+                focus:outline-none
+              `}
+              style={{ gap: '10px', padding: '8px 10px' }}
+            >
+              {IconComponent ? (
+                <IconComponent size={18} className="shrink-0" />
+              ) : (
+                <Icons.Search size={18} className="shrink-0" />
+              )}
+              <div className="min-w-0 flex-1">
+                <div className={`truncate ${isCalc ? 'text-base font-semibold' : 'text-sm'}`}>
+                  <HighlightedTitle title={result.title} highlights={result.highlights} />
+                </div>
+                {(result.subtitle || isCalc) && (
+                  <div
+                    className={`text-xs truncate ${isSelected ? 'text-white/70' : 'text-[var(--text-secondary)]'}`}
+                  >
+                    {isCalc
+                      ? copied === result.id
+                        ? 'In die Zwischenablage kopiert'
+                        : 'Enter kopiert das Ergebnis'
+                      : result.subtitle}
+                  </div>
+                )}
+              </div>
+            </button>
+
+            {/* Further actions of the result, e.g. of plugins */}
+            {result.actions && result.actions.length > 0 && (
+              <div className="flex flex-wrap" style={{ gap: '4px', padding: '2px 10px 6px 38px' }}>
+                {result.actions.map((action) => (
+                  <button
+                    key={action.id}
+                    onClick={() => handleExecute(new providers.Result({ ...result, action: action.id }))}
+                    className="rounded bg-[var(--bg-secondary)] hover:bg-[var(--bg-tertiary)] text-[11px] text-[var(--text-primary)] transition-colors focus:outline-none focus-visible:ring-2 focus-visible:ring-[var(--color-accent)]"
+                    style={{ padding: '2px 8px' }}
+                  >
+                    {action.title}
+                  </button>
+                ))}
+              </div>
+            )}
+          </div>
+        )
+      })}
+    </div>
+  )
+}
@@ -3,13 +3,17 @@ import { motion } from 'motion/react'
 import { Moon, Sun, Monitor, Keyboard, FolderOpen, ArrowLeft, Rocket, RefreshCw, Download, Check, AlertCircle } from 'lucide-react'
 import { useSettingsStore } from '@/stores/settingsStore'
 import { useAppStore } from '@/stores/appStore'
+import { useTilesStore } from '@/stores/tilesStore'
 import { useUpdateStore } from '@/stores/updateStore'
 import { useTheme } from '@/hooks/useTheme'
+import { FileIndexSettings } from './FileIndexSettings'
+import { PluginSettings } from './PluginSettings'
 import { GetAutoStartEnabled, SetAutoStart, GetCheckForUpdatesOnStartup } from '../../wailsjs/go/main/App'
 
 export function SettingsPanel() {
   const settings = useSettingsStore()
-  const { setView } = useAppStore()
+  const { setView, hotkeyConflicts } = useAppStore()
+  const { tiles } = useTilesStore()
   const { theme, setTheme } = useTheme()
   const [autoStart, setAutoStartState] = useState(false)
   const [checkOnStartup, setCheckOnStartup] = useState(true)
@@ -149,6 +153,24 @@ export function SettingsPanel() {
           <p className="text-xs text-[var(--text-tertiary)]" style={{ marginTop: '4px' }}>
             Kann derzeit nicht geändert werden
           </p>
+
+          {/* Tile hotkeys that could not be registered */}
+          {hotkeyConflicts.length > 0 && (
+            <div
+              className="bg-red-500/10 text-red-400 rounded-lg flex flex-col"
+              style={{ padding: '10px', marginTop: '8px', gap: '6px' }}
+            >
+              {hotkeyConflicts.map((c) => (
+                <div key={`${c.owner}-${c.hotkey}`} className="flex items-start" style={{ gap: '8px' }}>
+                  <AlertCircle size={14} className="shrink-0" style={{ marginTop: '1px' }} />
+                  <span className="text-xs flex-1">
+                    {tiles.find((t) => t.id === c.owner)?.name || c.owner}:{' '}
+                    <span className="font-mono">{c.hotkey}</span> – {c.reason}
+                  </span>
+                </div>
+              ))}
+            </div>
+          )}
         </div>
 
         {/* Recent Folders Limit */}
@@ -276,6 +298,12 @@ export function SettingsPanel() {
           </button>
         </div>
 
+        {/* File Search */}
+        <FileIndexSettings />
+
+        {/* Plugins */}
+        <PluginSettings />
+
         {/* Update Section */}
         <div id="update-section" className="border-t border-[var(--border-muted)]" style={{ paddingTop: '16px' }}>
           <label
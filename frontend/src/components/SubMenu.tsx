@@ -3,8 +3,8 @@ import { motion } from 'motion/react'
 import { Folder, Clock, FolderOpen } from 'lucide-react'
 import { useAppStore } from '@/stores/appStore'
 import { useTilesStore } from '@/stores/tilesStore'
+import { launchTile } from '@/lib/launchTile'
 import {
-  ExecuteActionWithPath,
   OpenFolderDialog,
   HidePanel,
 } from '../../wailsjs/go/main/App'
@@ -34,8 +34,8 @@ export function SubMenu({ tileId, onClose }: SubMenuProps) {
         const name = path.split(/[\\/]/).pop() || path
         await addRecentItem(tileId, { path, name })
 
-        // Execute action
-        await ExecuteActionWithPath(tile.action, tile.target, path)
+        // Execute tile
+        if (!(await launchTile(tile, path))) return
         HidePanel()
         onClose()
       }
@@ -49,8 +49,8 @@ export function SubMenu({ tileId, onClose }: SubMenuProps) {
       // Move to top of recent (async, persisted to backend)
       await addRecentItem(tileId, { path, name })
 
-      // Execute action
-      await ExecuteActionWithPath(tile.action, tile.target, path)
+      // Execute tile
+      if (!(await launchTile(tile, path))) return
       HidePanel()
       onClose()
     } catch (err) {
@@ -1,15 +1,15 @@
-import { useCallback, useEffect } from 'react'
+import { useCallback, useEffect, useState } from 'react'
 import { AnimatePresence } from 'motion/react'
 import { Plus } from 'lucide-react'
 import { useAppStore } from '@/stores/appStore'
 import { useTilesStore } from '@/stores/tilesStore'
 import { useHotkeys } from '@/hooks/useHotkeys'
+import { useSearch } from '@/hooks/useSearch'
 import { Tile } from './Tile'
 import { SubMenu } from './SubMenu'
-import {
-  ExecuteAction,
-  HidePanel,
-} from '../../wailsjs/go/main/App'
+import { SearchResults } from './SearchResults'
+import { launchTile } from '@/lib/launchTile'
+import { HidePanel } from '../../wailsjs/go/main/App'
 
 export function TileGrid() {
   const {
@@ -25,13 +25,23 @@ export function TileGrid() {
   } = useAppStore()
 
   const { tiles } = useTilesStore()
+  const [subMenuTileId, setSubMenuTileId] = useState<string | null>(null)
 
-  // Filter and sort tiles
+  // Typing searches all providers, the grid shows the enabled tiles
+  const searching = filterText.trim() !== ''
+  const { results, loading } = useSearch(filterText)
   const filteredTiles = tiles
     .filter((t) => t.enabled)
-    .filter((t) => t.name.toLowerCase().includes(filterText.toLowerCase()))
     .sort((a, b) => a.order - b.order)
 
+  const handleOpenSubMenu = useCallback(
+    (tileId: string) => {
+      setSubMenuTileId(tileId)
+      openSubMenu()
+    },
+    [openSubMenu]
+  )
+
   // Handle tile execution
   const handleExecuteTile = useCallback(
     async (tileId: string) => {
@@ -40,25 +50,25 @@ export function TileGrid() {
 
       // Handle tiles with submenus
       if (tile.hasSubMenu) {
-        openSubMenu()
+        handleOpenSubMenu(tile.id)
         return
       }
 
-      // Execute action
+      // Execute tile, asking for inputs and confirmation if needed
       try {
-        await ExecuteAction(tile.action, tile.target)
+        if (!(await launchTile(tile))) return
         HidePanel()
       } catch (err) {
         console.error('Error executing action:', err)
       }
     },
-    [tiles, openSubMenu]
+    [tiles, handleOpenSubMenu]
   )
 
   // Setup hotkeys (1-9 quick access, ESC to close submenu)
   useHotkeys({
     onExecuteTile: handleExecuteTile,
-    filteredTiles,
+    filteredTiles: searching ? [] : filteredTiles,
   })
 
   // Handle keyboard navigation within grid (Arrow keys)
@@ -146,34 +156,38 @@ export function TileGrid() {
     }
   }, [view, selectedTileIndex])
 
-  // Get currently selected tile for submenu
-  const selectedTile = filteredTiles[selectedTileIndex]
-
   return (
-    <div className="relative flex-1">
+    <div className="relative flex-1 min-h-0 flex flex-col">
+      {/* Search results */}
+      {searching && (
+        <SearchResults results={results} loading={loading} onOpenSubMenu={handleOpenSubMenu} />
+      )}
+
       {/* Tile Grid */}
-      <div className="grid grid-cols-3 justify-items-center" style={{ gap: '12px' }}>
-        {filteredTiles.map((tile, index) => (
-          <Tile
-            key={tile.id}
-            tile={tile}
-            index={index}
-            isSelected={index === selectedTileIndex}
-            onClick={() => {
-              setSelectedTileIndex(index)
-              handleExecuteTile(tile.id)
-            }}
-            onFocus={() => setSelectedTileIndex(index)}
-            onContextMenu={() => {
-              setEditingTileId(tile.id)
-              setView('editTile')
-            }}
-          />
-        ))}
-      </div>
+      {!searching && (
+        <div className="grid grid-cols-3 justify-items-center" style={{ gap: '12px' }}>
+          {filteredTiles.map((tile, index) => (
+            <Tile
+              key={tile.id}
+              tile={tile}
+              index={index}
+              isSelected={index === selectedTileIndex}
+              onClick={() => {
+                setSelectedTileIndex(index)
+                handleExecuteTile(tile.id)
+              }}
+              onFocus={() => setSelectedTileIndex(index)}
+              onContextMenu={() => {
+                setEditingTileId(tile.id)
+                setView('editTile')
+              }}
+            />
+          ))}
+        </div>
+      )}
 
       {/* Empty state - no tiles yet */}
-      {filteredTiles.length === 0 && !filterText && (
+      {filteredTiles.length === 0 && !searching && (
         <div className="flex flex-col items-center justify-center py-12 text-center">
           <div
             className="rounded-2xl bg-[var(--bg-secondary)] flex items-center justify-center"
@@ -193,17 +207,10 @@ export function TileGrid() {
         </div>
       )}
 
-      {/* Empty state - no search results */}
-      {filteredTiles.length === 0 && filterText && (
-        <div className="text-center py-8 text-[var(--text-secondary)]">
-          <p className="text-sm">Keine Ergebnisse für "{filterText}"</p>
-        </div>
-      )}
-
       {/* SubMenu Overlay */}
       <AnimatePresence>
-        {isSubMenuOpen && selectedTile && (
-          <SubMenu tileId={selectedTile.id} onClose={closeSubMenu} />
+        {isSubMenuOpen && subMenuTileId && (
+          <SubMenu tileId={subMenuTileId} onClose={closeSubMenu} />
         )}
       </AnimatePresence>
     </div>
@@ -0,0 +1,45 @@
+import { useEffect, useRef, useState } from 'react'
+import { useFileIndexStore } from '@/stores/fileIndexStore'
+import { SearchAll } from '../../wailsjs/go/main/App'
+import type { providers } from '../../wailsjs/go/models'
+
+/**
+ * Queries all search providers for the text in the search bar.
+ *
+ * Every keystroke starts a new search, the backend cancels the one still
+ * running. Responses and errors of overtaken searches are dropped, so
+ * only the results for the current text are shown. An empty text gives
+ * no results. Once the file index changed the search runs again, so
+ * files indexed meanwhile show up.
+ */
+export function useSearch(text: string) {
+  const [results, setResults] = useState<providers.Result[]>([])
+  const [loading, setLoading] = useState(false)
+  const latest = useRef(0)
+  const indexed = useFileIndexStore((s) => s.status?.updated)
+
+  useEffect(() => {
+    const id = ++latest.current
+    if (!text.trim()) {
+      setResults([])
+      setLoading(false)
+      return
+    }
+
+    setLoading(true)
+    SearchAll(text)
+      .then((response) => {
+        if (id !== latest.current) return
+        setResults(response.results || [])
+        setLoading(false)
+      })
+      .catch((err) => {
+        if (id !== latest.current) return
+        console.error('Error searching:', err)
+        setResults([])
+        setLoading(false)
+      })
+  }, [text, indexed])
+
+  return { results, loading }
+}
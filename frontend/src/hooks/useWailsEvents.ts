@@ -1,10 +1,16 @@
 import { useEffect } from 'react'
 import { EventsOn, EventsOff } from '../../wailsjs/runtime/runtime'
+import { GetHotkeyConflicts, HidePanel } from '../../wailsjs/go/main/App'
 import { useAppStore } from '@/stores/appStore'
+import { useTilesStore } from '@/stores/tilesStore'
+import { useFileIndexStore } from '@/stores/fileIndexStore'
+import { usePluginsStore } from '@/stores/pluginsStore'
+import { launchTile } from '@/lib/launchTile'
 import type { AppState } from '@/types'
+import type { hotkeys } from '../../wailsjs/go/models'
 
 export function useWailsEvents() {
-  const { setOpen, setView, reset } = useAppStore()
+  const { setOpen, setView, reset, setHotkeyConflicts } = useAppStore()
 
   useEffect(() => {
     // Listen for panel show/hide events from Go
@@ -25,14 +31,56 @@ export function useWailsEvents() {
       }
     }
 
+    // A tile hotkey opened the panel because the tile needs inputs or a
+    // confirmation; ask for them and launch the tile
+    const launchHandler = async (tileId: string) => {
+      const tile = useTilesStore.getState().tiles.find((t) => t.id === tileId)
+      if (!tile) return
+      try {
+        if (await launchTile(tile)) HidePanel()
+      } catch (err) {
+        console.error('Error executing tile:', err)
+      }
+    }
+
+    // Tile hotkeys that could not be registered
+    const conflictsHandler = (conflicts: hotkeys.Conflict[] | null) => {
+      setHotkeyConflicts(conflicts || [])
+    }
+
+    // Indexing started or finished
+    const fileIndexHandler = () => {
+      useFileIndexStore.getState().loadStatus()
+    }
+
+    // A plugin started, crashed or stopped
+    const pluginsHandler = () => {
+      usePluginsStore.getState().loadPlugins()
+    }
+
     EventsOn('panel:show', showHandler)
     EventsOn('panel:hide', hideHandler)
     EventsOn('panel:show:view', showViewHandler)
+    EventsOn('tile:input-required', launchHandler)
+    EventsOn('tile:confirm-required', launchHandler)
+    EventsOn('hotkeys:conflicts', conflictsHandler)
+    EventsOn('fileindex:changed', fileIndexHandler)
+    EventsOn('plugins:changed', pluginsHandler)
+
+    // Hotkeys are registered before the frontend listens, indexing
+    // starts before it too
+    GetHotkeyConflicts().then(conflictsHandler).catch(console.error)
+    fileIndexHandler()
 
     return () => {
       EventsOff('panel:show')
       EventsOff('panel:hide')
       EventsOff('panel:show:view')
+      EventsOff('tile:input-required')
+      EventsOff('tile:confirm-required')
+      EventsOff('hotkeys:conflicts')
+      EventsOff('fileindex:changed')
+      EventsOff('plugins:changed')
     }
-  }, [setOpen, setView, reset])
+  }, [setOpen, setView, reset, setHotkeyConflicts])
 }
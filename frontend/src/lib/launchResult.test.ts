@@ -0,0 +1,50 @@
+import { describe, it, expect, vi, beforeEach } from 'vitest'
+import { launchResult } from './launchResult'
+import { useTilesStore } from '@/stores/tilesStore'
+import { ExecuteResult, RequestConfirmation } from '../../wailsjs/go/main/App'
+import { providers } from '../../wailsjs/go/models'
+
+describe('launchResult', () => {
+  beforeEach(() => {
+    vi.mocked(ExecuteResult).mockClear()
+    vi.mocked(RequestConfirmation).mockClear()
+    useTilesStore.setState({
+      tiles: [
+        {
+          id: 'deploy',
+          name: 'Deploy',
+          icon: 'Rocket',
+          action: 'url',
+          target: 'https://example.com/deploy',
+          order: 0,
+          enabled: true,
+          requireConfirm: true,
+        },
+      ],
+    })
+  })
+
+  it('confirms guarded tiles before executing them', async () => {
+    vi.spyOn(window, 'confirm').mockReturnValue(true)
+    const result = new providers.Result({ provider: 'tiles', kind: 'tile', id: 'deploy', title: 'Deploy' })
+
+    expect(await launchResult(result)).toBe(true)
+    expect(RequestConfirmation).toHaveBeenCalledWith('deploy')
+    expect(ExecuteResult).toHaveBeenCalledWith(result, {}, 'token')
+  })
+
+  it('does not execute when the confirmation is cancelled', async () => {
+    vi.spyOn(window, 'confirm').mockReturnValue(false)
+    const result = new providers.Result({ provider: 'tiles', kind: 'tile', id: 'deploy', title: 'Deploy' })
+
+    expect(await launchResult(result)).toBe(false)
+    expect(ExecuteResult).not.toHaveBeenCalled()
+  })
+
+  it('executes other results directly', async () => {
+    const result = new providers.Result({ provider: 'calc', kind: 'calc', id: '4', title: '= 4' })
+
+    expect(await launchResult(result)).toBe(true)
+    expect(ExecuteResult).toHaveBeenCalledWith(result, {}, '')
+  })
+})
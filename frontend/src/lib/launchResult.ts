@@ -0,0 +1,26 @@
+import { useTilesStore } from '@/stores/tilesStore'
+import { askLaunchInputs } from './launchTile'
+import { ExecuteResult } from '../../wailsjs/go/main/App'
+import type { providers } from '../../wailsjs/go/models'
+
+// Providers whose results are tiles, the result ID is the tile ID
+const tileProviders = ['tiles', 'recent']
+
+// Execute a result of SearchAll. Tiles ask for inputs and confirmation
+// like launchTile. Returns false if the user cancelled.
+export async function launchResult(result: providers.Result): Promise<boolean> {
+  let inputs: Record<string, string> = {}
+  let token = ''
+  if (tileProviders.includes(result.provider)) {
+    const tile = useTilesStore.getState().tiles.find((t) => t.id === result.id)
+    if (tile) {
+      const asked = await askLaunchInputs(tile)
+      if (!asked) return false
+      inputs = asked.inputs
+      token = asked.token
+    }
+  }
+
+  await ExecuteResult(result, inputs, token)
+  return true
+}
@@ -0,0 +1,42 @@
+import type { Tile } from '@/types'
+import {
+  ExecuteTileConfirmed,
+  GetTilePrompts,
+  RequestConfirmation,
+} from '../../wailsjs/go/main/App'
+
+export interface LaunchInputs {
+  inputs: Record<string, string>
+  token: string
+}
+
+// Ask for the values of {input:...} placeholders and, for guarded tiles,
+// a confirmation. Returns null if the user cancelled.
+export async function askLaunchInputs(tile: Tile): Promise<LaunchInputs | null> {
+  const prompts = (await GetTilePrompts(tile.id)) || []
+  const inputs: Record<string, string> = {}
+  for (const prompt of prompts) {
+    const value = window.prompt(prompt)
+    if (value === null) return null
+    inputs[prompt] = value
+  }
+
+  let token = ''
+  if (tile.requireConfirm) {
+    if (!window.confirm(`„${tile.name}“ wirklich ausführen?`)) return null
+    const request = await RequestConfirmation(tile.id)
+    token = request.token
+  }
+  return { inputs, token }
+}
+
+// Launch a tile by ID like the backend would for a hotkey. Values for
+// {input:...} placeholders are asked for first, guarded tiles need a
+// confirmation. Returns false if the user cancelled.
+export async function launchTile(tile: Tile, path = ''): Promise<boolean> {
+  const asked = await askLaunchInputs(tile)
+  if (!asked) return false
+
+  await ExecuteTileConfirmed(tile.id, path, asked.inputs, asked.token)
+  return true
+}
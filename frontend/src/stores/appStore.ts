@@ -1,7 +1,11 @@
 import { create } from 'zustand'
 import type { AppState } from '@/types'
+import type { hotkeys } from '../../wailsjs/go/models'
 
 interface AppStore extends AppState {
+  // Tile hotkeys that could not be registered; kept across resets
+  hotkeyConflicts: hotkeys.Conflict[]
+  setHotkeyConflicts: (conflicts: hotkeys.Conflict[]) => void
   setOpen: (open: boolean) => void
   toggle: () => void
   setSelectedTileIndex: (index: number) => void
@@ -26,6 +30,7 @@ const initialState: AppState = {
 
 export const useAppStore = create<AppStore>((set) => ({
   ...initialState,
+  hotkeyConflicts: [],
 
   setOpen: (isOpen) => set({ isOpen }),
   toggle: () => set((state) => ({ isOpen: !state.isOpen })),
@@ -36,5 +41,6 @@ export const useAppStore = create<AppStore>((set) => ({
   setSelectedSubMenuIndex: (selectedSubMenuIndex) => set({ selectedSubMenuIndex }),
   setView: (view) => set({ view }),
   setEditingTileId: (editingTileId) => set({ editingTileId }),
+  setHotkeyConflicts: (hotkeyConflicts) => set({ hotkeyConflicts }),
   reset: () => set({ ...initialState, isOpen: true }),
 }))
@@ -0,0 +1,52 @@
+import { create } from 'zustand'
+import { GetConfig, GetFileIndexStatus, SaveFileIndexSettings } from '../../wailsjs/go/main/App'
+import { config } from '../../wailsjs/go/models'
+import type { fileindex } from '../../wailsjs/go/models'
+
+interface FileIndexStore {
+  status: fileindex.Status | null
+  // Ignore patterns are not part of the status, they come from the config
+  ignore: string[]
+  error: string | null
+
+  loadStatus: () => Promise<void>
+  loadSettings: () => Promise<void>
+  saveSettings: (roots: string[], ignore: string[]) => Promise<void>
+}
+
+export const useFileIndexStore = create<FileIndexStore>((set, get) => ({
+  status: null,
+  ignore: [],
+  error: null,
+
+  loadStatus: async () => {
+    try {
+      set({ status: await GetFileIndexStatus() })
+    } catch (err) {
+      console.error('Failed to load file index status:', err)
+    }
+  },
+
+  loadSettings: async () => {
+    try {
+      const cfg = await GetConfig()
+      set({ ignore: cfg.fileIndex?.ignore || [] })
+    } catch (err) {
+      console.error('Failed to load file index settings:', err)
+    }
+    await get().loadStatus()
+  },
+
+  // The backend indexes the new roots from scratch and reports its
+  // progress through fileindex:changed
+  saveSettings: async (roots, ignore) => {
+    try {
+      await SaveFileIndexSettings(new config.FileIndex({ roots, ignore }))
+      set({ ignore, error: null })
+    } catch (err) {
+      console.error('Failed to save file index settings:', err)
+      set({ error: String(err) })
+    }
+    await get().loadStatus()
+  },
+}))
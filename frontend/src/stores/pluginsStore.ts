@@ -0,0 +1,44 @@
+import { create } from 'zustand'
+import { GetPlugins, ReloadPlugins, OpenPluginsFolder } from '../../wailsjs/go/main/App'
+import type { plugins } from '../../wailsjs/go/models'
+
+interface PluginsStore {
+  plugins: plugins.Status[]
+  isReloading: boolean
+
+  loadPlugins: () => Promise<void>
+  reloadPlugins: () => Promise<void>
+  openFolder: () => Promise<void>
+}
+
+export const usePluginsStore = create<PluginsStore>((set) => ({
+  plugins: [],
+  isReloading: false,
+
+  loadPlugins: async () => {
+    try {
+      set({ plugins: (await GetPlugins()) || [] })
+    } catch (err) {
+      console.error('Failed to load plugins:', err)
+    }
+  },
+
+  // Restarts all plugins, e.g. after one was added to the folder
+  reloadPlugins: async () => {
+    set({ isReloading: true })
+    try {
+      set({ plugins: (await ReloadPlugins()) || [] })
+    } catch (err) {
+      console.error('Failed to reload plugins:', err)
+    }
+    set({ isReloading: false })
+  },
+
+  openFolder: async () => {
+    try {
+      await OpenPluginsFolder()
+    } catch (err) {
+      console.error('Failed to open plugins folder:', err)
+    }
+  },
+}))
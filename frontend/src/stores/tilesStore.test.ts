@@ -0,0 +1,39 @@
+import { describe, it, expect, vi, beforeEach } from 'vitest'
+import { useTilesStore } from './tilesStore'
+import { GetTiles, SaveTiles } from '../../wailsjs/go/main/App'
+import { config } from '../../wailsjs/go/models'
+
+describe('tilesStore', () => {
+  beforeEach(() => {
+    vi.mocked(SaveTiles).mockClear()
+  })
+
+  it('keeps backend settings when tiles are reordered', async () => {
+    vi.mocked(GetTiles).mockResolvedValue([
+      new config.Tile({
+        id: 'deploy',
+        name: 'Deploy',
+        icon: 'Rocket',
+        action: 'workflow',
+        target: '',
+        order: 0,
+        enabled: true,
+        hotkey: 'ctrl+alt+d',
+        requireConfirm: true,
+        steps: [{ action: 'shell', target: 'make deploy', wait: true }],
+      }),
+      new config.Tile({ id: 'mail', name: 'Mail', action: 'app', target: 'thunderbird', order: 1, enabled: true }),
+    ])
+    await useTilesStore.getState().loadTiles()
+    await useTilesStore.getState().reorderTiles(0, 1)
+
+    expect(SaveTiles).toHaveBeenCalledTimes(1)
+    const saved = vi.mocked(SaveTiles).mock.calls[0][0]
+    expect(saved.map((t) => t.id)).toEqual(['mail', 'deploy'])
+    expect(saved[1].order).toBe(1)
+    expect(saved[1].hotkey).toBe('ctrl+alt+d')
+    expect(saved[1].requireConfirm).toBe(true)
+    expect(saved[1].steps).toHaveLength(1)
+    expect(saved[1].steps?.[0].target).toBe('make deploy')
+  })
+})
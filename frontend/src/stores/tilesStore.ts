@@ -26,52 +26,26 @@ interface TilesStore {
   clearRecent: (tileId?: string) => Promise<void>
 }
 
-// Convert frontend Tile to Go config.Tile
+// Convert frontend Tile to Go config.Tile. All fields are passed on,
+// so settings the UI does not edit survive a save.
 function toConfigTile(tile: Tile): config.Tile {
   return new config.Tile({
-    id: tile.id,
-    name: tile.name,
-    icon: tile.icon,
-    action: tile.action,
-    target: tile.target,
-    args: tile.args,
-    workDir: tile.workDir,
+    ...tile,
     hasSubMenu: tile.hasSubMenu ?? false,
-    subMenuType: tile.subMenuType,
-    subMenuItems: tile.subMenuItems?.map(
-      (item) =>
-        new config.RecentItem({
-          path: item.path,
-          name: item.name,
-          timestamp: item.timestamp,
-        })
-    ),
-    order: tile.order,
-    enabled: tile.enabled,
-    color: tile.color,
   })
 }
 
 // Convert Go config.Tile to frontend Tile
 function fromConfigTile(t: config.Tile): Tile {
   return {
-    id: t.id,
-    name: t.name,
-    icon: t.icon,
+    ...t,
     action: t.action as Tile['action'],
-    target: t.target,
-    args: t.args,
-    workDir: t.workDir,
-    hasSubMenu: t.hasSubMenu,
     subMenuType: t.subMenuType as Tile['subMenuType'],
     subMenuItems: t.subMenuItems?.map((item) => ({
       path: item.path,
       name: item.name,
       timestamp: item.timestamp,
     })) || [],
-    order: t.order,
-    enabled: t.enabled,
-    color: t.color,
   }
 }
 
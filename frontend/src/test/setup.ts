@@ -14,10 +14,19 @@ vi.mock('../../wailsjs/runtime/runtime', () => ({
 
 // Mock Wails Go bindings
 vi.mock('../../wailsjs/go/main/App', () => ({
-  ExecuteAction: vi.fn().mockResolvedValue(undefined),
-  ExecuteActionWithPath: vi.fn().mockResolvedValue(undefined),
+  ExecuteTileConfirmed: vi.fn().mockResolvedValue(undefined),
+  GetTilePrompts: vi.fn().mockResolvedValue([]),
+  RequestConfirmation: vi.fn().mockResolvedValue({ token: 'token' }),
+  SearchAll: vi.fn().mockResolvedValue({ query: '', results: [] }),
+  ExecuteResult: vi.fn().mockResolvedValue(undefined),
+  GetFileIndexStatus: vi.fn().mockResolvedValue({ roots: [], items: 0, indexing: false, watching: true, truncated: false }),
+  SaveFileIndexSettings: vi.fn().mockResolvedValue(undefined),
+  GetPlugins: vi.fn().mockResolvedValue([]),
+  ReloadPlugins: vi.fn().mockResolvedValue([]),
+  OpenPluginsFolder: vi.fn().mockResolvedValue(undefined),
   OpenFolderDialog: vi.fn().mockResolvedValue(''),
   HidePanel: vi.fn().mockResolvedValue(undefined),
+  GetHotkeyConflicts: vi.fn().mockResolvedValue([]),
   GetAutoStartEnabled: vi.fn().mockResolvedValue(false),
   SetAutoStart: vi.fn().mockResolvedValue(undefined),
   GetConfig: vi.fn().mockResolvedValue({
@@ -28,6 +37,13 @@ vi.mock('../../wailsjs/go/main/App', () => ({
     blur: true,
     recentFoldersLimit: 5,
   }),
+  GetTiles: vi.fn().mockResolvedValue([]),
+  SaveTiles: vi.fn().mockResolvedValue(undefined),
+  AddTile: vi.fn().mockResolvedValue(undefined),
+  UpdateTile: vi.fn().mockResolvedValue(undefined),
+  RemoveTile: vi.fn().mockResolvedValue(undefined),
+  AddRecentItem: vi.fn().mockResolvedValue(undefined),
+  ClearRecentItems: vi.fn().mockResolvedValue(undefined),
   SaveConfig: vi.fn().mockResolvedValue(undefined),
   UpdateConfig: vi.fn().mockResolvedValue(undefined),
 }))
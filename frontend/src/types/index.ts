@@ -1,3 +1,5 @@
+import type { config } from '../../wailsjs/go/models'
+
 // Action types for tiles
 export type ActionType = 'app' | 'folder' | 'url' | 'powershell'
 
@@ -26,6 +28,20 @@ export interface Tile {
   order: number
   enabled: boolean
   color?: string
+  requireConfirm?: boolean // Ask before the tile runs
+  // Backend settings without an editor yet; kept so saving a tile
+  // does not drop them
+  hotkey?: string
+  terminalProfile?: string
+  shell?: string
+  runMode?: string
+  steps?: config.WorkflowStep[]
+  tags?: string[]
+  keyword?: string
+  elevated?: boolean
+  singleInstance?: boolean
+  windowClass?: string
+  startWithApp?: boolean
 }
 
 // SubMenu item
@@ -2,9 +2,23 @@
 // This file is automatically generated. DO NOT EDIT
 import {config} from '../models';
 import {updater} from '../models';
+import {providers} from '../models';
+import {actions} from '../models';
+import {fileindex} from '../models';
+import {hotkeys} from '../models';
+import {launcher} from '../models';
+import {plugins} from '../models';
+import {runlog} from '../models';
+import {runner} from '../models';
+import {procs} from '../models';
+import {service} from '../models';
 import {version} from '../models';
+import {keywords} from '../models';
+import {main} from '../models';
 import {tray} from '../models';
 
+export function AddApplicationTile(arg1:string):Promise<config.Tile>;
+
 export function AddRecentItem(arg1:string,arg2:config.RecentItem):Promise<void>;
 
 export function AddTile(arg1:config.Tile):Promise<void>;
@@ -15,18 +29,56 @@ export function ClearRecentItems(arg1:string):Promise<void>;
 
 export function DownloadAndApplyUpdate():Promise<void>;
 
-export function ExecuteAction(arg1:string,arg2:string):Promise<void>;
+export function ExecuteKeyword(arg1:string,arg2:Record<string, string>,arg3:string):Promise<void>;
+
+export function ExecuteResult(arg1:providers.Result,arg2:Record<string, string>,arg3:string):Promise<void>;
+
+export function ExecuteTile(arg1:string,arg2:string):Promise<void>;
+
+export function ExecuteTileConfirmed(arg1:string,arg2:string,arg3:Record<string, string>,arg4:string):Promise<void>;
+
+export function ExecuteTileWithInputs(arg1:string,arg2:string,arg3:Record<string, string>):Promise<void>;
 
-export function ExecuteActionWithPath(arg1:string,arg2:string,arg3:string):Promise<void>;
+export function GetActionTypes():Promise<Array<actions.Description>>;
 
 export function GetAutoStartEnabled():Promise<boolean>;
 
 export function GetCheckForUpdatesOnStartup():Promise<boolean>;
 
+export function GetCommandPolicy():Promise<config.CommandPolicy>;
+
 export function GetConfig():Promise<config.Config>;
 
+export function GetFileIndexStatus():Promise<fileindex.Status>;
+
+export function GetHotkey():Promise<string>;
+
+export function GetHotkeyConflicts():Promise<Array<hotkeys.Conflict>>;
+
+export function GetLaunchCapabilities():Promise<launcher.Capabilities>;
+
+export function GetPlugins():Promise<Array<plugins.Status>>;
+
 export function GetRecentItems(arg1:string):Promise<Array<config.RecentItem>>;
 
+export function GetRunHistory(arg1:runlog.Filter):Promise<Array<runlog.Entry>>;
+
+export function GetRunResults(arg1:string):Promise<Array<runner.Result>>;
+
+export function GetRunningProcesses(arg1:string):Promise<Array<procs.Process>>;
+
+export function GetSearchProviders():Promise<Array<providers.Info>>;
+
+export function GetServiceLogs(arg1:string):Promise<Array<service.LogLine>>;
+
+export function GetServices():Promise<Array<service.State>>;
+
+export function GetShellKinds():Promise<Array<launcher.ShellKind>>;
+
+export function GetTerminalProfiles():Promise<Array<config.TerminalProfile>>;
+
+export function GetTilePrompts(arg1:string):Promise<Array<string>>;
+
 export function GetTiles():Promise<Array<config.Tile>>;
 
 export function GetVersion():Promise<string>;
@@ -37,20 +89,56 @@ export function HidePanel():Promise<void>;
 
 export function IsVisible():Promise<boolean>;
 
+export function MatchKeyword(arg1:string):Promise<keywords.Match>;
+
+export function OpenFile(arg1:string):Promise<void>;
+
 export function OpenFolderDialog():Promise<string>;
 
+export function OpenPluginsFolder():Promise<void>;
+
 export function QuitApp():Promise<void>;
 
+export function ReloadPlugins():Promise<Array<plugins.Status>>;
+
 export function RemoveTile(arg1:string):Promise<void>;
 
+export function RequestConfirmation(arg1:string):Promise<main.ConfirmRequest>;
+
+export function RerunHistoryEntry(arg1:string,arg2:string):Promise<void>;
+
 export function RestartApp():Promise<void>;
 
+export function RestartService(arg1:string):Promise<void>;
+
+export function SaveCommandPolicy(arg1:config.CommandPolicy):Promise<void>;
+
 export function SaveConfig():Promise<void>;
 
+export function SaveFileIndexSettings(arg1:config.FileIndex):Promise<void>;
+
+export function SaveTerminalProfiles(arg1:Array<config.TerminalProfile>,arg2:string):Promise<void>;
+
 export function SaveTiles(arg1:Array<config.Tile>):Promise<void>;
 
+export function Search(arg1:string):Promise<Array<main.SearchResult>>;
+
+export function SearchAll(arg1:string):Promise<providers.Response>;
+
+export function SearchApplications(arg1:string):Promise<Array<main.ApplicationResult>>;
+
+export function SearchFiles(arg1:string):Promise<Array<fileindex.Result>>;
+
 export function SetAutoStart(arg1:boolean):Promise<void>;
 
+export function SetHotkey(arg1:string):Promise<void>;
+
+export function SetKeepTileOrder(arg1:boolean):Promise<void>;
+
+export function SetSearchProviderEnabled(arg1:string,arg2:boolean):Promise<void>;
+
+export function SetTerminal(arg1:string):Promise<void>;
+
 export function SetTrayManager(arg1:tray.Manager):Promise<void>;
 
 export function ShowPanel():Promise<void>;
@@ -59,8 +147,12 @@ export function ShowPanelWithView(arg1:string):Promise<void>;
 
 export function ShowUpdateReadyNotification(arg1:string):Promise<void>;
 
+export function StopTile(arg1:string):Promise<void>;
+
 export function TogglePanel():Promise<void>;
 
 export function UpdateConfig(arg1:string,arg2:string,arg3:boolean,arg4:boolean,arg5:number):Promise<void>;
 
 export function UpdateTile(arg1:string,arg2:config.Tile):Promise<void>;
+
+export function ValidateHotkey(arg1:string):Promise<string>;
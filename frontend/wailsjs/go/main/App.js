@@ -2,6 +2,10 @@
 // Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
 // This file is automatically generated. DO NOT EDIT
 
+export function AddApplicationTile(arg1) {
+  return window['go']['main']['App']['AddApplicationTile'](arg1);
+}
+
 export function AddRecentItem(arg1, arg2) {
   return window['go']['main']['App']['AddRecentItem'](arg1, arg2);
 }
@@ -22,12 +26,28 @@ export function DownloadAndApplyUpdate() {
   return window['go']['main']['App']['DownloadAndApplyUpdate']();
 }
 
-export function ExecuteAction(arg1, arg2) {
-  return window['go']['main']['App']['ExecuteAction'](arg1, arg2);
+export function ExecuteKeyword(arg1, arg2, arg3) {
+  return window['go']['main']['App']['ExecuteKeyword'](arg1, arg2, arg3);
+}
+
+export function ExecuteResult(arg1, arg2, arg3) {
+  return window['go']['main']['App']['ExecuteResult'](arg1, arg2, arg3);
+}
+
+export function ExecuteTile(arg1, arg2) {
+  return window['go']['main']['App']['ExecuteTile'](arg1, arg2);
+}
+
+export function ExecuteTileConfirmed(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['ExecuteTileConfirmed'](arg1, arg2, arg3, arg4);
+}
+
+export function ExecuteTileWithInputs(arg1, arg2, arg3) {
+  return window['go']['main']['App']['ExecuteTileWithInputs'](arg1, arg2, arg3);
 }
 
-export function ExecuteActionWithPath(arg1, arg2, arg3) {
-  return window['go']['main']['App']['ExecuteActionWithPath'](arg1, arg2, arg3);
+export function GetActionTypes() {
+  return window['go']['main']['App']['GetActionTypes']();
 }
 
 export function GetAutoStartEnabled() {
@@ -38,14 +58,74 @@ export function GetCheckForUpdatesOnStartup() {
   return window['go']['main']['App']['GetCheckForUpdatesOnStartup']();
 }
 
+export function GetCommandPolicy() {
+  return window['go']['main']['App']['GetCommandPolicy']();
+}
+
 export function GetConfig() {
   return window['go']['main']['App']['GetConfig']();
 }
 
+export function GetFileIndexStatus() {
+  return window['go']['main']['App']['GetFileIndexStatus']();
+}
+
+export function GetHotkey() {
+  return window['go']['main']['App']['GetHotkey']();
+}
+
+export function GetHotkeyConflicts() {
+  return window['go']['main']['App']['GetHotkeyConflicts']();
+}
+
+export function GetLaunchCapabilities() {
+  return window['go']['main']['App']['GetLaunchCapabilities']();
+}
+
+export function GetPlugins() {
+  return window['go']['main']['App']['GetPlugins']();
+}
+
 export function GetRecentItems(arg1) {
   return window['go']['main']['App']['GetRecentItems'](arg1);
 }
 
+export function GetRunHistory(arg1) {
+  return window['go']['main']['App']['GetRunHistory'](arg1);
+}
+
+export function GetRunResults(arg1) {
+  return window['go']['main']['App']['GetRunResults'](arg1);
+}
+
+export function GetRunningProcesses(arg1) {
+  return window['go']['main']['App']['GetRunningProcesses'](arg1);
+}
+
+export function GetSearchProviders() {
+  return window['go']['main']['App']['GetSearchProviders']();
+}
+
+export function GetServiceLogs(arg1) {
+  return window['go']['main']['App']['GetServiceLogs'](arg1);
+}
+
+export function GetServices() {
+  return window['go']['main']['App']['GetServices']();
+}
+
+export function GetShellKinds() {
+  return window['go']['main']['App']['GetShellKinds']();
+}
+
+export function GetTerminalProfiles() {
+  return window['go']['main']['App']['GetTerminalProfiles']();
+}
+
+export function GetTilePrompts(arg1) {
+  return window['go']['main']['App']['GetTilePrompts'](arg1);
+}
+
 export function GetTiles() {
   return window['go']['main']['App']['GetTiles']();
 }
@@ -66,34 +146,106 @@ export function IsVisible() {
   return window['go']['main']['App']['IsVisible']();
 }
 
+export function MatchKeyword(arg1) {
+  return window['go']['main']['App']['MatchKeyword'](arg1);
+}
+
+export function OpenFile(arg1) {
+  return window['go']['main']['App']['OpenFile'](arg1);
+}
+
 export function OpenFolderDialog() {
   return window['go']['main']['App']['OpenFolderDialog']();
 }
 
+export function OpenPluginsFolder() {
+  return window['go']['main']['App']['OpenPluginsFolder']();
+}
+
 export function QuitApp() {
   return window['go']['main']['App']['QuitApp']();
 }
 
+export function ReloadPlugins() {
+  return window['go']['main']['App']['ReloadPlugins']();
+}
+
 export function RemoveTile(arg1) {
   return window['go']['main']['App']['RemoveTile'](arg1);
 }
 
+export function RequestConfirmation(arg1) {
+  return window['go']['main']['App']['RequestConfirmation'](arg1);
+}
+
+export function RerunHistoryEntry(arg1, arg2) {
+  return window['go']['main']['App']['RerunHistoryEntry'](arg1, arg2);
+}
+
 export function RestartApp() {
   return window['go']['main']['App']['RestartApp']();
 }
 
+export function RestartService(arg1) {
+  return window['go']['main']['App']['RestartService'](arg1);
+}
+
+export function SaveCommandPolicy(arg1) {
+  return window['go']['main']['App']['SaveCommandPolicy'](arg1);
+}
+
 export function SaveConfig() {
   return window['go']['main']['App']['SaveConfig']();
 }
 
+export function SaveFileIndexSettings(arg1) {
+  return window['go']['main']['App']['SaveFileIndexSettings'](arg1);
+}
+
+export function SaveTerminalProfiles(arg1, arg2) {
+  return window['go']['main']['App']['SaveTerminalProfiles'](arg1, arg2);
+}
+
 export function SaveTiles(arg1) {
   return window['go']['main']['App']['SaveTiles'](arg1);
 }
 
+export function Search(arg1) {
+  return window['go']['main']['App']['Search'](arg1);
+}
+
+export function SearchAll(arg1) {
+  return window['go']['main']['App']['SearchAll'](arg1);
+}
+
+export function SearchApplications(arg1) {
+  return window['go']['main']['App']['SearchApplications'](arg1);
+}
+
+export function SearchFiles(arg1) {
+  return window['go']['main']['App']['SearchFiles'](arg1);
+}
+
 export function SetAutoStart(arg1) {
   return window['go']['main']['App']['SetAutoStart'](arg1);
 }
 
+export function SetHotkey(arg1) {
+  return window['go']['main']['App']['SetHotkey'](arg1);
+}
+
+export function SetKeepTileOrder(arg1) {
+  return window['go']['main']['App']['SetKeepTileOrder'](arg1);
+}
+
+export function SetSearchProviderEnabled(arg1, arg2) {
+  return window['go']['main']['App']['SetSearchProviderEnabled'](arg1, arg2);
+}
+
+export function SetTerminal(arg1) {
+  return window['go']['main']['App']['SetTerminal'](arg1);
+}
+
 export function SetTrayManager(arg1) {
   return window['go']['main']['App']['SetTrayManager'](arg1);
 }
@@ -110,6 +262,10 @@ export function ShowUpdateReadyNotification(arg1) {
   return window['go']['main']['App']['ShowUpdateReadyNotification'](arg1);
 }
 
+export function StopTile(arg1) {
+  return window['go']['main']['App']['StopTile'](arg1);
+}
+
 export function TogglePanel() {
   return window['go']['main']['App']['TogglePanel']();
 }
@@ -121,3 +277,7 @@ export function UpdateConfig(arg1, arg2, arg3, arg4, arg5) {
 export function UpdateTile(arg1, arg2) {
   return window['go']['main']['App']['UpdateTile'](arg1, arg2);
 }
+
+export function ValidateHotkey(arg1) {
+  return window['go']['main']['App']['ValidateHotkey'](arg1);
+}
@@ -1,5 +1,96 @@
+export namespace actions {
+	
+	export class Description {
+	    type: string;
+	    label: string;
+	    description: string;
+	    needsTarget: boolean;
+	    supportsPath: boolean;
+	    supportsElevation: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new Description(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.type = source["type"];
+	        this.label = source["label"];
+	        this.description = source["description"];
+	        this.needsTarget = source["needsTarget"];
+	        this.supportsPath = source["supportsPath"];
+	        this.supportsElevation = source["supportsElevation"];
+	    }
+	}
+
+}
+
 export namespace config {
 	
+	export class CommandPolicy {
+	    allow?: string[];
+	    deny?: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new CommandPolicy(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.allow = source["allow"];
+	        this.deny = source["deny"];
+	    }
+	}
+	export class SearchProvider {
+	    disabled?: boolean;
+	    timeoutMs?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new SearchProvider(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.disabled = source["disabled"];
+	        this.timeoutMs = source["timeoutMs"];
+	    }
+	}
+	export class WorkflowStep {
+	    name?: string;
+	    action: string;
+	    target: string;
+	    args?: string[];
+	    path?: string;
+	    workDir?: string;
+	    terminalProfile?: string;
+	    shell?: string;
+	    runMode?: string;
+	    delayMs?: number;
+	    wait?: boolean;
+	    continueOnError?: boolean;
+	    elevated?: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new WorkflowStep(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.action = source["action"];
+	        this.target = source["target"];
+	        this.args = source["args"];
+	        this.path = source["path"];
+	        this.workDir = source["workDir"];
+	        this.terminalProfile = source["terminalProfile"];
+	        this.shell = source["shell"];
+	        this.runMode = source["runMode"];
+	        this.delayMs = source["delayMs"];
+	        this.wait = source["wait"];
+	        this.continueOnError = source["continueOnError"];
+	        this.elevated = source["elevated"];
+	    }
+	}
 	export class RecentItem {
 	    path: string;
 	    name: string;
@@ -30,6 +121,18 @@ export namespace config {
 	    order: number;
 	    enabled: boolean;
 	    color?: string;
+	    hotkey?: string;
+	    terminalProfile?: string;
+	    shell?: string;
+	    runMode?: string;
+	    steps?: WorkflowStep[];
+	    tags?: string[];
+	    keyword?: string;
+	    requireConfirm?: boolean;
+	    elevated?: boolean;
+	    singleInstance?: boolean;
+	    windowClass?: string;
+	    startWithApp?: boolean;
 	
 	    static createFrom(source: any = {}) {
 	        return new Tile(source);
@@ -50,6 +153,18 @@ export namespace config {
 	        this.order = source["order"];
 	        this.enabled = source["enabled"];
 	        this.color = source["color"];
+	        this.hotkey = source["hotkey"];
+	        this.terminalProfile = source["terminalProfile"];
+	        this.shell = source["shell"];
+	        this.runMode = source["runMode"];
+	        this.steps = this.convertValues(source["steps"], WorkflowStep);
+	        this.tags = source["tags"];
+	        this.keyword = source["keyword"];
+	        this.requireConfirm = source["requireConfirm"];
+	        this.elevated = source["elevated"];
+	        this.singleInstance = source["singleInstance"];
+	        this.windowClass = source["windowClass"];
+	        this.startWithApp = source["startWithApp"];
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -70,6 +185,40 @@ export namespace config {
 		    return a;
 		}
 	}
+	export class FileIndex {
+	    roots?: string[];
+	    ignore?: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new FileIndex(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.roots = source["roots"];
+	        this.ignore = source["ignore"];
+	    }
+	}
+	export class TerminalProfile {
+	    id: string;
+	    name: string;
+	    terminal?: string;
+	    args?: string[];
+	    shell?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new TerminalProfile(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.terminal = source["terminal"];
+	        this.args = source["args"];
+	        this.shell = source["shell"];
+	    }
+	}
 	export class Config {
 	    theme: string;
 	    hotkey: string;
@@ -80,7 +229,14 @@ export namespace config {
 	    checkForUpdatesOnStartup: boolean;
 	    recentFoldersLimit: number;
 	    recentFolders: string[];
+	    keepTileOrder: boolean;
+	    terminal?: string;
+	    terminalProfiles: TerminalProfile[];
+	    defaultTerminalProfile: string;
+	    commandPolicy: CommandPolicy;
+	    fileIndex: FileIndex;
 	    tiles: Tile[];
+	    searchProviders?: Record<string, SearchProvider>;
 	
 	    static createFrom(source: any = {}) {
 	        return new Config(source);
@@ -97,7 +253,285 @@ export namespace config {
 	        this.checkForUpdatesOnStartup = source["checkForUpdatesOnStartup"];
 	        this.recentFoldersLimit = source["recentFoldersLimit"];
 	        this.recentFolders = source["recentFolders"];
+	        this.keepTileOrder = source["keepTileOrder"];
+	        this.terminal = source["terminal"];
+	        this.terminalProfiles = this.convertValues(source["terminalProfiles"], TerminalProfile);
+	        this.defaultTerminalProfile = source["defaultTerminalProfile"];
+	        this.commandPolicy = this.convertValues(source["commandPolicy"], CommandPolicy);
+	        this.fileIndex = this.convertValues(source["fileIndex"], FileIndex);
 	        this.tiles = this.convertValues(source["tiles"], Tile);
+	        this.searchProviders = this.convertValues(source["searchProviders"], SearchProvider, true);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	
+	
+	
+	
+
+}
+
+export namespace desktop {
+	
+	export class Entry {
+	    id: string;
+	    name: string;
+	    genericName?: string;
+	    comment?: string;
+	    icon?: string;
+	    exec: string;
+	    path?: string;
+	    terminal: boolean;
+	    keywords?: string[];
+	    categories?: string[];
+	    file: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Entry(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.genericName = source["genericName"];
+	        this.comment = source["comment"];
+	        this.icon = source["icon"];
+	        this.exec = source["exec"];
+	        this.path = source["path"];
+	        this.terminal = source["terminal"];
+	        this.keywords = source["keywords"];
+	        this.categories = source["categories"];
+	        this.file = source["file"];
+	    }
+	}
+
+}
+
+export namespace fileindex {
+	
+	export class Result {
+	    path: string;
+	    name: string;
+	    dir: boolean;
+	    score: number;
+	    highlights: search.Highlight[];
+	
+	    static createFrom(source: any = {}) {
+	        return new Result(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.path = source["path"];
+	        this.name = source["name"];
+	        this.dir = source["dir"];
+	        this.score = source["score"];
+	        this.highlights = this.convertValues(source["highlights"], search.Highlight);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Status {
+	    roots: string[];
+	    items: number;
+	    indexing: boolean;
+	    watching: boolean;
+	    truncated: boolean;
+	    // Go type: time
+	    updated: any;
+	    error?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Status(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.roots = source["roots"];
+	        this.items = source["items"];
+	        this.indexing = source["indexing"];
+	        this.watching = source["watching"];
+	        this.truncated = source["truncated"];
+	        this.updated = this.convertValues(source["updated"], null);
+	        this.error = source["error"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+
+}
+
+export namespace hotkeys {
+	
+	export class Conflict {
+	    owner: string;
+	    hotkey: string;
+	    reason: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Conflict(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.owner = source["owner"];
+	        this.hotkey = source["hotkey"];
+	        this.reason = source["reason"];
+	    }
+	}
+
+}
+
+export namespace keywords {
+	
+	export class Match {
+	    owner: string;
+	    keyword: string;
+	    query: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Match(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.owner = source["owner"];
+	        this.keyword = source["keyword"];
+	        this.query = source["query"];
+	    }
+	}
+
+}
+
+export namespace launcher {
+	
+	export class Capabilities {
+	    elevatedApps: boolean;
+	    elevatedShell: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new Capabilities(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.elevatedApps = source["elevatedApps"];
+	        this.elevatedShell = source["elevatedShell"];
+	    }
+	}
+
+}
+
+export namespace main {
+	
+	export class ApplicationResult {
+	    app: desktop.Entry;
+	    score: number;
+	    highlights: search.Highlight[];
+	    tile: config.Tile;
+	
+	    static createFrom(source: any = {}) {
+	        return new ApplicationResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.app = this.convertValues(source["app"], desktop.Entry);
+	        this.score = source["score"];
+	        this.highlights = this.convertValues(source["highlights"], search.Highlight);
+	        this.tile = this.convertValues(source["tile"], config.Tile);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ConfirmRequest {
+	    token: string;
+	    tileId: string;
+	    name: string;
+	    action: string;
+	    target: string;
+	    // Go type: time
+	    expiresAt: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new ConfirmRequest(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.token = source["token"];
+	        this.tileId = source["tileId"];
+	        this.name = source["name"];
+	        this.action = source["action"];
+	        this.target = source["target"];
+	        this.expiresAt = this.convertValues(source["expiresAt"], null);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -118,7 +552,572 @@ export namespace config {
 		    return a;
 		}
 	}
+	export class SearchResult {
+	    kind: string;
+	    tileId: string;
+	    name: string;
+	    path?: string;
+	    score: number;
+	    highlights: search.Highlight[];
+	
+	    static createFrom(source: any = {}) {
+	        return new SearchResult(source);
+	    }
 	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.kind = source["kind"];
+	        this.tileId = source["tileId"];
+	        this.name = source["name"];
+	        this.path = source["path"];
+	        this.score = source["score"];
+	        this.highlights = this.convertValues(source["highlights"], search.Highlight);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+
+}
+
+export namespace plugins {
+	
+	export class Status {
+	    id: string;
+	    path: string;
+	    name: string;
+	    description?: string;
+	    keyword?: string;
+	    status: string;
+	    pid?: number;
+	    restarts: number;
+	    lastError?: string;
+	    // Go type: time
+	    nextRestart?: any;
+	    stderr: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new Status(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.path = source["path"];
+	        this.name = source["name"];
+	        this.description = source["description"];
+	        this.keyword = source["keyword"];
+	        this.status = source["status"];
+	        this.pid = source["pid"];
+	        this.restarts = source["restarts"];
+	        this.lastError = source["lastError"];
+	        this.nextRestart = this.convertValues(source["nextRestart"], null);
+	        this.stderr = source["stderr"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+
+}
+
+export namespace procs {
+	
+	export class Process {
+	    id: string;
+	    tileId: string;
+	    pid: number;
+	    command: string;
+	    // Go type: time
+	    started: any;
+	    uptimeMs: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new Process(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.tileId = source["tileId"];
+	        this.pid = source["pid"];
+	        this.command = source["command"];
+	        this.started = this.convertValues(source["started"], null);
+	        this.uptimeMs = source["uptimeMs"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+
+}
+
+export namespace providers {
+	
+	export class Action {
+	    id: string;
+	    title: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Action(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.title = source["title"];
+	    }
+	}
+	export class Info {
+	    name: string;
+	    enabled: boolean;
+	    timeoutMs: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new Info(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.enabled = source["enabled"];
+	        this.timeoutMs = source["timeoutMs"];
+	    }
+	}
+	export class Result {
+	    provider: string;
+	    kind: string;
+	    id: string;
+	    title: string;
+	    subtitle?: string;
+	    path?: string;
+	    icon?: string;
+	    score: number;
+	    highlights: search.Highlight[];
+	    actions?: Action[];
+	    action?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Result(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.provider = source["provider"];
+	        this.kind = source["kind"];
+	        this.id = source["id"];
+	        this.title = source["title"];
+	        this.subtitle = source["subtitle"];
+	        this.path = source["path"];
+	        this.icon = source["icon"];
+	        this.score = source["score"];
+	        this.highlights = this.convertValues(source["highlights"], search.Highlight);
+	        this.actions = this.convertValues(source["actions"], Action);
+	        this.action = source["action"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Response {
+	    query: string;
+	    results: Result[];
+	    errors?: Record<string, string>;
+	
+	    static createFrom(source: any = {}) {
+	        return new Response(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.query = source["query"];
+	        this.results = this.convertValues(source["results"], Result);
+	        this.errors = source["errors"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+
+}
+
+export namespace runlog {
+	
+	export class Entry {
+	    id: string;
+	    tileId?: string;
+	    tileName?: string;
+	    action: string;
+	    target: string;
+	    path?: string;
+	    query?: string;
+	    // Go type: time
+	    started: any;
+	    durationMs: number;
+	    status: string;
+	    exitCode?: number;
+	    error?: string;
+	    runId?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Entry(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.tileId = source["tileId"];
+	        this.tileName = source["tileName"];
+	        this.action = source["action"];
+	        this.target = source["target"];
+	        this.path = source["path"];
+	        this.query = source["query"];
+	        this.started = this.convertValues(source["started"], null);
+	        this.durationMs = source["durationMs"];
+	        this.status = source["status"];
+	        this.exitCode = source["exitCode"];
+	        this.error = source["error"];
+	        this.runId = source["runId"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Filter {
+	    tileId?: string;
+	    action?: string;
+	    status?: string;
+	    // Go type: time
+	    since?: any;
+	    // Go type: time
+	    until?: any;
+	    query?: string;
+	    limit?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new Filter(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.tileId = source["tileId"];
+	        this.action = source["action"];
+	        this.status = source["status"];
+	        this.since = this.convertValues(source["since"], null);
+	        this.until = this.convertValues(source["until"], null);
+	        this.query = source["query"];
+	        this.limit = source["limit"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+
+}
+
+export namespace runner {
+	
+	export class Result {
+	    id: string;
+	    tileId: string;
+	    action: string;
+	    target: string;
+	    path?: string;
+	    command: string;
+	    // Go type: time
+	    started: any;
+	    durationMs: number;
+	    running: boolean;
+	    exitCode: number;
+	    success: boolean;
+	    stdout: string;
+	    stderr: string;
+	    error?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Result(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.tileId = source["tileId"];
+	        this.action = source["action"];
+	        this.target = source["target"];
+	        this.path = source["path"];
+	        this.command = source["command"];
+	        this.started = this.convertValues(source["started"], null);
+	        this.durationMs = source["durationMs"];
+	        this.running = source["running"];
+	        this.exitCode = source["exitCode"];
+	        this.success = source["success"];
+	        this.stdout = source["stdout"];
+	        this.stderr = source["stderr"];
+	        this.error = source["error"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+
+}
+
+export namespace search {
+	
+	export class Range {
+	    start: number;
+	    end: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new Range(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.start = source["start"];
+	        this.end = source["end"];
+	    }
+	}
+	export class Highlight {
+	    field: string;
+	    ranges: Range[];
+	
+	    static createFrom(source: any = {}) {
+	        return new Highlight(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.field = source["field"];
+	        this.ranges = this.convertValues(source["ranges"], Range);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+
+}
+
+export namespace service {
+	
+	export class LogLine {
+	    // Go type: time
+	    time: any;
+	    stream: string;
+	    text: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new LogLine(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.time = this.convertValues(source["time"], null);
+	        this.stream = source["stream"];
+	        this.text = source["text"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class State {
+	    id: string;
+	    name: string;
+	    status: string;
+	    pid?: number;
+	    // Go type: time
+	    started?: any;
+	    restarts: number;
+	    exitCode?: number;
+	    lastError?: string;
+	    // Go type: time
+	    nextRestart?: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new State(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.status = source["status"];
+	        this.pid = source["pid"];
+	        this.started = this.convertValues(source["started"], null);
+	        this.restarts = source["restarts"];
+	        this.exitCode = source["exitCode"];
+	        this.lastError = source["lastError"];
+	        this.nextRestart = this.convertValues(source["nextRestart"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
 
 }
 
@@ -7,8 +7,10 @@ toolchain go1.24.11
 require (
 	git.sr.ht/~jackmordaunt/go-toast/v2 v2.0.3
 	github.com/creativeprojects/go-selfupdate v1.5.1
+	github.com/fsnotify/fsnotify v1.10.1
 	github.com/getlantern/systray v1.2.2
 	github.com/wailsapp/wails/v2 v2.11.0
+	go.starlark.net v0.0.0-20260210143700-b62fd896b91b
 	golang.design/x/hotkey v0.4.1
 	golang.org/x/sys v0.39.0
 )
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"fmt"
+
+	"golang.design/x/hotkey"
+	"golang.design/x/hotkey/mainthread"
+
+	"quicklaunch/internal/hotkeys"
+)
+
+// defaultHotkey is used when the configured hotkey is missing or invalid
+const defaultHotkey = "Ctrl+Space"
+
+// hotkeyKeys maps canonical key names from the hotkeys package to hotkey keys
+var hotkeyKeys = map[string]hotkey.Key{
+	"Space": hotkey.KeySpace, "Enter": hotkey.KeyReturn, "Escape": hotkey.KeyEscape,
+	"Tab": hotkey.KeyTab, "Delete": hotkey.KeyDelete,
+	"Left": hotkey.KeyLeft, "Right": hotkey.KeyRight, "Up": hotkey.KeyUp, "Down": hotkey.KeyDown,
+	"0": hotkey.Key0, "1": hotkey.Key1, "2": hotkey.Key2, "3": hotkey.Key3, "4": hotkey.Key4,
+	"5": hotkey.Key5, "6": hotkey.Key6, "7": hotkey.Key7, "8": hotkey.Key8, "9": hotkey.Key9,
+	"A": hotkey.KeyA, "B": hotkey.KeyB, "C": hotkey.KeyC, "D": hotkey.KeyD, "E": hotkey.KeyE,
+	"F": hotkey.KeyF, "G": hotkey.KeyG, "H": hotkey.KeyH, "I": hotkey.KeyI, "J": hotkey.KeyJ,
+	"K": hotkey.KeyK, "L": hotkey.KeyL, "M": hotkey.KeyM, "N": hotkey.KeyN, "O": hotkey.KeyO,
+	"P": hotkey.KeyP, "Q": hotkey.KeyQ, "R": hotkey.KeyR, "S": hotkey.KeyS, "T": hotkey.KeyT,
+	"U": hotkey.KeyU, "V": hotkey.KeyV, "W": hotkey.KeyW, "X": hotkey.KeyX, "Y": hotkey.KeyY,
+	"Z": hotkey.KeyZ,
+
+	"F1": hotkey.KeyF1, "F2": hotkey.KeyF2, "F3": hotkey.KeyF3, "F4": hotkey.KeyF4,
+	"F5": hotkey.KeyF5, "F6": hotkey.KeyF6, "F7": hotkey.KeyF7, "F8": hotkey.KeyF8,
+	"F9": hotkey.KeyF9, "F10": hotkey.KeyF10, "F11": hotkey.KeyF11, "F12": hotkey.KeyF12,
+	"F13": hotkey.KeyF13, "F14": hotkey.KeyF14, "F15": hotkey.KeyF15, "F16": hotkey.KeyF16,
+	"F17": hotkey.KeyF17, "F18": hotkey.KeyF18, "F19": hotkey.KeyF19, "F20": hotkey.KeyF20,
+}
+
+// tileHotkey is a registered global hotkey that executes a tile
+type tileHotkey struct {
+	combo hotkeys.Combo
+	hk    *hotkey.Hotkey
+}
+
+// newHotkey creates an unregistered hotkey for the given combo
+func newHotkey(combo hotkeys.Combo) (*hotkey.Hotkey, error) {
+	key, ok := hotkeyKeys[combo.Key]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", hotkeys.ErrUnknownKey, combo.Key)
+	}
+	return hotkey.New(platformModifiers(combo), key), nil
+}
+
+// registerOnMainThread registers hk on the hotkey main thread.
+// Registration must happen there on macOS; elsewhere it is harmless.
+func registerOnMainThread(hk *hotkey.Hotkey) error {
+	var err error
+	done := make(chan struct{})
+	mainthread.Call(func() {
+		err = hk.Register()
+		close(done)
+	})
+	<-done
+	return err
+}
+
+// unregisterOnMainThread unregisters hk on the hotkey main thread
+func unregisterOnMainThread(hk *hotkey.Hotkey) {
+	done := make(chan struct{})
+	mainthread.Call(func() {
+		hk.Unregister()
+		close(done)
+	})
+	<-done
+}
+
+// listenHotkey calls fn for every keydown until hk is unregistered
+func listenHotkey(hk *hotkey.Hotkey, fn func()) {
+	for range hk.Keydown() {
+		fn()
+	}
+}
@@ -0,0 +1,28 @@
+//go:build darwin
+
+package main
+
+import (
+	"golang.design/x/hotkey"
+
+	"quicklaunch/internal/hotkeys"
+)
+
+// platformModifiers converts combo modifiers to macOS hotkey modifiers.
+// Alt maps to Option and Super maps to Cmd.
+func platformModifiers(combo hotkeys.Combo) []hotkey.Modifier {
+	var mods []hotkey.Modifier
+	if combo.Has(hotkeys.ModCtrl) {
+		mods = append(mods, hotkey.ModCtrl)
+	}
+	if combo.Has(hotkeys.ModAlt) {
+		mods = append(mods, hotkey.ModOption)
+	}
+	if combo.Has(hotkeys.ModShift) {
+		mods = append(mods, hotkey.ModShift)
+	}
+	if combo.Has(hotkeys.ModSuper) {
+		mods = append(mods, hotkey.ModCmd)
+	}
+	return mods
+}
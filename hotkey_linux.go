@@ -0,0 +1,28 @@
+//go:build linux
+
+package main
+
+import (
+	"golang.design/x/hotkey"
+
+	"quicklaunch/internal/hotkeys"
+)
+
+// platformModifiers converts combo modifiers to X11 hotkey modifiers.
+// Alt is Mod1 and Super is Mod4 on virtually every X11 keymap.
+func platformModifiers(combo hotkeys.Combo) []hotkey.Modifier {
+	var mods []hotkey.Modifier
+	if combo.Has(hotkeys.ModCtrl) {
+		mods = append(mods, hotkey.ModCtrl)
+	}
+	if combo.Has(hotkeys.ModAlt) {
+		mods = append(mods, hotkey.Mod1)
+	}
+	if combo.Has(hotkeys.ModShift) {
+		mods = append(mods, hotkey.ModShift)
+	}
+	if combo.Has(hotkeys.ModSuper) {
+		mods = append(mods, hotkey.Mod4)
+	}
+	return mods
+}
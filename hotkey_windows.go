@@ -0,0 +1,27 @@
+//go:build windows
+
+package main
+
+import (
+	"golang.design/x/hotkey"
+
+	"quicklaunch/internal/hotkeys"
+)
+
+// platformModifiers converts combo modifiers to Windows hotkey modifiers
+func platformModifiers(combo hotkeys.Combo) []hotkey.Modifier {
+	var mods []hotkey.Modifier
+	if combo.Has(hotkeys.ModCtrl) {
+		mods = append(mods, hotkey.ModCtrl)
+	}
+	if combo.Has(hotkeys.ModAlt) {
+		mods = append(mods, hotkey.ModAlt)
+	}
+	if combo.Has(hotkeys.ModShift) {
+		mods = append(mods, hotkey.ModShift)
+	}
+	if combo.Has(hotkeys.ModSuper) {
+		mods = append(mods, hotkey.ModWin)
+	}
+	return mods
+}
@@ -0,0 +1,69 @@
+package actions
+
+import (
+	"strings"
+
+	"quicklaunch/internal/focus"
+)
+
+func init() {
+	Register(appHandler{})
+}
+
+// appHandler launches an application, optionally with a path argument
+type appHandler struct{}
+
+func (appHandler) Describe() Description {
+	return Description{
+		Type:              "app",
+		Label:             "Anwendung",
+		Description:       "Startet ein Programm, optional mit einem Ordner als Argument",
+		NeedsTarget:       true,
+		SupportsPath:      true,
+		SupportsElevation: true,
+	}
+}
+
+func (appHandler) Validate(req Request) error {
+	if req.Target == "" {
+		return ErrMissingTarget
+	}
+	return nil
+}
+
+func (appHandler) Execute(env Env, req Request) error {
+	if focused(env, req) {
+		return nil
+	}
+
+	if req.Elevated {
+		el, err := env.Executor.ElevatedAppCommand(req.spec())
+		if err != nil {
+			return err
+		}
+		return startElevated(env, el, req)
+	}
+
+	cmd, err := env.Executor.AppCommand(req.spec())
+	if err != nil {
+		return err
+	}
+	return env.Runner.Start(cmd, req)
+}
+
+// command returns the program and its arguments for the command policy
+func (appHandler) command(req Request) string {
+	return strings.Join(append([]string{req.Target}, req.Args...), " ")
+}
+
+// focused activates a running instance for single-instance requests and
+// reports whether one was found. Requests with a path always launch, the
+// path is what the user wants to open. Lookup errors fall back to
+// launching.
+func focused(env Env, req Request) bool {
+	if !req.SingleInstance || req.Path != "" || env.Focuser == nil {
+		return false
+	}
+	ok, err := env.Focuser.Focus(focus.Match{Exe: req.Target, Class: req.WindowClass})
+	return ok && err == nil
+}
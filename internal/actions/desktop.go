@@ -0,0 +1,100 @@
+package actions
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+
+	"quicklaunch/internal/desktop"
+	"quicklaunch/internal/launcher"
+)
+
+// Applications is the catalog of installed desktop applications that
+// desktop actions launch from
+var Applications = desktop.NewCatalog(desktop.DefaultOptions(os.Getenv))
+
+// desktopHandler launches an application from its desktop entry. The
+// target is a desktop file ID like "firefox.desktop" or the path of a
+// desktop entry file; the path is passed as file or URL argument.
+type desktopHandler struct {
+	catalog *desktop.Catalog
+}
+
+func (desktopHandler) Describe() Description {
+	return Description{
+		Type:         "desktop",
+		Label:        "Installierte Anwendung",
+		Description:  "Startet eine Anwendung aus dem Anwendungsmenü, optional mit einer Datei",
+		NeedsTarget:  true,
+		SupportsPath: true,
+	}
+}
+
+func (desktopHandler) Validate(req Request) error {
+	if req.Target == "" {
+		return ErrMissingTarget
+	}
+	return nil
+}
+
+// Execute starts the entry's Exec command, in a terminal for entries that
+// ask for one
+func (h desktopHandler) Execute(env Env, req Request) error {
+	entry, argv, err := h.resolve(req)
+	if err != nil {
+		return err
+	}
+
+	dir := entry.Path
+	if dir == "" {
+		dir = req.WorkDir
+	}
+
+	if entry.Terminal {
+		kind, err := env.Executor.ShellKind(req.Profile)
+		if err != nil {
+			return err
+		}
+		quoted := make([]string, len(argv))
+		for i, arg := range argv {
+			if quoted[i], err = kind.Quote(arg); err != nil {
+				return err
+			}
+		}
+		cmd, err := env.Executor.ShellCommand(launcher.Spec{
+			Target:  strings.Join(quoted, " "),
+			WorkDir: dir,
+			Profile: req.Profile,
+		})
+		if err != nil {
+			return err
+		}
+		return env.Runner.Start(cmd, req)
+	}
+
+	cmd := exec.Command(argv[0], argv[1:]...)
+	cmd.Dir = dir
+	return env.Runner.Start(cmd, req)
+}
+
+// resolve looks up the entry of a request and expands its Exec command
+func (h desktopHandler) resolve(req Request) (desktop.Entry, []string, error) {
+	entry, err := h.catalog.Find(req.Target)
+	if err != nil {
+		return desktop.Entry{}, nil, err
+	}
+	var targets []string
+	if req.Path != "" {
+		targets = []string{req.Path}
+	}
+	argv, err := entry.Command(targets)
+	return entry, argv, err
+}
+
+// command returns the expanded command for the command policy
+func (h desktopHandler) command(req Request) string {
+	if _, argv, err := h.resolve(req); err == nil {
+		return strings.Join(argv, " ")
+	}
+	return req.Target
+}
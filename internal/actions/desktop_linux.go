@@ -0,0 +1,8 @@
+//go:build linux
+
+package actions
+
+func init() {
+	// Desktop entries only exist on Linux desktops
+	Register(desktopHandler{catalog: Applications})
+}
@@ -0,0 +1,35 @@
+package actions
+
+func init() {
+	Register(folderHandler{})
+}
+
+// folderHandler opens a folder in the platform file manager
+type folderHandler struct{}
+
+func (folderHandler) Describe() Description {
+	return Description{
+		Type:         "folder",
+		Label:        "Ordner",
+		Description:  "Öffnet einen Ordner im Dateimanager",
+		NeedsTarget:  false,
+		SupportsPath: true,
+	}
+}
+
+func (folderHandler) Validate(req Request) error {
+	return nil
+}
+
+// Execute opens the selected path, or the tile target if no path was chosen
+func (folderHandler) Execute(env Env, req Request) error {
+	path := req.Path
+	if path == "" {
+		path = req.Target
+	}
+	cmd, err := env.Executor.FolderCommand(path)
+	if err != nil {
+		return err
+	}
+	return env.Runner.Start(cmd, req)
+}
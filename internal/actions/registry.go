@@ -0,0 +1,425 @@
+package actions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"sort"
+	"sync"
+	"time"
+
+	"quicklaunch/internal/focus"
+	"quicklaunch/internal/guard"
+	"quicklaunch/internal/launcher"
+	"quicklaunch/internal/vars"
+	"quicklaunch/internal/workflow"
+)
+
+// Request describes a single execution of an action
+type Request struct {
+	// Action is the resolved action type, set by Registry.Execute
+	Action string
+	// TileID identifies the tile the request came from, if any
+	TileID  string
+	Target  string
+	Args    []string
+	Path    string
+	WorkDir string
+	Profile launcher.Profile
+	// Background runs shell commands without a terminal window and
+	// captures their output instead
+	Background bool
+	// Wait blocks until the started process has exited and fails if it
+	// exited with a non-zero code
+	Wait bool
+	// Context ends a wait early once it is done, the process keeps
+	// running; nil waits until the process has exited. Workflows stop
+	// before their next step.
+	Context context.Context
+	// Elevated launches with administrator rights where the action
+	// supports it
+	Elevated bool
+	// SingleInstance activates a running instance of the app instead of
+	// launching another one; WindowClass narrows the window lookup
+	SingleInstance bool
+	WindowClass    string
+	// Steps are the steps of a workflow request
+	Steps []Step
+	// Progress receives the progress of a workflow request, may be nil
+	Progress func(workflow.Progress)
+	// Vars expands placeholders like {path} or {input:Prompt} in the
+	// target, arguments and working directory; nil disables expansion
+	Vars *vars.Context
+	// RequireConfirm refuses the request unless ConfirmToken is a token
+	// issued for TileID by Registry.IssueConfirmation
+	RequireConfirm bool
+	ConfirmToken   string
+}
+
+// Step is one step of a workflow. Request.Action holds the step's
+// action type.
+type Step struct {
+	Request
+	Name            string
+	Delay           time.Duration
+	ContinueOnError bool
+}
+
+// spec converts the request to a launcher spec
+func (r Request) spec() launcher.Spec {
+	return launcher.Spec{
+		Target:  r.Target,
+		Args:    r.Args,
+		Path:    r.Path,
+		WorkDir: r.WorkDir,
+		Profile: r.Profile,
+	}
+}
+
+// Description describes an action type for the UI
+type Description struct {
+	Type         string `json:"type"`
+	Label        string `json:"label"`
+	Description  string `json:"description"`
+	NeedsTarget  bool   `json:"needsTarget"`
+	SupportsPath bool   `json:"supportsPath"`
+	// SupportsElevation is set for actions that can run elevated; see
+	// Registry.Capabilities for what the system supports
+	SupportsElevation bool `json:"supportsElevation"`
+}
+
+// Runner starts the processes that handlers build. Both methods wait for
+// the process to exit instead when req.Wait is set.
+type Runner interface {
+	// Start launches cmd detached, e.g. GUI apps and terminal windows
+	Start(cmd *exec.Cmd, req Request) error
+	// Run launches cmd in the background and collects its output and
+	// exit status; it returns once the process has started
+	Run(cmd *exec.Cmd, req Request) error
+}
+
+// Focuser activates windows of running applications
+type Focuser interface {
+	// Focus activates the first window matching m and reports whether
+	// there was one
+	Focus(m focus.Match) (bool, error)
+}
+
+// Supervisor keeps service processes running
+type Supervisor interface {
+	// Supervise starts the service of req unless it is already running.
+	// command builds the process for every start and restart.
+	Supervise(req Request, command func() (*exec.Cmd, error)) error
+}
+
+// Scripter runs the scripts of script requests
+type Scripter interface {
+	// RunScript runs the script file named by req.Target. It returns once
+	// the script has started, or once it has ended if req.Wait is set.
+	RunScript(req Request) error
+}
+
+// Env gives handlers access to the platform executor, the runner, the
+// focuser, the service supervisor and the scripter
+type Env struct {
+	Executor   *launcher.Executor
+	Runner     Runner
+	Focuser    Focuser
+	Supervisor Supervisor
+	Scripter   Scripter
+}
+
+// Handler validates and executes one action type
+type Handler interface {
+	// Describe returns the action type and its UI description
+	Describe() Description
+	// Validate checks a request before it is executed
+	Validate(req Request) error
+	// Execute runs the request
+	Execute(env Env, req Request) error
+}
+
+// detachedRunner starts processes without collecting any results
+type detachedRunner struct{}
+
+func (detachedRunner) Start(cmd *exec.Cmd, req Request) error {
+	if req.Wait {
+		return cmd.Run()
+	}
+	return cmd.Start()
+}
+
+func (detachedRunner) Run(cmd *exec.Cmd, req Request) error {
+	if req.Wait {
+		return cmd.Run()
+	}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	go cmd.Wait()
+	return nil
+}
+
+// windowFocuser activates windows through the focus package
+type windowFocuser struct{}
+
+func (windowFocuser) Focus(m focus.Match) (bool, error) {
+	return focus.FocusExisting(m)
+}
+
+// startElevated starts an elevated command. Commands that exit after the
+// elevation prompt are waited for, so a cancelled prompt is reported as
+// launcher.ErrElevationCancelled.
+func startElevated(env Env, el launcher.Elevation, req Request) error {
+	if !el.Prompt {
+		return env.Runner.Start(el.Cmd, req)
+	}
+	req.Wait = true
+	return el.Err(env.Runner.Start(el.Cmd, req))
+}
+
+// UnknownActionError is returned for action types without a handler
+type UnknownActionError struct {
+	Type string
+}
+
+func (e *UnknownActionError) Error() string {
+	return fmt.Sprintf("unknown action type %q", e.Type)
+}
+
+var (
+	// ErrMissingTarget is returned when an action requires a target but got none
+	ErrMissingTarget = errors.New("missing target")
+	// ErrElevationNotSupported is returned for elevated requests of
+	// actions that cannot run elevated
+	ErrElevationNotSupported = errors.New("action cannot run elevated")
+)
+
+// Registry maps action types to their handlers
+type Registry struct {
+	mu       sync.RWMutex
+	handlers map[string]Handler
+	aliases  map[string]string
+	executor *launcher.Executor
+	runner   Runner
+	focuser  Focuser
+	services Supervisor
+	scripts  Scripter
+	policy   guard.Policy
+	tokens   *guard.Tokens
+}
+
+// NewRegistry creates an empty registry using the given executor
+func NewRegistry(x *launcher.Executor) *Registry {
+	return &Registry{
+		handlers: make(map[string]Handler),
+		aliases:  make(map[string]string),
+		executor: x,
+		runner:   detachedRunner{},
+		focuser:  windowFocuser{},
+		tokens:   guard.NewTokens(guard.DefaultTokenTTL),
+	}
+}
+
+// Default is the registry the built-in action types register with
+var Default = NewRegistry(launcher.New(launcher.Options{}))
+
+// Register adds a handler to the default registry
+func Register(h Handler) {
+	if err := Default.Register(h); err != nil {
+		panic(err)
+	}
+}
+
+// Register adds a handler. Registering a type twice is an error.
+func (r *Registry) Register(h Handler) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	t := h.Describe().Type
+	if t == "" {
+		return errors.New("action handler without type")
+	}
+	if _, ok := r.handlers[t]; ok {
+		return fmt.Errorf("action type %q already registered", t)
+	}
+	r.handlers[t] = h
+	return nil
+}
+
+// Alias makes alias resolve to an already registered action type.
+// Aliases keep older configurations working after a type was renamed.
+func (r *Registry) Alias(alias, actionType string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.aliases[alias] = actionType
+}
+
+// SetExecutor replaces the platform executor used by all handlers
+func (r *Registry) SetExecutor(x *launcher.Executor) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.executor = x
+}
+
+// SetRunner replaces the runner used by all handlers
+func (r *Registry) SetRunner(runner Runner) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.runner = runner
+}
+
+// SetFocuser replaces the focuser used for single-instance launches
+func (r *Registry) SetFocuser(f Focuser) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.focuser = f
+}
+
+// SetSupervisor sets the supervisor that runs service requests
+func (r *Registry) SetSupervisor(s Supervisor) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.services = s
+}
+
+// SetScripter sets the scripter that runs script requests
+func (r *Registry) SetScripter(s Scripter) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.scripts = s
+}
+
+// SetPolicy replaces the command policy checked before commands run
+func (r *Registry) SetPolicy(p guard.Policy) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.policy = p
+}
+
+// Capabilities returns the optional launch features of the executor
+func (r *Registry) Capabilities() launcher.Capabilities {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.executor.Capabilities()
+}
+
+// IssueConfirmation returns a single-use token that confirms the next
+// execution of the given tile
+func (r *Registry) IssueConfirmation(tileID string) (string, time.Time, error) {
+	return r.tokens.Issue(tileID)
+}
+
+// Lookup returns the handler for an action type
+func (r *Registry) Lookup(actionType string) (Handler, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if t, ok := r.aliases[actionType]; ok {
+		actionType = t
+	}
+	h, ok := r.handlers[actionType]
+	if !ok {
+		return nil, &UnknownActionError{Type: actionType}
+	}
+	return h, nil
+}
+
+// Execute expands placeholders, validates and executes a request for the
+// given action type
+func (r *Registry) Execute(actionType string, req Request) error {
+	h, err := r.Lookup(actionType)
+	if err != nil {
+		return err
+	}
+
+	r.mu.RLock()
+	env := Env{Executor: r.executor, Runner: r.runner, Focuser: r.focuser, Supervisor: r.services, Scripter: r.scripts}
+	policy := r.policy
+	r.mu.RUnlock()
+
+	if req.Vars != nil {
+		if req, err = expand(h, env, req); err != nil {
+			return err
+		}
+	}
+	if req.Elevated && !h.Describe().SupportsElevation {
+		return fmt.Errorf("%s: %w", h.Describe().Type, ErrElevationNotSupported)
+	}
+	if err := h.Validate(req); err != nil {
+		return fmt.Errorf("invalid %s action: %w", h.Describe().Type, err)
+	}
+	req.Action = h.Describe().Type
+
+	if c, ok := h.(commander); ok {
+		if err := policy.Check(c.command(req)); err != nil {
+			return err
+		}
+	}
+	// The token is only used up once everything else checked out
+	if req.RequireConfirm {
+		if err := r.tokens.Consume(req.ConfirmToken, req.TileID); err != nil {
+			return err
+		}
+	}
+
+	return h.Execute(env, req)
+}
+
+// commander is implemented by handlers that run commands, which are
+// checked against the registry's command policy
+type commander interface {
+	command(req Request) string
+}
+
+// valueQuoter is implemented by handlers whose target is a command line
+// or URL, so substituted values must be escaped to keep their meaning
+type valueQuoter interface {
+	quoteValue(env Env, req Request) (vars.Quote, error)
+}
+
+// expand substitutes the placeholders of a request. Arguments and the
+// working directory are passed on as separate values and never escaped.
+func expand(h Handler, env Env, req Request) (Request, error) {
+	var quote vars.Quote
+	if q, ok := h.(valueQuoter); ok {
+		var err error
+		if quote, err = q.quoteValue(env, req); err != nil {
+			return req, err
+		}
+	}
+
+	target, err := vars.Expand(req.Target, *req.Vars, quote)
+	if err != nil {
+		return req, err
+	}
+	workDir, err := vars.Expand(req.WorkDir, *req.Vars, nil)
+	if err != nil {
+		return req, err
+	}
+	var args []string
+	for _, arg := range req.Args {
+		expanded, err := vars.Expand(arg, *req.Vars, nil)
+		if err != nil {
+			return req, err
+		}
+		args = append(args, expanded)
+	}
+
+	req.Target, req.WorkDir, req.Args = target, workDir, args
+	return req, nil
+}
+
+// Descriptions returns the descriptions of all registered types sorted by type
+func (r *Registry) Descriptions() []Description {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	descs := make([]Description, 0, len(r.handlers))
+	for _, h := range r.handlers {
+		descs = append(descs, h.Describe())
+	}
+	sort.Slice(descs, func(i, j int) bool { return descs[i].Type < descs[j].Type })
+	return descs
+}
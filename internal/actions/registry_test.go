@@ -0,0 +1,518 @@
+package actions
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"quicklaunch/internal/desktop"
+	"quicklaunch/internal/focus"
+	"quicklaunch/internal/guard"
+	"quicklaunch/internal/launcher"
+	"quicklaunch/internal/vars"
+	"quicklaunch/internal/workflow"
+)
+
+// recordingHandler remembers the last executed request
+type recordingHandler struct {
+	typ  string
+	last *Request
+}
+
+func (h recordingHandler) Describe() Description {
+	return Description{Type: h.typ, NeedsTarget: true}
+}
+
+func (h recordingHandler) Validate(req Request) error {
+	if req.Target == "" {
+		return ErrMissingTarget
+	}
+	return nil
+}
+
+func (h recordingHandler) Execute(env Env, req Request) error {
+	*h.last = req
+	return nil
+}
+
+func TestRegistryExecute(t *testing.T) {
+	r := NewRegistry(launcher.New(launcher.Options{}))
+	var last Request
+	if err := r.Register(recordingHandler{typ: "test", last: &last}); err != nil {
+		t.Fatalf("Register returned error: %v", err)
+	}
+
+	if err := r.Execute("test", Request{Target: "x", Path: "/tmp"}); err != nil {
+		t.Fatalf("Execute returned error: %v", err)
+	}
+	if last.Target != "x" || last.Path != "/tmp" {
+		t.Errorf("handler received %+v", last)
+	}
+
+	if err := r.Execute("test", Request{}); !errors.Is(err, ErrMissingTarget) {
+		t.Errorf("expected ErrMissingTarget, got %v", err)
+	}
+}
+
+func TestRegistryUnknownType(t *testing.T) {
+	r := NewRegistry(nil)
+
+	err := r.Execute("internal", Request{Target: "settings"})
+	var unknown *UnknownActionError
+	if !errors.As(err, &unknown) {
+		t.Fatalf("expected UnknownActionError, got %v", err)
+	}
+	if unknown.Type != "internal" {
+		t.Errorf("Type = %q, want internal", unknown.Type)
+	}
+}
+
+func TestRegistryDuplicateAndAlias(t *testing.T) {
+	r := NewRegistry(nil)
+	var last Request
+	r.Register(recordingHandler{typ: "shell", last: &last})
+
+	if err := r.Register(recordingHandler{typ: "shell", last: &last}); err == nil {
+		t.Error("expected error when registering a type twice")
+	}
+
+	r.Alias("powershell", "shell")
+	if _, err := r.Lookup("powershell"); err != nil {
+		t.Errorf("alias lookup failed: %v", err)
+	}
+	if err := r.Execute("powershell", Request{Target: "dir"}); err != nil || last.Action != "shell" {
+		t.Errorf("expected resolved action type shell, got %q (%v)", last.Action, err)
+	}
+}
+
+func TestBuiltinActions(t *testing.T) {
+	var types []string
+	for _, d := range Default.Descriptions() {
+		types = append(types, d.Type)
+	}
+
+	want := []string{"app", "folder", "script", "search", "service", "shell", "url", "workflow"}
+	if runtime.GOOS == "linux" {
+		want = []string{"app", "desktop", "folder", "script", "search", "service", "shell", "url", "workflow"}
+	}
+	if len(types) != len(want) {
+		t.Fatalf("built-in types = %v, want %v", types, want)
+	}
+	for i := range want {
+		if types[i] != want[i] {
+			t.Fatalf("built-in types = %v, want %v", types, want)
+		}
+	}
+
+	if _, err := Default.Lookup("powershell"); err != nil {
+		t.Errorf("powershell should resolve to shell: %v", err)
+	}
+}
+
+// fakeRunner records which start method a handler used
+type fakeRunner struct {
+	started []*exec.Cmd
+	run     []*exec.Cmd
+}
+
+func (r *fakeRunner) Start(cmd *exec.Cmd, req Request) error {
+	r.started = append(r.started, cmd)
+	return nil
+}
+
+func (r *fakeRunner) Run(cmd *exec.Cmd, req Request) error {
+	r.run = append(r.run, cmd)
+	return nil
+}
+
+func TestShellBackgroundMode(t *testing.T) {
+	r := NewRegistry(launcher.New(launcher.Options{}))
+	r.Register(shellHandler{})
+	runner := &fakeRunner{}
+	r.SetRunner(runner)
+
+	req := Request{Target: "make test", Profile: launcher.Profile{Shell: "sh"}, Background: true}
+	if err := r.Execute("shell", req); err != nil {
+		t.Fatalf("Execute returned error: %v", err)
+	}
+	if len(runner.run) != 1 || len(runner.started) != 0 {
+		t.Fatalf("background request should use Run, got run=%d start=%d", len(runner.run), len(runner.started))
+	}
+	if got := runner.run[0].Args; len(got) != 3 || got[0] != "sh" || got[1] != "-c" || got[2] != "make test" {
+		t.Errorf("unexpected background command %q", got)
+	}
+}
+
+func TestWorkflow(t *testing.T) {
+	r := NewRegistry(launcher.New(launcher.Options{}))
+	var last Request
+	r.Register(recordingHandler{typ: "test", last: &last})
+	r.Register(workflowHandler{registry: r})
+
+	done := make(chan workflow.Progress, 1)
+	req := Request{
+		Steps: []Step{
+			{Request: Request{Action: "test", Target: "first"}},
+			{Request: Request{Action: "test", Target: "second", Path: "/tmp"}},
+		},
+		Progress: func(p workflow.Progress) {
+			if p.Status == workflow.StatusFinished || p.Status == workflow.StatusAborted {
+				done <- p
+			}
+		},
+	}
+	if err := r.Execute("workflow", req); err != nil {
+		t.Fatalf("Execute returned error: %v", err)
+	}
+
+	select {
+	case p := <-done:
+		if p.Status != workflow.StatusFinished || p.Total != 2 {
+			t.Errorf("unexpected final progress %+v", p)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("workflow did not finish")
+	}
+	if last.Target != "second" || last.Path != "/tmp" || last.Context == nil {
+		t.Errorf("last step received %+v", last)
+	}
+}
+
+func TestWorkflowCancel(t *testing.T) {
+	r := NewRegistry(launcher.New(launcher.Options{}))
+	var last Request
+	r.Register(recordingHandler{typ: "test", last: &last})
+	r.Register(workflowHandler{registry: r})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan workflow.Progress, 1)
+	req := Request{
+		Context: ctx,
+		Steps: []Step{
+			{Request: Request{Action: "test", Target: "first"}},
+			{Request: Request{Action: "test", Target: "second"}, Delay: time.Hour},
+		},
+		Progress: func(p workflow.Progress) {
+			if p.Status == workflow.StatusWaiting {
+				cancel()
+			}
+			if p.Status == workflow.StatusFinished || p.Status == workflow.StatusAborted {
+				done <- p
+			}
+		},
+	}
+	if err := r.Execute("workflow", req); err != nil {
+		t.Fatalf("Execute returned error: %v", err)
+	}
+
+	select {
+	case p := <-done:
+		if p.Status != workflow.StatusAborted || p.Step != 2 {
+			t.Errorf("unexpected final progress %+v", p)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("cancelling did not stop the workflow")
+	}
+	if last.Target != "first" {
+		t.Errorf("the second step should not run, last step received %+v", last)
+	}
+}
+
+func TestWorkflowValidation(t *testing.T) {
+	r := NewRegistry(nil)
+	var last Request
+	r.Register(recordingHandler{typ: "test", last: &last})
+	r.Register(workflowHandler{registry: r})
+
+	tests := []struct {
+		name  string
+		steps []Step
+	}{
+		{"no steps", nil},
+		{"unknown type", []Step{{Request: Request{Action: "nope", Target: "x"}}}},
+		{"missing target", []Step{{Request: Request{Action: "test", Target: "x"}}, {Request: Request{Action: "test"}}}},
+		{"nested", []Step{{Request: Request{Action: "workflow"}}}},
+	}
+	for _, tt := range tests {
+		if err := r.Execute("workflow", Request{Steps: tt.steps}); err == nil {
+			t.Errorf("%s: expected validation error", tt.name)
+		}
+	}
+	if last.Target != "" {
+		t.Errorf("no step should run when validation fails, got %+v", last)
+	}
+}
+
+func TestExpandPlaceholders(t *testing.T) {
+	r := NewRegistry(launcher.New(launcher.Options{}))
+	r.Register(shellHandler{})
+	runner := &fakeRunner{}
+	r.SetRunner(runner)
+
+	req := Request{
+		Target:     "grep {input:Pattern} {path}",
+		Args:       []string{"{input:Pattern}"},
+		Path:       "/tmp",
+		Profile:    launcher.Profile{Shell: "sh"},
+		Background: true,
+		Vars:       &vars.Context{Path: "/tmp/my dir", Inputs: map[string]string{"Pattern": "a'b; rm -rf ~"}},
+	}
+	if err := r.Execute("shell", req); err != nil {
+		t.Fatalf("Execute returned error: %v", err)
+	}
+
+	want := `grep 'a'\''b; rm -rf ~' '/tmp/my dir' 'a'\''b; rm -rf ~'`
+	if got := runner.run[0].Args[2]; got != want {
+		t.Errorf("script = %q, want %q", got, want)
+	}
+
+	req.Vars = &vars.Context{}
+	var missing *vars.MissingInputError
+	if err := r.Execute("shell", req); !errors.As(err, &missing) || missing.Prompts[0] != "Pattern" {
+		t.Errorf("expected MissingInputError, got %v", err)
+	}
+}
+
+func TestURLQuoteValue(t *testing.T) {
+	quote, _ := urlHandler{}.quoteValue(Env{}, Request{Target: "https://jira/browse/{input:Ticket}"})
+	if got, _ := quote("input", "QL 42&x=1"); got != "QL%2042%26x%3D1" {
+		t.Errorf("quote = %q", got)
+	}
+	if got, _ := quote("path", "/home/me/my docs/a#1"); got != "/home/me/my%20docs/a%231" {
+		t.Errorf("quote of a path = %q", got)
+	}
+
+	if quote, _ := (urlHandler{}).quoteValue(Env{}, Request{Target: "{clipboard}"}); quote != nil {
+		t.Error("a target that is only a placeholder should not be escaped")
+	}
+
+	// Search queries are escaped like any other URL value
+	if _, ok := Handler(searchHandler{}).(valueQuoter); !ok {
+		t.Error("search handler should escape substituted values")
+	}
+}
+
+func TestCommandPolicy(t *testing.T) {
+	r := NewRegistry(launcher.New(launcher.Options{}))
+	r.Register(shellHandler{})
+	runner := &fakeRunner{}
+	r.SetRunner(runner)
+	r.SetPolicy(guard.Policy{Deny: []string{"rm *"}})
+
+	req := Request{Target: "echo {input:Name}", Profile: launcher.Profile{Shell: "sh"}, Background: true,
+		Vars: &vars.Context{Inputs: map[string]string{"Name": "x; rm -rf ~"}}}
+	if err := r.Execute("shell", req); err != nil {
+		t.Errorf("quoted input should not be split into commands, got %v", err)
+	}
+
+	req = Request{Target: "make clean; rm -rf build", Profile: launcher.Profile{Shell: "sh"}, Background: true}
+	var denied *guard.DeniedError
+	if err := r.Execute("shell", req); !errors.As(err, &denied) {
+		t.Errorf("expected DeniedError, got %v", err)
+	}
+	if len(runner.run) != 1 {
+		t.Errorf("denied command must not run, ran %d commands", len(runner.run))
+	}
+}
+
+func TestRequireConfirm(t *testing.T) {
+	r := NewRegistry(launcher.New(launcher.Options{}))
+	var last Request
+	r.Register(recordingHandler{typ: "test", last: &last})
+
+	req := Request{TileID: "deploy", Target: "x", RequireConfirm: true}
+	if err := r.Execute("test", req); !errors.Is(err, guard.ErrConfirmationRequired) {
+		t.Fatalf("expected ErrConfirmationRequired, got %v", err)
+	}
+
+	other, _, _ := r.IssueConfirmation("other")
+	req.ConfirmToken = other
+	if err := r.Execute("test", req); !errors.Is(err, guard.ErrInvalidToken) {
+		t.Fatalf("token of another tile must be rejected, got %v", err)
+	}
+	if last.Target != "" {
+		t.Fatal("guarded request ran without confirmation")
+	}
+
+	req.ConfirmToken, _, _ = r.IssueConfirmation("deploy")
+	if err := r.Execute("test", req); err != nil {
+		t.Fatalf("confirmed request returned %v", err)
+	}
+	if err := r.Execute("test", req); !errors.Is(err, guard.ErrInvalidToken) {
+		t.Errorf("tokens must be single-use, got %v", err)
+	}
+}
+
+func TestElevatedRequests(t *testing.T) {
+	r := NewRegistry(launcher.New(launcher.Options{}))
+	r.Register(urlHandler{})
+	r.Register(shellHandler{})
+	runner := &fakeRunner{}
+	r.SetRunner(runner)
+
+	if err := r.Execute("url", Request{Target: "https://example.com", Elevated: true}); !errors.Is(err, ErrElevationNotSupported) {
+		t.Errorf("expected ErrElevationNotSupported for url, got %v", err)
+	}
+	if err := r.Execute("shell", Request{Target: "make", Elevated: true, Background: true}); err == nil {
+		t.Error("elevated background commands should be rejected")
+	}
+	if len(runner.started)+len(runner.run) != 0 {
+		t.Error("rejected requests must not start anything")
+	}
+}
+
+// fakeFocuser reports a running instance for the configured executable
+type fakeFocuser struct {
+	exe     string
+	matches []focus.Match
+}
+
+func (f *fakeFocuser) Focus(m focus.Match) (bool, error) {
+	f.matches = append(f.matches, m)
+	return m.Exe == f.exe, nil
+}
+
+func TestSingleInstance(t *testing.T) {
+	r := NewRegistry(launcher.New(launcher.Options{}))
+	r.Register(appHandler{})
+	runner := &fakeRunner{}
+	r.SetRunner(runner)
+	focuser := &fakeFocuser{exe: "/bin/true"}
+	r.SetFocuser(focuser)
+
+	if err := r.Execute("app", Request{Target: "/bin/true", SingleInstance: true, WindowClass: "Code"}); err != nil {
+		t.Fatalf("Execute returned error: %v", err)
+	}
+	if len(runner.started) != 0 {
+		t.Error("a running instance should be focused instead of launched")
+	}
+	if m := focuser.matches[0]; m.Exe != "/bin/true" || m.Class != "Code" {
+		t.Errorf("unexpected match %+v", m)
+	}
+
+	// Not running, with a path and without single-instance mode all launch
+	for _, req := range []Request{
+		{Target: "/bin/false", SingleInstance: true},
+		{Target: "/bin/true", Path: "/tmp", SingleInstance: true},
+		{Target: "/bin/true"},
+	} {
+		r.Execute("app", req)
+	}
+	if len(runner.started) != 3 {
+		t.Errorf("expected 3 launches, got %d", len(runner.started))
+	}
+	if len(focuser.matches) != 2 {
+		t.Errorf("expected 2 window lookups, got %d", len(focuser.matches))
+	}
+}
+
+// fakeSupervisor records supervised requests and builds their commands
+type fakeSupervisor struct {
+	reqs []Request
+	cmds []*exec.Cmd
+}
+
+func (s *fakeSupervisor) Supervise(req Request, command func() (*exec.Cmd, error)) error {
+	cmd, err := command()
+	if err != nil {
+		return err
+	}
+	s.reqs = append(s.reqs, req)
+	s.cmds = append(s.cmds, cmd)
+	return nil
+}
+
+func TestService(t *testing.T) {
+	r := NewRegistry(launcher.New(launcher.Options{}))
+	r.Register(serviceHandler{})
+
+	req := Request{TileID: "proxy", Target: "mitmproxy -p 8080", Profile: launcher.Profile{Shell: "sh"}}
+	if err := r.Execute("service", req); !errors.Is(err, ErrNoSupervisor) {
+		t.Errorf("expected ErrNoSupervisor, got %v", err)
+	}
+
+	s := &fakeSupervisor{}
+	r.SetSupervisor(s)
+	if err := r.Execute("service", req); err != nil {
+		t.Fatalf("Execute returned error: %v", err)
+	}
+	if len(s.reqs) != 1 || s.reqs[0].TileID != "proxy" {
+		t.Fatalf("unexpected supervised requests %+v", s.reqs)
+	}
+	if got := s.cmds[0].Args; len(got) != 3 || got[2] != "mitmproxy -p 8080" {
+		t.Errorf("unexpected service command %q", got)
+	}
+
+	r.SetPolicy(guard.Policy{Deny: []string{"mitmproxy *"}})
+	var denied *guard.DeniedError
+	if err := r.Execute("service", req); !errors.As(err, &denied) {
+		t.Errorf("services must be checked against the command policy, got %v", err)
+	}
+}
+
+// fakeScripter records script requests
+type fakeScripter struct {
+	reqs []Request
+}
+
+func (s *fakeScripter) RunScript(req Request) error {
+	s.reqs = append(s.reqs, req)
+	return nil
+}
+
+func TestScript(t *testing.T) {
+	r := NewRegistry(launcher.New(launcher.Options{}))
+	r.Register(scriptHandler{})
+
+	req := Request{TileID: "intranet", Target: "intranet.star", Path: "/srv"}
+	if err := r.Execute("script", req); !errors.Is(err, ErrNoScripter) {
+		t.Errorf("expected ErrNoScripter, got %v", err)
+	}
+	if err := r.Execute("script", Request{}); !errors.Is(err, ErrMissingTarget) {
+		t.Errorf("expected ErrMissingTarget, got %v", err)
+	}
+
+	s := &fakeScripter{}
+	r.SetScripter(s)
+	if err := r.Execute("script", req); err != nil {
+		t.Fatalf("Execute returned error: %v", err)
+	}
+	if len(s.reqs) != 1 || s.reqs[0].Target != "intranet.star" || s.reqs[0].Action != "script" {
+		t.Errorf("unexpected script requests %+v", s.reqs)
+	}
+}
+
+func TestDesktopEntry(t *testing.T) {
+	dir := t.TempDir()
+	entry := "[Desktop Entry]\nType=Application\nName=Viewer\nExec=viewer --open %f\nPath=/srv\n"
+	if err := os.WriteFile(filepath.Join(dir, "viewer.desktop"), []byte(entry), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	r := NewRegistry(launcher.New(launcher.Options{}))
+	r.Register(desktopHandler{catalog: desktop.NewCatalog(desktop.Options{Dirs: []string{dir}})})
+	runner := &fakeRunner{}
+	r.SetRunner(runner)
+
+	if err := r.Execute("desktop", Request{Target: "viewer.desktop", Path: "/tmp/a b.pdf"}); err != nil {
+		t.Fatalf("Execute returned error: %v", err)
+	}
+	cmd := runner.started[0]
+	if got := cmd.Args; len(got) != 3 || got[1] != "--open" || got[2] != "/tmp/a b.pdf" || cmd.Dir != "/srv" {
+		t.Errorf("unexpected command %q in %q", got, cmd.Dir)
+	}
+
+	r.SetPolicy(guard.Policy{Deny: []string{"viewer *"}})
+	var denied *guard.DeniedError
+	if err := r.Execute("desktop", Request{Target: "viewer.desktop"}); !errors.As(err, &denied) {
+		t.Errorf("desktop entries must be checked against the command policy, got %v", err)
+	}
+	if err := r.Execute("desktop", Request{Target: "missing.desktop"}); !errors.Is(err, desktop.ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,39 @@
+package actions
+
+import "errors"
+
+func init() {
+	Register(scriptHandler{})
+}
+
+// ErrNoScripter is returned for script requests when no scripter is set
+var ErrNoScripter = errors.New("scripts are not supported")
+
+// scriptHandler runs a Starlark script that can launch further actions,
+// e.g. to connect a VPN before opening the intranet
+type scriptHandler struct{}
+
+func (scriptHandler) Describe() Description {
+	return Description{
+		Type:         "script",
+		Label:        "Skript",
+		Description:  "Führt ein Skript aus, das Aktionen starten, die Zwischenablage nutzen, Benachrichtigungen zeigen und HTTP-Anfragen senden kann",
+		NeedsTarget:  true,
+		SupportsPath: true,
+	}
+}
+
+func (scriptHandler) Validate(req Request) error {
+	if req.Target == "" {
+		return ErrMissingTarget
+	}
+	return nil
+}
+
+// Execute hands the script to the scripter
+func (scriptHandler) Execute(env Env, req Request) error {
+	if env.Scripter == nil {
+		return ErrNoScripter
+	}
+	return env.Scripter.RunScript(req)
+}
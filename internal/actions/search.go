@@ -0,0 +1,20 @@
+package actions
+
+func init() {
+	Register(searchHandler{})
+}
+
+// searchHandler opens a URL template with the text typed after the tile's
+// keyword in place of {query}. It behaves like a url action otherwise.
+type searchHandler struct {
+	urlHandler
+}
+
+func (searchHandler) Describe() Description {
+	return Description{
+		Type:        "search",
+		Label:       "Websuche",
+		Description: "Öffnet eine URL mit {query} als Suchbegriff, z.B. über ein Stichwort in der Suche",
+		NeedsTarget: true,
+	}
+}
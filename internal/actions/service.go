@@ -0,0 +1,59 @@
+package actions
+
+import (
+	"errors"
+	"os/exec"
+	"strings"
+
+	"quicklaunch/internal/vars"
+)
+
+func init() {
+	Register(serviceHandler{})
+}
+
+// ErrNoSupervisor is returned for service requests when no supervisor is
+// set
+var ErrNoSupervisor = errors.New("services are not supported")
+
+// serviceHandler starts a long-running command that is restarted when it
+// exits, e.g. a local proxy or a development database
+type serviceHandler struct{}
+
+func (serviceHandler) Describe() Description {
+	return Description{
+		Type:         "service",
+		Label:        "Dienst",
+		Description:  "Startet einen Hintergrunddienst, der nach einem Absturz neu gestartet wird",
+		NeedsTarget:  true,
+		SupportsPath: true,
+	}
+}
+
+func (serviceHandler) Validate(req Request) error {
+	if req.Target == "" {
+		return ErrMissingTarget
+	}
+	return nil
+}
+
+// Execute hands the command to the supervisor, which starts it without a
+// window unless the service is already running
+func (serviceHandler) Execute(env Env, req Request) error {
+	if env.Supervisor == nil {
+		return ErrNoSupervisor
+	}
+	return env.Supervisor.Supervise(req, func() (*exec.Cmd, error) {
+		return env.Executor.BackgroundCommand(req.spec())
+	})
+}
+
+// quoteValue quotes substituted values like the shell handler
+func (serviceHandler) quoteValue(env Env, req Request) (vars.Quote, error) {
+	return shellHandler{}.quoteValue(env, req)
+}
+
+// command returns the command line for the command policy
+func (serviceHandler) command(req Request) string {
+	return strings.Join(append([]string{req.Target}, req.Args...), " ")
+}
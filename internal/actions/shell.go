@@ -0,0 +1,81 @@
+package actions
+
+import (
+	"errors"
+	"strings"
+
+	"quicklaunch/internal/vars"
+)
+
+func init() {
+	Register(shellHandler{})
+	// Tiles created before the rename use "powershell"
+	Default.Alias("powershell", "shell")
+}
+
+// shellHandler runs a command in a terminal window
+type shellHandler struct{}
+
+func (shellHandler) Describe() Description {
+	return Description{
+		Type:              "shell",
+		Label:             "Shell",
+		Description:       "Führt einen Befehl in einem Terminal oder im Hintergrund aus, optional in einem Ordner",
+		NeedsTarget:       true,
+		SupportsPath:      true,
+		SupportsElevation: true,
+	}
+}
+
+func (shellHandler) Validate(req Request) error {
+	if req.Target == "" {
+		return ErrMissingTarget
+	}
+	if req.Elevated && req.Background {
+		return errors.New("elevated commands run in a terminal, not in the background")
+	}
+	return nil
+}
+
+// Execute opens the command in a terminal, or runs it hidden and captures
+// its output when the request asks for background mode
+func (shellHandler) Execute(env Env, req Request) error {
+	if req.Background {
+		cmd, err := env.Executor.BackgroundCommand(req.spec())
+		if err != nil {
+			return err
+		}
+		return env.Runner.Run(cmd, req)
+	}
+
+	if req.Elevated {
+		el, err := env.Executor.ElevatedShellCommand(req.spec())
+		if err != nil {
+			return err
+		}
+		return startElevated(env, el, req)
+	}
+
+	cmd, err := env.Executor.ShellCommand(req.spec())
+	if err != nil {
+		return err
+	}
+	return env.Runner.Start(cmd, req)
+}
+
+// quoteValue quotes substituted values for the shell the command runs in,
+// so a placeholder always expands to a single argument
+func (shellHandler) quoteValue(env Env, req Request) (vars.Quote, error) {
+	kind, err := env.Executor.ShellKind(req.Profile)
+	if err != nil {
+		return nil, err
+	}
+	return func(_, value string) (string, error) {
+		return kind.Quote(value)
+	}, nil
+}
+
+// command returns the command line for the command policy
+func (shellHandler) command(req Request) string {
+	return strings.Join(append([]string{req.Target}, req.Args...), " ")
+}
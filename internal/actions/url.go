@@ -0,0 +1,54 @@
+package actions
+
+import (
+	"strings"
+
+	"quicklaunch/internal/vars"
+)
+
+func init() {
+	Register(urlHandler{})
+}
+
+// urlHandler opens a URL in the default browser
+type urlHandler struct{}
+
+func (urlHandler) Describe() Description {
+	return Description{
+		Type:        "url",
+		Label:       "Webseite",
+		Description: "Öffnet eine URL im Standardbrowser",
+		NeedsTarget: true,
+	}
+}
+
+func (urlHandler) Validate(req Request) error {
+	if req.Target == "" {
+		return ErrMissingTarget
+	}
+	return nil
+}
+
+func (urlHandler) Execute(env Env, req Request) error {
+	cmd, err := env.Executor.URLCommand(req.Target)
+	if err != nil {
+		return err
+	}
+	return env.Runner.Start(cmd, req)
+}
+
+// quoteValue escapes substituted values so they stay within their URL
+// component. Paths keep their separators, e.g. in "file://{path}". A
+// target that is only a placeholder, e.g. "{clipboard}", is taken as a
+// complete URL instead.
+func (urlHandler) quoteValue(env Env, req Request) (vars.Quote, error) {
+	if vars.IsPlaceholder(strings.TrimSpace(req.Target)) {
+		return nil, nil
+	}
+	return func(name, value string) (string, error) {
+		if vars.IsPath(name) {
+			return vars.URLPathEscape(value), nil
+		}
+		return vars.URLEscape(value)
+	}, nil
+}
@@ -0,0 +1,80 @@
+package actions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"quicklaunch/internal/workflow"
+)
+
+// ErrNoSteps is returned for workflow requests without steps
+var ErrNoSteps = errors.New("workflow has no steps")
+
+func init() {
+	Register(workflowHandler{registry: Default})
+}
+
+// workflowHandler runs the steps of a workflow one after another
+type workflowHandler struct {
+	registry *Registry
+}
+
+func (workflowHandler) Describe() Description {
+	return Description{
+		Type:        "workflow",
+		Label:       "Workflow",
+		Description: "Führt mehrere Aktionen nacheinander aus, optional mit Wartezeit",
+	}
+}
+
+// Validate checks all steps up front so a workflow does not fail halfway
+// because of a typo in a later step
+func (h workflowHandler) Validate(req Request) error {
+	if len(req.Steps) == 0 {
+		return ErrNoSteps
+	}
+	for i, step := range req.Steps {
+		sh, err := h.registry.Lookup(step.Action)
+		if err != nil {
+			return fmt.Errorf("step %d: %w", i+1, err)
+		}
+		if sh.Describe().Type == "workflow" {
+			return fmt.Errorf("step %d: workflows cannot be nested", i+1)
+		}
+		if err := sh.Validate(step.Request); err != nil {
+			return fmt.Errorf("step %d: %w", i+1, err)
+		}
+	}
+	return nil
+}
+
+// Execute starts the workflow in the background and returns immediately;
+// the outcome is reported through req.Progress. Cancelling req.Context
+// stops the workflow and ends the waits of its steps.
+func (h workflowHandler) Execute(env Env, req Request) error {
+	ctx := req.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	steps := make([]workflow.Step, len(req.Steps))
+	for i, step := range req.Steps {
+		step := step
+		name := step.Name
+		if name == "" {
+			name = step.Target
+		}
+		steps[i] = workflow.Step{
+			Name:            name,
+			Delay:           step.Delay,
+			ContinueOnError: step.ContinueOnError,
+			Run: func(ctx context.Context) error {
+				step.Request.Context = ctx
+				return h.registry.Execute(step.Action, step.Request)
+			},
+		}
+	}
+
+	go workflow.Run(ctx, steps, req.Progress)
+	return nil
+}
@@ -0,0 +1,197 @@
+package calc
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ErrNotCalculation is returned by Evaluate for input that is not meant
+// as a calculation, e.g. a single number or a search term
+var ErrNotCalculation = errors.New("not a calculation")
+
+// ErrNotInteger is returned for base conversions of fractions
+var ErrNotInteger = errors.New("base conversion needs an integer")
+
+// precision is the number of significant digits of results
+const precision = 10
+
+// Result is the outcome of a calculation or conversion
+type Result struct {
+	// Input is the evaluated text
+	Input string `json:"input"`
+	// Value is the formatted number, e.g. "395", "3.106855961" or "0x1F"
+	Value string `json:"value"`
+	// Unit is the unit symbol of conversion results, e.g. "mi"
+	Unit   string  `json:"unit,omitempty"`
+	Number float64 `json:"number"`
+}
+
+// String returns the value with its unit
+func (r Result) String() string {
+	if r.Unit == "" {
+		return r.Value
+	}
+	return r.Value + " " + r.Unit
+}
+
+// bases are the targets of base conversions
+var bases = map[string]int{
+	"dec": 10, "decimal": 10, "dezimal": 10,
+	"hex": 16, "hexadecimal": 16, "hexadezimal": 16,
+	"bin": 2, "binary": 2, "binär": 2,
+	"oct": 8, "octal": 8, "oktal": 8,
+}
+
+// conversionWords separate the value from the target of a conversion
+var conversionWords = []string{" in ", " to ", " into ", " as ", " nach ", " als ", "->", "=>"}
+
+// Evaluate computes input, which is an expression like "17*23+4", a unit
+// conversion like "5 km in mi" or "30 °C to F", or a base conversion like
+// "0x1F to dec" or "255 in hex". A hexadecimal, binary or octal number on
+// its own is converted to decimal. Input that is not meant as a
+// calculation, e.g. a plain number or word, returns ErrNotCalculation.
+func Evaluate(input string) (Result, error) {
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return Result{}, ErrNotCalculation
+	}
+
+	if value, target, ok := splitConversion(input); ok {
+		if base, ok := bases[strings.ToLower(target)]; ok {
+			return convertBase(input, value, base)
+		}
+		if to, ok := lookupUnit(target); ok {
+			return convertUnit(input, value, to)
+		}
+		// "x in y" may also be a search for a file or tile
+		return Result{}, fmt.Errorf("%w: %w %q", ErrNotCalculation, ErrUnknownUnit, target)
+	}
+
+	v, p, err := eval(input)
+	if err != nil {
+		return Result{}, fmt.Errorf("%w: %w", ErrNotCalculation, err)
+	}
+	if p.ops == 0 {
+		if !p.bases {
+			return Result{}, ErrNotCalculation
+		}
+		// "0x1F" on its own shows the decimal value
+		return convertBase(input, input, 10)
+	}
+	return Result{Input: input, Value: formatNumber(v), Number: v}, nil
+}
+
+// splitConversion splits input at the last conversion word into the
+// value and the target
+func splitConversion(input string) (value, target string, ok bool) {
+	lower := strings.ToLower(input)
+	at, width := -1, 0
+	for _, w := range conversionWords {
+		if i := strings.LastIndex(lower, w); i > at {
+			at, width = i, len(w)
+		}
+	}
+	if at <= 0 {
+		return "", "", false
+	}
+	value = strings.TrimSpace(input[:at])
+	target = strings.TrimSpace(input[at+width:])
+	return value, target, value != "" && target != ""
+}
+
+// convertBase evaluates value and formats it in base
+func convertBase(input, value string, base int) (Result, error) {
+	v, err := Eval(value)
+	if err != nil {
+		return Result{}, fmt.Errorf("%w: %w", ErrNotCalculation, err)
+	}
+	if v != math.Trunc(v) || math.Abs(v) >= 1<<63 {
+		return Result{}, ErrNotInteger
+	}
+
+	n := int64(v)
+	sign := ""
+	if n < 0 {
+		sign, n = "-", -n
+	}
+	var text string
+	switch base {
+	case 16:
+		text = "0x" + strings.ToUpper(strconv.FormatInt(n, 16))
+	case 2:
+		text = "0b" + strconv.FormatInt(n, 2)
+	case 8:
+		text = "0o" + strconv.FormatInt(n, 8)
+	default:
+		text = strconv.FormatInt(n, 10)
+	}
+	return Result{Input: input, Value: sign + text, Number: v}, nil
+}
+
+// convertUnit evaluates value, which ends with a unit, and converts it
+func convertUnit(input, value string, to unit) (Result, error) {
+	v, from, err := splitUnit(value)
+	if err != nil {
+		return Result{}, fmt.Errorf("%w: %w", ErrNotCalculation, err)
+	}
+	converted, err := convert(v, from, to)
+	if err != nil {
+		return Result{}, err
+	}
+	return Result{Input: input, Value: formatNumber(converted), Unit: to.name, Number: converted}, nil
+}
+
+// splitUnit splits text like "5 km", "5km", "(2+3) m/s" or "km" into the
+// value of its expression and the unit at its end. Without an expression
+// the value is 1. The longest known unit wins.
+func splitUnit(text string) (float64, unit, error) {
+	for i, r := range text {
+		if i > 0 && !unitStart(text, i) {
+			continue
+		}
+		if !unicode.IsLetter(r) && !strings.ContainsRune(`°µ"'`, r) {
+			continue
+		}
+		un, ok := lookupUnit(text[i:])
+		if !ok {
+			continue
+		}
+		expr := strings.TrimSpace(text[:i])
+		if expr == "" {
+			return 1, un, nil
+		}
+		if v, err := Eval(expr); err == nil {
+			return v, un, nil
+		}
+	}
+	return 0, unit{}, fmt.Errorf("%w in %q", ErrUnknownUnit, text)
+}
+
+// unitStart reports whether a unit may start at byte i of text, i.e.
+// after a space, a digit or a closing parenthesis
+func unitStart(text string, i int) bool {
+	prev, _ := utf8.DecodeLastRuneInString(text[:i])
+	return unicode.IsSpace(prev) || unicode.IsDigit(prev) || prev == ')' || prev == '.'
+}
+
+// formatNumber formats v with up to ten significant digits. Integers are
+// written in full as long as a float64 holds them exactly.
+func formatNumber(v float64) string {
+	if v == 0 {
+		return "0"
+	}
+	if v == math.Trunc(v) && math.Abs(v) < 1<<53 {
+		return strconv.FormatInt(int64(v), 10)
+	}
+	abs := math.Abs(v)
+	if abs >= 1e15 || abs < 1e-6 {
+		return strconv.FormatFloat(v, 'g', precision, 64)
+	}
+	rounded, _ := strconv.ParseFloat(strconv.FormatFloat(v, 'g', precision, 64), 64)
+	return strconv.FormatFloat(rounded, 'f', -1, 64)
+}
@@ -0,0 +1,179 @@
+package calc
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestEval(t *testing.T) {
+	tests := []struct {
+		expr string
+		want float64
+	}{
+		{"17*23+4", 395},
+		{"1 + 2 * 3", 7},
+		{"(1 + 2) * 3", 9},
+		{"10 - 4 - 3", 3},
+		{"2 ^ 3 ^ 2", 512},
+		{"2**10", 1024},
+		{"-2^2", -4},
+		{"2^-1", 0.5},
+		{"--3", 3},
+		{"7 / 2", 3.5},
+		{"10 % 3", 1},
+		{"200 * 15%", 30},
+		{"50%", 0.5},
+		{"5!", 120},
+		{"3!!", 720},
+		{"2pi", 2 * math.Pi},
+		{"3(4+5)", 27},
+		{"sqrt(16)", 4},
+		{"sqrt 16", 4},
+		{"abs(-3) + floor(2.7) + ceil(2.1) + round(2.5)", 11},
+		{"log(1000)", 3},
+		{"ln(e)", 1},
+		{"log2 8", 3},
+		{"sin(0) + cos(0)", 1},
+		{"0x1F + 1", 32},
+		{"0b1010", 10},
+		{"0o17", 15},
+		{"1e3 * 2", 2000},
+		{"2.5E-1", 0.25},
+		{".5 + .5", 1},
+		{"3,5 * 2", 7},
+		{"6 × 7", 42},
+		{"9 ÷ 3", 3},
+		{"5 − 2", 3},
+		{"π", math.Pi},
+	}
+	for _, tt := range tests {
+		got, err := Eval(tt.expr)
+		if err != nil {
+			t.Errorf("Eval(%q) returned error: %v", tt.expr, err)
+			continue
+		}
+		if math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("Eval(%q) = %v, want %v", tt.expr, got, tt.want)
+		}
+	}
+}
+
+func TestEvalErrors(t *testing.T) {
+	tests := []struct {
+		expr string
+		want error
+	}{
+		{"", ErrSyntax},
+		{"1 +", ErrSyntax},
+		{"(1 + 2", ErrSyntax},
+		{"1 + 2)", ErrSyntax},
+		{"2 3", ErrSyntax},
+		{"1.2.3", ErrSyntax},
+		{"foo(2)", ErrSyntax},
+		{"0x", ErrSyntax},
+		{"0xFFFFFFFFFFFFFFFFF", ErrSyntax},
+		{"2 $ 3", ErrSyntax},
+		{"1 / 0", ErrDivisionByZero},
+		{"5 % 0", ErrDivisionByZero},
+		{"sqrt(-1)", ErrDomain},
+		{"2.5!", ErrDomain},
+		{"171!", ErrDomain},
+		{"10^400", ErrDomain},
+	}
+	for _, tt := range tests {
+		if _, err := Eval(tt.expr); !errors.Is(err, tt.want) {
+			t.Errorf("Eval(%q) error = %v, want %v", tt.expr, err, tt.want)
+		}
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	tests := []struct {
+		input, want string
+	}{
+		{"17*23+4", "395"},
+		{"0.1 + 0.2", "0.3"},
+		{"1/3", "0.3333333333"},
+		{"2^60", "1.152921505e+18"},
+		{"2^52", "4503599627370496"},
+		{"1/3000000", "3.333333333e-07"},
+		{"5 km in mi", "3.106855961 mi"},
+		{"5km to mi", "3.106855961 mi"},
+		{"10 mi in km", "16.09344 km"},
+		{"(2+3) m/s in km/h", "18 km/h"},
+		{"100 km/h in mph", "62.13711922 mph"},
+		{"12 in in cm", "30.48 cm"},
+		{"6 ft to m", "1.8288 m"},
+		{"1 lb in g", "453.59237 g"},
+		{"2 kg nach pfund", "4 pfd"},
+		{"1 gal in l", "3.785411784 l"},
+		{"1 fl oz in ml", "29.57352956 ml"},
+		{"1 ha in m2", "10000 m²"},
+		{"90 min in h", "1.5 h"},
+		{"1 GiB in MB", "1073.741824 MB"},
+		{"8 Mbit in kB", "1000 kB"},
+		{"1 kcal in kJ", "4.184 kJ"},
+		{"1 atm in hPa", "1013.25 hPa"},
+		{"30 °C in °F", "86 °F"},
+		{"30 c to f", "86 °F"},
+		{"212 F in C", "100 °C"},
+		{"0 K in celsius", "-273.15 °C"},
+		{"km in m", "1000 m"},
+		{"0x1F to dec", "31"},
+		{"0x1F", "31"},
+		{"255 in hex", "0xFF"},
+		{"-255 as hex", "-0xFF"},
+		{"10 to bin", "0b1010"},
+		{"64 in oct", "0o100"},
+		{"0b1111 + 1 in hex", "0x10"},
+	}
+	for _, tt := range tests {
+		got, err := Evaluate(tt.input)
+		if err != nil {
+			t.Errorf("Evaluate(%q) returned error: %v", tt.input, err)
+			continue
+		}
+		if got.String() != tt.want {
+			t.Errorf("Evaluate(%q) = %q, want %q", tt.input, got.String(), tt.want)
+		}
+	}
+}
+
+func TestEvaluateNotCalculation(t *testing.T) {
+	for _, input := range []string{"", "  ", "42", "-5", "(7)", "pi", "e", "code", "visual studio", "Rechnungen 2025", "vpn in office", "5 km in kg2", "sqrt"} {
+		if r, err := Evaluate(input); !errors.Is(err, ErrNotCalculation) {
+			t.Errorf("Evaluate(%q) = %+v, %v, want ErrNotCalculation", input, r, err)
+		}
+	}
+}
+
+func TestEvaluateErrors(t *testing.T) {
+	if _, err := Evaluate("5 km in kg"); !errors.Is(err, ErrIncompatibleUnits) {
+		t.Errorf("expected ErrIncompatibleUnits, got %v", err)
+	}
+	if _, err := Evaluate("2.5 in hex"); !errors.Is(err, ErrNotInteger) {
+		t.Errorf("expected ErrNotInteger, got %v", err)
+	}
+	if _, err := Evaluate("1/0"); !errors.Is(err, ErrNotCalculation) || !errors.Is(err, ErrDivisionByZero) {
+		t.Errorf("expected ErrDivisionByZero, got %v", err)
+	}
+}
+
+func TestUnitLookup(t *testing.T) {
+	// Case matters where two units differ only by it
+	for name, want := range map[string]string{"MB": "MB", "mb": "MB", "Mbit": "Mbit", "B": "B", "bit": "bit", "KM": "km", "°c": "°C", "Kelvin": "K"} {
+		if un, ok := lookupUnit(name); !ok || un.name != want {
+			t.Errorf("lookupUnit(%q) = %+v, %v, want %s", name, un, ok, want)
+		}
+	}
+	// Every quantity converts to itself
+	for _, q := range unitTable {
+		for _, def := range q.units {
+			un, _ := lookupUnit(def.names[0])
+			if v, err := convert(3, un, un); err != nil || math.Abs(v-3) > 1e-9 {
+				t.Errorf("converting %s to itself = %v, %v", def.names[0], v, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,437 @@
+package calc
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+var (
+	// ErrSyntax is returned for input that is not a valid expression
+	ErrSyntax = errors.New("invalid expression")
+	// ErrDivisionByZero is returned for divisions and modulos by zero
+	ErrDivisionByZero = errors.New("division by zero")
+	// ErrDomain is returned when a result is not a finite number, e.g.
+	// for sqrt(-1) or 200!
+	ErrDomain = errors.New("result is not a finite number")
+)
+
+// tokenKind classifies tokens of an expression
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokNumber
+	tokIdent
+	tokOp
+	tokLParen
+	tokRParen
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	num  float64
+	// base is the base a number literal was written in
+	base int
+}
+
+// operatorAliases maps typographic operators to their ASCII form
+var operatorAliases = map[rune]string{
+	'×': "*", '·': "*", '⋅': "*", '÷': "/", '−': "-", '–': "-",
+}
+
+// lex splits an expression into tokens. A comma between digits is a
+// decimal separator, so "3,5" is read like "3.5".
+func lex(s string) ([]token, error) {
+	var toks []token
+	for i := 0; i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		switch {
+		case unicode.IsSpace(r):
+			i += size
+
+		case r >= '0' && r <= '9' || r == '.' && i+1 < len(s) && isDigit(s[i+1]):
+			tok, n, err := lexNumber(s[i:])
+			if err != nil {
+				return nil, err
+			}
+			toks = append(toks, tok)
+			i += n
+
+		case unicode.IsLetter(r) || r == '_':
+			j := i
+			for j < len(s) {
+				r, size := utf8.DecodeRuneInString(s[j:])
+				if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' {
+					break
+				}
+				j += size
+			}
+			toks = append(toks, token{kind: tokIdent, text: strings.ToLower(s[i:j])})
+			i = j
+
+		case r == '(':
+			toks = append(toks, token{kind: tokLParen, text: "("})
+			i += size
+		case r == ')':
+			toks = append(toks, token{kind: tokRParen, text: ")"})
+			i += size
+
+		case strings.HasPrefix(s[i:], "**"):
+			toks = append(toks, token{kind: tokOp, text: "^"})
+			i += 2
+		case strings.ContainsRune("+-*/%^!", r):
+			toks = append(toks, token{kind: tokOp, text: string(r)})
+			i += size
+		case operatorAliases[r] != "":
+			toks = append(toks, token{kind: tokOp, text: operatorAliases[r]})
+			i += size
+
+		default:
+			return nil, fmt.Errorf("%w: unexpected %q", ErrSyntax, r)
+		}
+	}
+	return append(toks, token{kind: tokEOF}), nil
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+// lexNumber reads a number literal at the start of s: decimal with an
+// optional fraction and exponent, or hexadecimal, binary or octal with a
+// 0x, 0b or 0o prefix. It returns the token and its length.
+func lexNumber(s string) (token, int, error) {
+	if len(s) > 2 && s[0] == '0' {
+		base := map[byte]int{'x': 16, 'X': 16, 'b': 2, 'B': 2, 'o': 8, 'O': 8}[s[1]]
+		if base != 0 {
+			j := 2
+			for j < len(s) && isBaseDigit(s[j], base) {
+				j++
+			}
+			if j == 2 {
+				return token{}, 0, fmt.Errorf("%w: %q has no digits", ErrSyntax, s[:2])
+			}
+			n, err := strconv.ParseUint(s[2:j], base, 64)
+			if err != nil {
+				return token{}, 0, fmt.Errorf("%w: %q is too large", ErrSyntax, s[:j])
+			}
+			return token{kind: tokNumber, text: s[:j], num: float64(n), base: base}, j, nil
+		}
+	}
+
+	var b strings.Builder
+	j := 0
+	for j < len(s) && isDigit(s[j]) {
+		j++
+	}
+	b.WriteString(s[:j])
+	if j+1 < len(s) && (s[j] == '.' || s[j] == ',') && isDigit(s[j+1]) || j < len(s) && s[j] == '.' {
+		b.WriteByte('.')
+		j++
+		start := j
+		for j < len(s) && isDigit(s[j]) {
+			j++
+		}
+		b.WriteString(s[start:j])
+	}
+	// An exponent needs digits, "2e" is two times e
+	if j < len(s) && (s[j] == 'e' || s[j] == 'E') {
+		k := j + 1
+		if k < len(s) && (s[k] == '+' || s[k] == '-') {
+			k++
+		}
+		if k < len(s) && isDigit(s[k]) {
+			for k < len(s) && isDigit(s[k]) {
+				k++
+			}
+			b.WriteString(s[j:k])
+			j = k
+		}
+	}
+
+	n, err := strconv.ParseFloat(b.String(), 64)
+	if err != nil {
+		return token{}, 0, fmt.Errorf("%w: %q", ErrSyntax, s[:j])
+	}
+	return token{kind: tokNumber, text: s[:j], num: n, base: 10}, j, nil
+}
+
+func isBaseDigit(c byte, base int) bool {
+	switch base {
+	case 2:
+		return c == '0' || c == '1'
+	case 8:
+		return '0' <= c && c <= '7'
+	}
+	return isDigit(c) || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F'
+}
+
+// constants are the names usable as numbers
+var constants = map[string]float64{
+	"pi":  math.Pi,
+	"π":   math.Pi,
+	"tau": 2 * math.Pi,
+	"e":   math.E,
+}
+
+// functions are the names usable as functions of one argument
+var functions = map[string]func(float64) float64{
+	"sqrt":  math.Sqrt,
+	"cbrt":  math.Cbrt,
+	"abs":   math.Abs,
+	"ln":    math.Log,
+	"log":   math.Log10,
+	"log2":  math.Log2,
+	"exp":   math.Exp,
+	"sin":   math.Sin,
+	"cos":   math.Cos,
+	"tan":   math.Tan,
+	"asin":  math.Asin,
+	"acos":  math.Acos,
+	"atan":  math.Atan,
+	"round": math.Round,
+	"floor": math.Floor,
+	"ceil":  math.Ceil,
+}
+
+// maxFactorial is the largest n whose factorial fits a float64
+const maxFactorial = 170
+
+// parser evaluates tokens by recursive descent. Precedence from low to
+// high: + -, * / % and implicit multiplication, unary signs, ^ (right
+// associative), postfix ! and %.
+type parser struct {
+	toks []token
+	pos  int
+	// ops counts operators and functions; an input without any is just
+	// a number
+	ops int
+	// bases records whether a non-decimal literal was used
+	bases bool
+}
+
+// Eval evaluates an arithmetic expression like "17*23+4" or
+// "sqrt(2)^2". Besides + - * / and ^, "%" after a number is a percentage
+// ("200*15%") and between numbers the remainder; "!" is the factorial.
+func Eval(expr string) (float64, error) {
+	v, _, err := eval(expr)
+	return v, err
+}
+
+// eval evaluates expr and returns its parser for inspection
+func eval(expr string) (float64, *parser, error) {
+	toks, err := lex(expr)
+	if err != nil {
+		return 0, nil, err
+	}
+	p := &parser{toks: toks}
+	if p.peek().kind == tokEOF {
+		return 0, nil, fmt.Errorf("%w: empty", ErrSyntax)
+	}
+	v, err := p.expr()
+	if err != nil {
+		return 0, nil, err
+	}
+	if t := p.peek(); t.kind != tokEOF {
+		return 0, nil, fmt.Errorf("%w: unexpected %q", ErrSyntax, t.text)
+	}
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return 0, nil, ErrDomain
+	}
+	return v, p, nil
+}
+
+func (p *parser) peek() token {
+	return p.toks[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.toks[p.pos]
+	if t.kind != tokEOF {
+		p.pos++
+	}
+	return t
+}
+
+// isOp reports whether the next token is one of the operators
+func (p *parser) isOp(ops string) bool {
+	t := p.peek()
+	return t.kind == tokOp && strings.Contains(ops, t.text)
+}
+
+// startsOperand reports whether t can start an operand
+func startsOperand(t token) bool {
+	return t.kind == tokNumber || t.kind == tokIdent || t.kind == tokLParen
+}
+
+// implicitProduct reports whether t continues a product without an
+// operator, like "2pi" or "3(4+5)". Two numbers in a row are an error.
+func implicitProduct(t token) bool {
+	return t.kind == tokIdent || t.kind == tokLParen
+}
+
+func (p *parser) expr() (float64, error) {
+	v, err := p.term()
+	if err != nil {
+		return 0, err
+	}
+	for p.isOp("+-") {
+		op := p.next().text
+		w, err := p.term()
+		if err != nil {
+			return 0, err
+		}
+		p.ops++
+		if op == "+" {
+			v += w
+		} else {
+			v -= w
+		}
+	}
+	return v, nil
+}
+
+func (p *parser) term() (float64, error) {
+	v, err := p.unary()
+	if err != nil {
+		return 0, err
+	}
+	for {
+		op := "*"
+		switch {
+		case p.isOp("*/%"):
+			op = p.next().text
+		case implicitProduct(p.peek()):
+		default:
+			return v, nil
+		}
+		w, err := p.unary()
+		if err != nil {
+			return 0, err
+		}
+		p.ops++
+		switch op {
+		case "*":
+			v *= w
+		case "/":
+			if w == 0 {
+				return 0, ErrDivisionByZero
+			}
+			v /= w
+		case "%":
+			if w == 0 {
+				return 0, ErrDivisionByZero
+			}
+			v = math.Mod(v, w)
+		}
+	}
+}
+
+func (p *parser) unary() (float64, error) {
+	if p.isOp("+-") {
+		op := p.next().text
+		v, err := p.unary()
+		if err != nil {
+			return 0, err
+		}
+		if op == "-" {
+			v = -v
+		}
+		return v, nil
+	}
+	return p.power()
+}
+
+func (p *parser) power() (float64, error) {
+	v, err := p.postfix()
+	if err != nil {
+		return 0, err
+	}
+	if !p.isOp("^") {
+		return v, nil
+	}
+	p.next()
+	// Right associative, and the exponent may have a sign: 2^-1
+	w, err := p.unary()
+	if err != nil {
+		return 0, err
+	}
+	p.ops++
+	return math.Pow(v, w), nil
+}
+
+func (p *parser) postfix() (float64, error) {
+	v, err := p.primary()
+	if err != nil {
+		return 0, err
+	}
+	for {
+		switch {
+		case p.isOp("!"):
+			p.next()
+			p.ops++
+			if v < 0 || v != math.Trunc(v) || v > maxFactorial {
+				return 0, fmt.Errorf("%w: factorial of %v", ErrDomain, v)
+			}
+			f := 1.0
+			for i := 2.0; i <= v; i++ {
+				f *= i
+			}
+			v = f
+		case p.isOp("%") && !startsOperand(p.toks[p.pos+1]):
+			// A percentage, unless an operand follows for the remainder
+			p.next()
+			p.ops++
+			v /= 100
+		default:
+			return v, nil
+		}
+	}
+}
+
+func (p *parser) primary() (float64, error) {
+	t := p.next()
+	switch t.kind {
+	case tokNumber:
+		if t.base != 10 {
+			p.bases = true
+		}
+		return t.num, nil
+
+	case tokLParen:
+		v, err := p.expr()
+		if err != nil {
+			return 0, err
+		}
+		if p.next().kind != tokRParen {
+			return 0, fmt.Errorf("%w: missing )", ErrSyntax)
+		}
+		return v, nil
+
+	case tokIdent:
+		if c, ok := constants[t.text]; ok {
+			return c, nil
+		}
+		fn, ok := functions[t.text]
+		if !ok {
+			return 0, fmt.Errorf("%w: unknown name %q", ErrSyntax, t.text)
+		}
+		// Functions take a parenthesized argument or an operand: sqrt 2
+		arg, err := p.postfix()
+		if err != nil {
+			return 0, err
+		}
+		p.ops++
+		return fn(arg), nil
+
+	case tokEOF:
+		return 0, fmt.Errorf("%w: unexpected end", ErrSyntax)
+	}
+	return 0, fmt.Errorf("%w: unexpected %q", ErrSyntax, t.text)
+}
@@ -0,0 +1,218 @@
+package calc
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	// ErrUnknownUnit is returned for unit names not in the table
+	ErrUnknownUnit = errors.New("unknown unit")
+	// ErrIncompatibleUnits is returned for conversions between different
+	// quantities, e.g. from km to kg
+	ErrIncompatibleUnits = errors.New("incompatible units")
+)
+
+// unit converts to the base unit of its quantity: base = v*factor+offset.
+// Only temperatures have an offset.
+type unit struct {
+	name     string
+	quantity string
+	factor   float64
+	offset   float64
+}
+
+// unitDef is a unit of the table with its factor to the base unit
+type unitDef struct {
+	names  []string
+	factor float64
+}
+
+func u(factor float64, names ...string) unitDef {
+	return unitDef{names, factor}
+}
+
+// unitTable lists the units by quantity. The first name is the symbol
+// used in results; names are matched exactly first, then ignoring case.
+var unitTable = []struct {
+	quantity string
+	units    []unitDef
+}{
+	{"length", []unitDef{
+		u(1, "m", "meter", "meters", "metre", "metres"),
+		u(1000, "km", "kilometer", "kilometers", "kilometre", "kilometres"),
+		u(0.1, "dm", "decimeter"),
+		u(0.01, "cm", "centimeter", "centimeters", "zentimeter"),
+		u(0.001, "mm", "millimeter", "millimeters"),
+		u(1e-6, "µm", "um", "micrometer", "mikrometer"),
+		u(1e-9, "nm", "nanometer"),
+		u(0.0254, "in", "inch", "inches", "zoll", `"`),
+		u(0.3048, "ft", "foot", "feet", "fuß", "'"),
+		u(0.9144, "yd", "yard", "yards"),
+		u(1609.344, "mi", "mile", "miles", "meile", "meilen"),
+		u(1852, "nmi", "seemeile", "seemeilen"),
+		u(9.4607304725808e15, "ly", "lightyear", "lightyears", "lichtjahr", "lichtjahre"),
+	}},
+	{"mass", []unitDef{
+		u(1, "kg", "kilogram", "kilograms", "kilogramm", "kilo"),
+		u(0.001, "g", "gram", "grams", "gramm"),
+		u(1e-6, "mg", "milligram", "milligramm"),
+		u(1e-9, "µg", "ug", "microgram", "mikrogramm"),
+		u(1000, "t", "tonne", "tonnes", "tonnen"),
+		u(0.45359237, "lb", "lbs", "pound", "pounds"),
+		u(0.028349523125, "oz", "ounce", "ounces", "unze", "unzen"),
+		u(6.35029318, "st", "stone"),
+		u(0.5, "pfd", "pfund"),
+	}},
+	{"volume", []unitDef{
+		u(1, "l", "liter", "liters", "litre", "litres"),
+		u(0.1, "dl", "deciliter"),
+		u(0.01, "cl", "centiliter"),
+		u(0.001, "ml", "milliliter", "milliliters"),
+		u(1000, "m³", "m3", "cubic meter", "kubikmeter"),
+		u(0.001, "cm³", "cm3", "ccm"),
+		u(3.785411784, "gal", "gallon", "gallons"),
+		u(0.946352946, "qt", "quart", "quarts"),
+		u(0.473176473, "pt", "pint", "pints"),
+		u(0.2365882365, "cup", "cups", "tasse", "tassen"),
+		u(0.0295735295625, "floz", "fl oz", "fluid ounce", "fluid ounces"),
+		u(0.01478676478125, "tbsp", "tablespoon", "esslöffel", "el"),
+		u(0.00492892159375, "tsp", "teaspoon", "teelöffel", "tl"),
+	}},
+	{"area", []unitDef{
+		u(1, "m²", "m2", "sqm", "quadratmeter"),
+		u(1e6, "km²", "km2", "quadratkilometer"),
+		u(1e-4, "cm²", "cm2"),
+		u(1e-6, "mm²", "mm2"),
+		u(1e4, "ha", "hectare", "hectares", "hektar"),
+		u(4046.8564224, "ac", "acre", "acres"),
+		u(0.09290304, "ft²", "ft2", "sqft"),
+		u(2589988.110336, "mi²", "mi2"),
+	}},
+	{"time", []unitDef{
+		u(1, "s", "sec", "second", "seconds", "sekunde", "sekunden"),
+		u(0.001, "ms", "millisecond", "milliseconds", "millisekunden"),
+		u(1e-6, "µs", "us", "microsecond", "microseconds"),
+		u(1e-9, "ns", "nanosecond", "nanoseconds"),
+		u(60, "min", "minute", "minutes", "minuten"),
+		u(3600, "h", "hr", "hour", "hours", "stunde", "stunden", "std"),
+		u(86400, "d", "day", "days", "tag", "tage"),
+		u(604800, "wk", "week", "weeks", "woche", "wochen"),
+		u(2629800, "mo", "month", "months", "monat", "monate"),
+		u(31557600, "yr", "year", "years", "jahr", "jahre"),
+	}},
+	{"speed", []unitDef{
+		u(1, "m/s", "mps"),
+		u(1/3.6, "km/h", "kmh", "kph"),
+		u(0.44704, "mph"),
+		u(0.3048, "ft/s", "fps"),
+		u(1852.0/3600, "kn", "knot", "knots", "knoten"),
+	}},
+	{"data", []unitDef{
+		u(1, "B", "byte", "bytes"),
+		u(0.125, "bit", "bits"),
+		u(1e3, "kB", "KB", "kilobyte", "kilobytes"),
+		u(1e6, "MB", "megabyte", "megabytes"),
+		u(1e9, "GB", "gigabyte", "gigabytes"),
+		u(1e12, "TB", "terabyte", "terabytes"),
+		u(1024, "KiB", "kibibyte"),
+		u(1<<20, "MiB", "mebibyte"),
+		u(1<<30, "GiB", "gibibyte"),
+		u(1<<40, "TiB", "tebibyte"),
+		u(125, "kbit"),
+		u(125e3, "Mbit"),
+		u(125e6, "Gbit"),
+	}},
+	{"energy", []unitDef{
+		u(1, "J", "joule", "joules"),
+		u(1000, "kJ", "kilojoule"),
+		u(4.184, "cal", "calorie", "calories", "kalorie", "kalorien"),
+		u(4184, "kcal", "kilocalorie", "kilocalories", "kilokalorien"),
+		u(3600, "Wh", "wattstunde"),
+		u(3.6e6, "kWh", "kilowattstunde", "kilowattstunden"),
+	}},
+	{"pressure", []unitDef{
+		u(1, "Pa", "pascal"),
+		u(100, "hPa", "hektopascal"),
+		u(1000, "kPa", "kilopascal"),
+		u(1e5, "bar"),
+		u(100, "mbar", "millibar"),
+		u(6894.757293168, "psi"),
+		u(101325, "atm"),
+	}},
+}
+
+// temperatures are converted to kelvin with an offset
+var temperatures = []unit{
+	{name: "°C", quantity: "temperature", factor: 1, offset: 273.15},
+	{name: "°F", quantity: "temperature", factor: 5.0 / 9, offset: 459.67 * 5 / 9},
+	{name: "K", quantity: "temperature", factor: 1},
+}
+
+// temperatureNames are further names of the temperatures besides the
+// symbol with and without "°"
+var temperatureNames = map[string][]string{
+	"°C": {"c", "celsius", "grad", "grad celsius"},
+	"°F": {"f", "fahrenheit"},
+	"K":  {"kelvin"},
+}
+
+// exactUnits and foldedUnits index the table by name and by lower-case
+// name. Lower-case names that two units share, like "mb" for MB and
+// Mbit, are left out of foldedUnits.
+var exactUnits, foldedUnits = indexUnits()
+
+func indexUnits() (map[string]unit, map[string]unit) {
+	exact := make(map[string]unit)
+	folded := make(map[string]unit)
+	ambiguous := make(map[string]bool)
+	add := func(name string, un unit) {
+		exact[name] = un
+		lower := strings.ToLower(name)
+		if prev, ok := folded[lower]; ok && prev.name != un.name {
+			ambiguous[lower] = true
+		}
+		folded[lower] = un
+	}
+
+	for _, q := range unitTable {
+		for _, entry := range q.units {
+			un := unit{name: entry.names[0], quantity: q.quantity, factor: entry.factor}
+			for _, name := range entry.names {
+				add(name, un)
+			}
+		}
+	}
+	for _, t := range temperatures {
+		add(t.name, t)
+		add(strings.TrimPrefix(t.name, "°"), t)
+		for _, name := range temperatureNames[t.name] {
+			add(name, t)
+		}
+	}
+
+	for name := range ambiguous {
+		delete(folded, name)
+	}
+	return exact, folded
+}
+
+// lookupUnit finds a unit by name, exactly or ignoring case
+func lookupUnit(name string) (unit, bool) {
+	name = strings.TrimSpace(name)
+	if un, ok := exactUnits[name]; ok {
+		return un, true
+	}
+	un, ok := foldedUnits[strings.ToLower(name)]
+	return un, ok
+}
+
+// convert converts v from one unit to another of the same quantity
+func convert(v float64, from, to unit) (float64, error) {
+	if from.quantity != to.quantity {
+		return 0, fmt.Errorf("%w: %s (%s) to %s (%s)", ErrIncompatibleUnits, from.name, from.quantity, to.name, to.quantity)
+	}
+	base := v*from.factor + from.offset
+	return (base - to.offset) / to.factor, nil
+}
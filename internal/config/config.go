@@ -15,33 +15,130 @@ type RecentItem struct {
 
 // Tile represents a launcher tile
 type Tile struct {
-	ID           string       `json:"id"`
-	Name         string       `json:"name"`
-	Icon         string       `json:"icon"`
-	Action       string       `json:"action"`
-	Target       string       `json:"target"`
-	Args         []string     `json:"args,omitempty"`
-	WorkDir      string       `json:"workDir,omitempty"`
-	HasSubMenu   bool         `json:"hasSubMenu"`
-	SubMenuType  string       `json:"subMenuType,omitempty"`
-	SubMenuItems []RecentItem `json:"subMenuItems,omitempty"`
-	Order        int          `json:"order"`
-	Enabled      bool         `json:"enabled"`
-	Color        string       `json:"color,omitempty"`
+	ID              string         `json:"id"`
+	Name            string         `json:"name"`
+	Icon            string         `json:"icon"`
+	Action          string         `json:"action"`
+	Target          string         `json:"target"`
+	Args            []string       `json:"args,omitempty"`
+	WorkDir         string         `json:"workDir,omitempty"`
+	HasSubMenu      bool           `json:"hasSubMenu"`
+	SubMenuType     string         `json:"subMenuType,omitempty"`
+	SubMenuItems    []RecentItem   `json:"subMenuItems,omitempty"`
+	Order           int            `json:"order"`
+	Enabled         bool           `json:"enabled"`
+	Color           string         `json:"color,omitempty"`
+	Hotkey          string         `json:"hotkey,omitempty"`
+	TerminalProfile string         `json:"terminalProfile,omitempty"`
+	Shell           string         `json:"shell,omitempty"`
+	RunMode         string         `json:"runMode,omitempty"`
+	Steps           []WorkflowStep `json:"steps,omitempty"`
+	// Tags are additional search terms, e.g. "büro" or "finanzen"
+	Tags []string `json:"tags,omitempty"`
+	// Keyword runs the tile from the search bar, e.g. "gh quicklaunch"
+	// with the rest of the input as {query}
+	Keyword string `json:"keyword,omitempty"`
+	// RequireConfirm asks for confirmation in the panel before the tile
+	// runs, also when it is started by a hotkey or number key
+	RequireConfirm bool `json:"requireConfirm,omitempty"`
+	// Elevated launches app and shell tiles with administrator rights
+	Elevated bool `json:"elevated,omitempty"`
+	// SingleInstance brings a running instance of an app tile to the
+	// front instead of launching it again. Instances are found by the
+	// target's executable and, if set, the window class.
+	SingleInstance bool   `json:"singleInstance,omitempty"`
+	WindowClass    string `json:"windowClass,omitempty"`
+	// StartWithApp starts service tiles when QuickLaunch starts
+	StartWithApp bool `json:"startWithApp,omitempty"`
+}
+
+// WorkflowStep is one step of a workflow tile. Each step is an action
+// like a regular tile; the workflow stops at the first failing step
+// unless ContinueOnError is set.
+type WorkflowStep struct {
+	Name            string   `json:"name,omitempty"`
+	Action          string   `json:"action"`
+	Target          string   `json:"target"`
+	Args            []string `json:"args,omitempty"`
+	Path            string   `json:"path,omitempty"`
+	WorkDir         string   `json:"workDir,omitempty"`
+	TerminalProfile string   `json:"terminalProfile,omitempty"`
+	Shell           string   `json:"shell,omitempty"`
+	RunMode         string   `json:"runMode,omitempty"`
+	// DelayMs is waited before the step starts
+	DelayMs int `json:"delayMs,omitempty"`
+	// Wait holds the workflow until the step's process has exited. GUI
+	// apps and URLs usually hand off to another process and return early.
+	Wait            bool `json:"wait,omitempty"`
+	ContinueOnError bool `json:"continueOnError,omitempty"`
+	Elevated        bool `json:"elevated,omitempty"`
+}
+
+// Run modes of shell tiles
+const (
+	// RunModeTerminal opens the command in a terminal window (default)
+	RunModeTerminal = "terminal"
+	// RunModeBackground runs the command without a window and captures
+	// its output and exit status
+	RunModeBackground = "background"
+)
+
+// TerminalProfile describes how shell tiles are opened in a terminal.
+// Args is a template where "{dir}" is the working directory and an
+// argument "{command}" is replaced by the shell running the command.
+// Shell is a shell kind (bash, zsh, fish, sh, pwsh, powershell, cmd)
+// or the path to one of them.
+type TerminalProfile struct {
+	ID       string   `json:"id"`
+	Name     string   `json:"name"`
+	Terminal string   `json:"terminal,omitempty"`
+	Args     []string `json:"args,omitempty"`
+	Shell    string   `json:"shell,omitempty"`
 }
 
 // Config represents the application configuration
 type Config struct {
-	Theme                    string   `json:"theme"`
-	Hotkey                   string   `json:"hotkey"`
-	Position                 string   `json:"position"`
-	Animation                bool     `json:"animation"`
-	Blur                     bool     `json:"blur"`
-	StartWithWindows         bool     `json:"startWithWindows"`
-	CheckForUpdatesOnStartup bool     `json:"checkForUpdatesOnStartup"`
-	RecentFoldersLimit       int      `json:"recentFoldersLimit"`
-	RecentFolders            []string `json:"recentFolders"`
-	Tiles                    []Tile   `json:"tiles"`
+	Theme                    string            `json:"theme"`
+	Hotkey                   string            `json:"hotkey"`
+	Position                 string            `json:"position"`
+	Animation                bool              `json:"animation"`
+	Blur                     bool              `json:"blur"`
+	StartWithWindows         bool              `json:"startWithWindows"`
+	CheckForUpdatesOnStartup bool              `json:"checkForUpdatesOnStartup"`
+	RecentFoldersLimit       int               `json:"recentFoldersLimit"`
+	RecentFolders            []string          `json:"recentFolders"`
+	KeepTileOrder            bool              `json:"keepTileOrder"`
+	Terminal                 string            `json:"terminal,omitempty"`
+	TerminalProfiles         []TerminalProfile `json:"terminalProfiles"`
+	DefaultTerminalProfile   string            `json:"defaultTerminalProfile"`
+	CommandPolicy            CommandPolicy     `json:"commandPolicy"`
+	FileIndex                FileIndex         `json:"fileIndex"`
+	Tiles                    []Tile            `json:"tiles"`
+
+	// SearchProviders configures the sources of search results by name
+	SearchProviders map[string]SearchProvider `json:"searchProviders,omitempty"`
+}
+
+// CommandPolicy limits the commands shell and app tiles may run. Patterns
+// use "*" and "?" wildcards, e.g. "rm *" or "git push --force*".
+type CommandPolicy struct {
+	Allow []string `json:"allow,omitempty"`
+	Deny  []string `json:"deny,omitempty"`
+}
+
+// FileIndex configures the file and folder search. Roots may start with
+// "~" for the home directory, Ignore holds gitignore patterns.
+type FileIndex struct {
+	Roots  []string `json:"roots,omitempty"`
+	Ignore []string `json:"ignore,omitempty"`
+}
+
+// SearchProvider configures a source of search results such as "files".
+// Providers are enabled unless Disabled is set; TimeoutMs overrides how
+// long the search waits for the provider.
+type SearchProvider struct {
+	Disabled  bool `json:"disabled,omitempty"`
+	TimeoutMs int  `json:"timeoutMs,omitempty"`
 }
 
 // GetConfigDir returns the configuration directory path
@@ -88,9 +185,50 @@ func Load() (*Config, error) {
 		return DefaultConfig(), nil
 	}
 
+	migrateTerminalProfiles(&cfg)
+
 	return &cfg, nil
 }
 
+// migrateTerminalProfiles adds the default terminal profiles to configs
+// written before profiles existed. Tiles that relied on the old hard-coded
+// "claude" handling are switched to the equivalent profile.
+func migrateTerminalProfiles(cfg *Config) {
+	if cfg.TerminalProfiles != nil {
+		return
+	}
+
+	cfg.TerminalProfiles = DefaultTerminalProfiles()
+	if cfg.DefaultTerminalProfile == "" {
+		cfg.DefaultTerminalProfile = defaultTerminalProfileID
+	}
+
+	if legacyClaudeProfileID == "" {
+		return
+	}
+	for i, t := range cfg.Tiles {
+		if t.Action == "powershell" && t.Target == "claude" && t.TerminalProfile == "" {
+			cfg.Tiles[i].TerminalProfile = legacyClaudeProfileID
+		}
+	}
+}
+
+// FindTerminalProfile returns the profile with the given ID, falling back
+// to the default profile when id is empty or unknown
+func (c *Config) FindTerminalProfile(id string) (TerminalProfile, bool) {
+	for _, want := range []string{id, c.DefaultTerminalProfile} {
+		if want == "" {
+			continue
+		}
+		for _, p := range c.TerminalProfiles {
+			if p.ID == want {
+				return p, true
+			}
+		}
+	}
+	return TerminalProfile{}, false
+}
+
 // Save saves the configuration to disk
 func (c *Config) Save() error {
 	path, err := GetConfigPath()
@@ -118,6 +256,8 @@ func DefaultConfig() *Config {
 		CheckForUpdatesOnStartup: true,
 		RecentFoldersLimit:       5,
 		RecentFolders:            []string{},
+		TerminalProfiles:         DefaultTerminalProfiles(),
+		DefaultTerminalProfile:   defaultTerminalProfileID,
 		Tiles:                    []Tile{},
 	}
 }
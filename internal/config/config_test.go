@@ -0,0 +1,59 @@
+package config
+
+import "testing"
+
+func TestMigrateTerminalProfiles(t *testing.T) {
+	cfg := &Config{
+		Tiles: []Tile{
+			{ID: "claude", Action: "powershell", Target: "claude"},
+			{ID: "npm", Action: "powershell", Target: "npm run dev"},
+		},
+	}
+
+	migrateTerminalProfiles(cfg)
+
+	if len(cfg.TerminalProfiles) == 0 {
+		t.Fatal("default terminal profiles were not added")
+	}
+	if cfg.DefaultTerminalProfile != defaultTerminalProfileID {
+		t.Errorf("DefaultTerminalProfile = %q, want %q", cfg.DefaultTerminalProfile, defaultTerminalProfileID)
+	}
+	if got := cfg.Tiles[0].TerminalProfile; got != legacyClaudeProfileID {
+		t.Errorf("claude tile profile = %q, want %q", got, legacyClaudeProfileID)
+	}
+	if got := cfg.Tiles[1].TerminalProfile; got != "" {
+		t.Errorf("other tiles must keep the default profile, got %q", got)
+	}
+
+	// Existing profiles are left alone
+	cfg.TerminalProfiles = []TerminalProfile{}
+	migrateTerminalProfiles(cfg)
+	if len(cfg.TerminalProfiles) != 0 {
+		t.Error("migration must not overwrite configured profiles")
+	}
+}
+
+func TestFindTerminalProfile(t *testing.T) {
+	cfg := &Config{
+		TerminalProfiles: []TerminalProfile{
+			{ID: "a", Terminal: "kitty"},
+			{ID: "b", Terminal: "wezterm"},
+		},
+		DefaultTerminalProfile: "b",
+	}
+
+	if p, ok := cfg.FindTerminalProfile("a"); !ok || p.Terminal != "kitty" {
+		t.Errorf("FindTerminalProfile(a) = %+v, %v", p, ok)
+	}
+	if p, ok := cfg.FindTerminalProfile(""); !ok || p.ID != "b" {
+		t.Errorf("empty ID should resolve to the default profile, got %+v", p)
+	}
+	if p, ok := cfg.FindTerminalProfile("missing"); !ok || p.ID != "b" {
+		t.Errorf("unknown ID should resolve to the default profile, got %+v", p)
+	}
+
+	cfg.DefaultTerminalProfile = ""
+	if _, ok := cfg.FindTerminalProfile("missing"); ok {
+		t.Error("expected no profile without a default")
+	}
+}
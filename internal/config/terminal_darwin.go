@@ -0,0 +1,18 @@
+//go:build darwin
+
+package config
+
+const (
+	defaultTerminalProfileID = "terminal-app"
+	legacyClaudeProfileID    = ""
+)
+
+// DefaultTerminalProfiles returns the built-in terminal profiles for macOS
+func DefaultTerminalProfiles() []TerminalProfile {
+	return []TerminalProfile{
+		{ID: "terminal-app", Name: "Terminal"},
+		{ID: "kitty", Name: "kitty", Terminal: "kitty", Args: []string{"--directory", "{dir}", "{command}"}},
+		{ID: "alacritty", Name: "Alacritty", Terminal: "alacritty", Args: []string{"--working-directory", "{dir}", "-e", "{command}"}},
+		{ID: "wezterm", Name: "WezTerm", Terminal: "wezterm", Args: []string{"start", "--cwd", "{dir}", "--", "{command}"}},
+	}
+}
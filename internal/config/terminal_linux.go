@@ -0,0 +1,20 @@
+//go:build linux
+
+package config
+
+const (
+	defaultTerminalProfileID = "auto"
+	legacyClaudeProfileID    = ""
+)
+
+// DefaultTerminalProfiles returns the built-in terminal profiles for Linux
+func DefaultTerminalProfiles() []TerminalProfile {
+	return []TerminalProfile{
+		{ID: "auto", Name: "Standard-Terminal"},
+		{ID: "gnome-terminal", Name: "GNOME Terminal", Terminal: "gnome-terminal", Args: []string{"--working-directory={dir}", "--", "{command}"}},
+		{ID: "konsole", Name: "Konsole", Terminal: "konsole", Args: []string{"--workdir", "{dir}", "-e", "{command}"}},
+		{ID: "kitty", Name: "kitty", Terminal: "kitty", Args: []string{"--directory", "{dir}", "{command}"}},
+		{ID: "alacritty", Name: "Alacritty", Terminal: "alacritty", Args: []string{"--working-directory", "{dir}", "-e", "{command}"}},
+		{ID: "wezterm", Name: "WezTerm", Terminal: "wezterm", Args: []string{"start", "--cwd", "{dir}", "--", "{command}"}},
+	}
+}
@@ -0,0 +1,18 @@
+//go:build windows
+
+package config
+
+const (
+	defaultTerminalProfileID = "powershell"
+	legacyClaudeProfileID    = "windows-terminal"
+)
+
+// DefaultTerminalProfiles returns the built-in terminal profiles for Windows
+func DefaultTerminalProfiles() []TerminalProfile {
+	return []TerminalProfile{
+		{ID: "powershell", Name: "PowerShell", Shell: "powershell"},
+		{ID: "windows-terminal", Name: "Windows Terminal", Terminal: "wt", Args: []string{"-d", "{dir}", "{command}"}, Shell: "powershell"},
+		{ID: "windows-terminal-pwsh", Name: "Windows Terminal (pwsh)", Terminal: "wt", Args: []string{"-d", "{dir}", "{command}"}, Shell: "pwsh"},
+		{ID: "cmd", Name: "Eingabeaufforderung", Shell: "cmd"},
+	}
+}
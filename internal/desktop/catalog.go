@@ -0,0 +1,256 @@
+package desktop
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrNotFound is returned for desktop file IDs without a visible entry
+var ErrNotFound = errors.New("desktop entry not found")
+
+// Options control which entries a scan finds
+type Options struct {
+	// Dirs are the application directories, highest precedence first
+	Dirs []string
+	// Locale selects localized names, e.g. "de_DE.UTF-8"
+	Locale string
+	// Desktops are the names of the current desktop for OnlyShowIn and
+	// NotShowIn, e.g. "GNOME"
+	Desktops []string
+	// LookPath checks TryExec, exec.LookPath if nil
+	LookPath func(string) (string, error)
+}
+
+// DefaultOptions returns the options for the current session from the
+// XDG and locale environment variables
+func DefaultOptions(getenv func(string) string) Options {
+	locale := ""
+	for _, key := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		if locale = getenv(key); locale != "" {
+			break
+		}
+	}
+
+	var desktops []string
+	for _, d := range strings.Split(getenv("XDG_CURRENT_DESKTOP"), ":") {
+		if d != "" {
+			desktops = append(desktops, d)
+		}
+	}
+
+	return Options{
+		Dirs:     Dirs(getenv),
+		Locale:   locale,
+		Desktops: desktops,
+	}
+}
+
+// Dirs returns the application directories of the XDG base directory
+// specification. Desktop entries are a Linux desktop feature, other
+// systems have none.
+func Dirs(getenv func(string) string) []string {
+	if runtime.GOOS != "linux" {
+		return nil
+	}
+
+	dataHome := getenv("XDG_DATA_HOME")
+	if dataHome == "" {
+		if home := getenv("HOME"); home != "" {
+			dataHome = filepath.Join(home, ".local", "share")
+		}
+	}
+	dataDirs := getenv("XDG_DATA_DIRS")
+	if dataDirs == "" {
+		dataDirs = "/usr/local/share:/usr/share"
+	}
+
+	var dirs []string
+	for _, dir := range append([]string{dataHome}, strings.Split(dataDirs, ":")...) {
+		if dir == "" {
+			continue
+		}
+		if dir = filepath.Join(dir, "applications"); !slices.Contains(dirs, dir) {
+			dirs = append(dirs, dir)
+		}
+	}
+	return dirs
+}
+
+// Scan returns the visible applications in the directories of opts,
+// sorted by name. Entries in earlier directories hide entries with the
+// same desktop file ID in later ones, also if they are hidden themselves.
+func Scan(opts Options) []Entry {
+	entries, _ := scan(opts)
+	return entries
+}
+
+// scan is Scan, also returning the directories it read and their
+// modification times
+func scan(opts Options) ([]Entry, map[string]time.Time) {
+	seen := map[string]bool{}
+	stamps := map[string]time.Time{}
+	var entries []Entry
+
+	for _, root := range opts.Dirs {
+		stamps[root] = time.Time{}
+		filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return nil
+			}
+			if d.IsDir() {
+				if info, err := d.Info(); err == nil {
+					stamps[path] = info.ModTime()
+				}
+				return nil
+			}
+			if !strings.HasSuffix(path, ".desktop") {
+				return nil
+			}
+
+			rel, _ := filepath.Rel(root, path)
+			id := strings.ReplaceAll(filepath.ToSlash(rel), "/", "-")
+			if seen[id] {
+				return nil
+			}
+			seen[id] = true
+
+			e, err := parseFile(path, opts.Locale)
+			if err != nil || !opts.visible(e) {
+				return nil
+			}
+			e.ID = id
+			entries = append(entries, e)
+			return nil
+		})
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return strings.ToLower(entries[i].Name) < strings.ToLower(entries[j].Name)
+	})
+	return entries, stamps
+}
+
+// parseFile reads a desktop entry file
+func parseFile(path, locale string) (Entry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return Entry{}, err
+	}
+	defer f.Close()
+
+	e, err := Parse(f, locale)
+	if err != nil {
+		return Entry{}, err
+	}
+	e.File = path
+	return e, nil
+}
+
+// visible reports whether an application should be offered in the
+// current session
+func (o Options) visible(e Entry) bool {
+	if e.typ != "Application" || e.noDisplay || e.hidden || e.Name == "" || e.Exec == "" {
+		return false
+	}
+	if len(e.onlyShowIn) > 0 && !o.onDesktop(e.onlyShowIn) {
+		return false
+	}
+	if o.onDesktop(e.notShowIn) {
+		return false
+	}
+	if e.TryExec != "" {
+		lookPath := o.LookPath
+		if lookPath == nil {
+			lookPath = exec.LookPath
+		}
+		if _, err := lookPath(e.TryExec); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// onDesktop reports whether one of the current desktops is in names
+func (o Options) onDesktop(names []string) bool {
+	for _, d := range o.Desktops {
+		if slices.Contains(names, d) {
+			return true
+		}
+	}
+	return false
+}
+
+// Catalog caches the scanned applications and scans again once one of
+// the application directories has changed
+type Catalog struct {
+	mu      sync.Mutex
+	opts    Options
+	entries []Entry
+	stamps  map[string]time.Time
+}
+
+// NewCatalog creates a catalog, the first scan happens on first use
+func NewCatalog(opts Options) *Catalog {
+	return &Catalog{opts: opts}
+}
+
+// Entries returns the visible applications, sorted by name
+func (c *Catalog) Entries() []Entry {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.stamps == nil || c.changed() {
+		c.entries, c.stamps = scan(c.opts)
+	}
+	return c.entries
+}
+
+// changed reports whether a scanned directory was modified, created or
+// removed since the last scan
+func (c *Catalog) changed() bool {
+	for dir, stamp := range c.stamps {
+		info, err := os.Stat(dir)
+		if err != nil {
+			if !stamp.IsZero() {
+				return true
+			}
+			continue
+		}
+		if !info.ModTime().Equal(stamp) {
+			return true
+		}
+	}
+	return false
+}
+
+// Find returns the visible application with a desktop file ID. An
+// absolute path to a desktop entry file is read directly.
+func (c *Catalog) Find(id string) (Entry, error) {
+	if filepath.IsAbs(id) {
+		e, err := parseFile(id, c.opts.Locale)
+		if err != nil {
+			return Entry{}, err
+		}
+		if e.typ != "Application" || e.Exec == "" {
+			return Entry{}, ErrNotFound
+		}
+		e.ID = filepath.Base(id)
+		return e, nil
+	}
+
+	for _, e := range c.Entries() {
+		if e.ID == id {
+			return e, nil
+		}
+	}
+	return Entry{}, ErrNotFound
+}
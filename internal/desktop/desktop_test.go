@@ -0,0 +1,162 @@
+package desktop
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+const firefox = `# Firefox
+[Desktop Entry]
+Type=Application
+Name=Firefox Web Browser
+Name[de]=Firefox-Webbrowser
+Name[de_AT]=Firefox Browser (AT)
+Comment=Browse the Web
+Comment[de]=Im Internet surfen
+Exec=firefox %u
+Icon=firefox
+Terminal=false
+Keywords=Internet;WWW;Browser;
+Keywords[de]=Internet;WWW;Browser;Web;
+
+[Desktop Action new-window]
+Name=New Window
+Exec=firefox --new-window %u
+`
+
+func TestParseLocalized(t *testing.T) {
+	tests := []struct {
+		locale, name, comment string
+	}{
+		{"de_DE.UTF-8", "Firefox-Webbrowser", "Im Internet surfen"},
+		{"de_AT.UTF-8@euro", "Firefox Browser (AT)", "Im Internet surfen"},
+		{"en_US.UTF-8", "Firefox Web Browser", "Browse the Web"},
+		{"C", "Firefox Web Browser", "Browse the Web"},
+	}
+	for _, tt := range tests {
+		e, err := Parse(strings.NewReader(firefox), tt.locale)
+		if err != nil {
+			t.Fatalf("Parse returned %v", err)
+		}
+		if e.Name != tt.name || e.Comment != tt.comment {
+			t.Errorf("%s: Name = %q, Comment = %q", tt.locale, e.Name, e.Comment)
+		}
+		if e.Exec != "firefox %u" {
+			t.Errorf("%s: actions must not override the entry, Exec = %q", tt.locale, e.Exec)
+		}
+	}
+
+	if _, err := Parse(strings.NewReader("[Other]\nName=x\n"), ""); !errors.Is(err, ErrNoDesktopEntry) {
+		t.Errorf("expected ErrNoDesktopEntry, got %v", err)
+	}
+}
+
+func TestCommand(t *testing.T) {
+	tests := []struct {
+		exec    string
+		targets []string
+		want    []string
+	}{
+		{"firefox %u", []string{"https://example.com"}, []string{"firefox", "https://example.com"}},
+		{"firefox %u", nil, []string{"firefox"}},
+		{"gimp-2.10 %U", []string{"/a.png", "/b b.png"}, []string{"gimp-2.10", "/a.png", "/b b.png"}},
+		{"code --new-window %F", []string{"/tmp"}, []string{"code", "--new-window", "/tmp"}},
+		{"app %i --name=%c %k 100%%", nil, []string{"app", "--icon", "app-icon", "--name=My App", "/usr/share/applications/app.desktop", "100%"}},
+		{`"/opt/My App/run" --title "a \"quoted\" \$x" %f %m`, []string{"/file"}, []string{"/opt/My App/run", "--title", `a "quoted" $x`, "/file"}},
+	}
+	for _, tt := range tests {
+		e := Entry{Name: "My App", Icon: "app-icon", File: "/usr/share/applications/app.desktop", Exec: tt.exec}
+		got, err := e.Command(tt.targets)
+		if err != nil || !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Command(%q) = %q, %v, want %q", tt.exec, got, err, tt.want)
+		}
+	}
+
+	if _, err := (Entry{Exec: `app "unterminated`}).Command(nil); !errors.Is(err, ErrInvalidExec) {
+		t.Errorf("expected ErrInvalidExec, got %v", err)
+	}
+}
+
+func writeEntry(t *testing.T, dir, name, content string) {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte("[Desktop Entry]\nType=Application\n"+content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestScan(t *testing.T) {
+	user, system := t.TempDir(), t.TempDir()
+	writeEntry(t, system, "editor.desktop", "Name=Editor\nExec=editor %f\n")
+	writeEntry(t, system, "removed.desktop", "Name=Removed\nExec=removed\n")
+	writeEntry(t, user, "removed.desktop", "Name=Removed\nExec=removed\nHidden=true\n")
+	writeEntry(t, system, "settings.desktop", "Name=Settings\nExec=settings\nNoDisplay=true\n")
+	writeEntry(t, system, "kde-only.desktop", "Name=KDE Tool\nExec=kdetool\nOnlyShowIn=KDE;\n")
+	writeEntry(t, system, "not-gnome.desktop", "Name=Not GNOME\nExec=x\nNotShowIn=GNOME;\n")
+	writeEntry(t, system, "missing.desktop", "Name=Missing\nExec=missing\nTryExec=missing\n")
+	writeEntry(t, system, "kde4/dolphin.desktop", "Name=Dolphin\nExec=dolphin %u\n")
+	writeEntry(t, user, "editor.desktop", "Name=My Editor\nExec=editor --user %f\n")
+
+	opts := Options{
+		Dirs:     []string{user, system},
+		Desktops: []string{"GNOME"},
+		LookPath: func(name string) (string, error) { return "", errors.New("not found") },
+	}
+	var names, ids []string
+	for _, e := range Scan(opts) {
+		names = append(names, e.Name)
+		ids = append(ids, e.ID)
+	}
+	if want := []string{"Dolphin", "My Editor"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("names = %q, want %q", names, want)
+	}
+	if want := []string{"kde4-dolphin.desktop", "editor.desktop"}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("ids = %q, want %q", ids, want)
+	}
+}
+
+func TestCatalogRescans(t *testing.T) {
+	dir := t.TempDir()
+	writeEntry(t, dir, "a.desktop", "Name=A\nExec=a\n")
+	c := NewCatalog(Options{Dirs: []string{dir}})
+
+	if e, err := c.Find("a.desktop"); err != nil || e.Name != "A" {
+		t.Fatalf("Find = %+v, %v", e, err)
+	}
+	if _, err := c.Find("b.desktop"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+
+	// Make sure the directory time changes on coarse file systems
+	writeEntry(t, dir, "b.desktop", "Name=B\nExec=b\n")
+	later := time.Now().Add(time.Second)
+	os.Chtimes(dir, later, later)
+	if len(c.Entries()) != 2 {
+		t.Errorf("new entries should be picked up, got %+v", c.Entries())
+	}
+
+	if e, err := c.Find(filepath.Join(dir, "b.desktop")); err != nil || e.Name != "B" {
+		t.Errorf("Find by path = %+v, %v", e, err)
+	}
+}
+
+func TestDirs(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("desktop entries are Linux only")
+	}
+	env := map[string]string{"HOME": "/home/jo", "XDG_DATA_DIRS": "/usr/share:/usr/share:/var/lib/flatpak/exports/share"}
+	got := Dirs(func(k string) string { return env[k] })
+	want := []string{"/home/jo/.local/share/applications", "/usr/share/applications", "/var/lib/flatpak/exports/share/applications"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Dirs = %q, want %q", got, want)
+	}
+}
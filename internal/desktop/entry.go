@@ -0,0 +1,194 @@
+package desktop
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"strings"
+)
+
+// Entry is an application from a desktop entry file
+type Entry struct {
+	// ID is the desktop file ID, e.g. "org.gnome.Nautilus.desktop"
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	GenericName string   `json:"genericName,omitempty"`
+	Comment     string   `json:"comment,omitempty"`
+	Icon        string   `json:"icon,omitempty"`
+	Exec        string   `json:"exec"`
+	TryExec     string   `json:"-"`
+	Path        string   `json:"path,omitempty"`
+	Terminal    bool     `json:"terminal"`
+	Keywords    []string `json:"keywords,omitempty"`
+	Categories  []string `json:"categories,omitempty"`
+	// File is the path of the desktop entry file
+	File string `json:"file"`
+
+	typ        string
+	noDisplay  bool
+	hidden     bool
+	onlyShowIn []string
+	notShowIn  []string
+}
+
+// ErrNoDesktopEntry is returned for files without a [Desktop Entry] group
+var ErrNoDesktopEntry = errors.New("no [Desktop Entry] group")
+
+// Parse reads a desktop entry file. Localized keys like Name[de] are
+// preferred according to locale, e.g. "de_DE.UTF-8".
+func Parse(r io.Reader, locale string) (Entry, error) {
+	var e Entry
+	// rank of the locale variant each localized key was taken from
+	ranks := map[string]int{}
+	variants := localeVariants(locale)
+
+	found := false
+	inGroup := false
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || line[0] == '#' {
+			continue
+		}
+		if line[0] == '[' {
+			inGroup = line == "[Desktop Entry]"
+			found = found || inGroup
+			continue
+		}
+		if !inGroup {
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		key = strings.TrimSpace(key)
+		value = strings.TrimSpace(value)
+
+		key, lang, localized := strings.Cut(key, "[")
+		rank := len(variants)
+		if localized {
+			lang = strings.TrimSuffix(lang, "]")
+			rank = -1
+			for i, v := range variants {
+				if v == lang {
+					rank = i
+					break
+				}
+			}
+			if rank < 0 {
+				continue
+			}
+		}
+		if prev, ok := ranks[key]; ok && prev <= rank {
+			continue
+		}
+		ranks[key] = rank
+
+		switch key {
+		case "Type":
+			e.typ = value
+		case "Name":
+			e.Name = unescape(value)
+		case "GenericName":
+			e.GenericName = unescape(value)
+		case "Comment":
+			e.Comment = unescape(value)
+		case "Icon":
+			e.Icon = unescape(value)
+		case "Exec":
+			e.Exec = unescape(value)
+		case "TryExec":
+			e.TryExec = unescape(value)
+		case "Path":
+			e.Path = unescape(value)
+		case "Terminal":
+			e.Terminal = value == "true"
+		case "NoDisplay":
+			e.noDisplay = value == "true"
+		case "Hidden":
+			e.hidden = value == "true"
+		case "OnlyShowIn":
+			e.onlyShowIn = list(value)
+		case "NotShowIn":
+			e.notShowIn = list(value)
+		case "Keywords":
+			e.Keywords = list(value)
+		case "Categories":
+			e.Categories = list(value)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return Entry{}, err
+	}
+	if !found {
+		return Entry{}, ErrNoDesktopEntry
+	}
+	return e, nil
+}
+
+// localeVariants returns the locale keys to look for, best first: for
+// "de_DE.UTF-8@euro" that is de_DE@euro, de_DE, de@euro and de
+func localeVariants(locale string) []string {
+	if locale == "" || locale == "C" || locale == "POSIX" {
+		return nil
+	}
+	locale, modifier, _ := strings.Cut(locale, "@")
+	locale, _, _ = strings.Cut(locale, ".")
+	lang, country, _ := strings.Cut(locale, "_")
+
+	var variants []string
+	if country != "" {
+		if modifier != "" {
+			variants = append(variants, lang+"_"+country+"@"+modifier)
+		}
+		variants = append(variants, lang+"_"+country)
+	}
+	if modifier != "" {
+		variants = append(variants, lang+"@"+modifier)
+	}
+	return append(variants, lang)
+}
+
+// unescape resolves the escape sequences of string values
+func unescape(value string) string {
+	if !strings.Contains(value, `\`) {
+		return value
+	}
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		if value[i] != '\\' || i+1 == len(value) {
+			b.WriteByte(value[i])
+			continue
+		}
+		i++
+		switch value[i] {
+		case 's':
+			b.WriteByte(' ')
+		case 'n':
+			b.WriteByte('\n')
+		case 't':
+			b.WriteByte('\t')
+		case 'r':
+			b.WriteByte('\r')
+		case '\\':
+			b.WriteByte('\\')
+		default:
+			b.WriteByte('\\')
+			b.WriteByte(value[i])
+		}
+	}
+	return b.String()
+}
+
+// list splits a semicolon-separated list value
+func list(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ";") {
+		if item = strings.TrimSpace(unescape(item)); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
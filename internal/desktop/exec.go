@@ -0,0 +1,118 @@
+package desktop
+
+import (
+	"errors"
+	"strings"
+)
+
+// ErrInvalidExec is returned for Exec values that cannot be split into
+// arguments, e.g. because of an unterminated quote
+var ErrInvalidExec = errors.New("invalid Exec value")
+
+// Command returns the argument list to launch the entry with the given
+// files or URLs. Field codes are expanded: %f and %u take the first
+// target, %F and %U all of them, %i the icon, %c the name and %k the
+// entry file. Arguments that consist of a field code without value are
+// dropped, deprecated field codes are removed.
+func (e Entry) Command(targets []string) ([]string, error) {
+	args, err := splitExec(e.Exec)
+	if err != nil {
+		return nil, err
+	}
+	if len(args) == 0 {
+		return nil, ErrInvalidExec
+	}
+
+	first := ""
+	if len(targets) > 0 {
+		first = targets[0]
+	}
+
+	var argv []string
+	for _, arg := range args {
+		switch arg {
+		case "%f", "%u":
+			if first != "" {
+				argv = append(argv, first)
+			}
+		case "%F", "%U":
+			argv = append(argv, targets...)
+		case "%i":
+			if e.Icon != "" {
+				argv = append(argv, "--icon", e.Icon)
+			}
+		case "%d", "%D", "%n", "%N", "%v", "%m":
+		default:
+			argv = append(argv, expandInline(arg, e, first))
+		}
+	}
+	if len(argv) == 0 {
+		return nil, ErrInvalidExec
+	}
+	return argv, nil
+}
+
+// expandInline expands field codes within an argument
+func expandInline(arg string, e Entry, first string) string {
+	if !strings.Contains(arg, "%") {
+		return arg
+	}
+	var b strings.Builder
+	for i := 0; i < len(arg); i++ {
+		if arg[i] != '%' || i+1 == len(arg) {
+			b.WriteByte(arg[i])
+			continue
+		}
+		i++
+		switch arg[i] {
+		case '%':
+			b.WriteByte('%')
+		case 'f', 'u', 'F', 'U':
+			b.WriteString(first)
+		case 'c':
+			b.WriteString(e.Name)
+		case 'k':
+			b.WriteString(e.File)
+		}
+	}
+	return b.String()
+}
+
+// splitExec splits an Exec value into arguments. Arguments in double
+// quotes may contain spaces; within them \", \`, \$ and \\ are escapes.
+func splitExec(exec string) ([]string, error) {
+	var args []string
+	var cur strings.Builder
+	inArg, quoted := false, false
+
+	for i := 0; i < len(exec); i++ {
+		c := exec[i]
+		switch {
+		case quoted && c == '\\' && i+1 < len(exec) && strings.IndexByte("\"`$\\", exec[i+1]) >= 0:
+			i++
+			cur.WriteByte(exec[i])
+		case quoted && c == '"':
+			quoted = false
+		case quoted:
+			cur.WriteByte(c)
+		case c == '"':
+			quoted, inArg = true, true
+		case c == ' ' || c == '\t':
+			if inArg {
+				args = append(args, cur.String())
+				cur.Reset()
+				inArg = false
+			}
+		default:
+			cur.WriteByte(c)
+			inArg = true
+		}
+	}
+	if quoted {
+		return nil, ErrInvalidExec
+	}
+	if inArg {
+		args = append(args, cur.String())
+	}
+	return args, nil
+}
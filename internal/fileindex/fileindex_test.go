@@ -0,0 +1,195 @@
+package fileindex
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+)
+
+// writeTree creates files and their directories below root
+func writeTree(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestIgnoreRules(t *testing.T) {
+	base := filepath.FromSlash("/code/app")
+	rs := newRuleSet(base, []string{"*.log", "!keep.log", "/build", "docs/**/*.tmp", "cache/", "# comment", ""}, nil)
+	child := newRuleSet(filepath.Join(base, "web"), []string{"dist"}, rs)
+
+	tests := []struct {
+		rs   *ruleSet
+		path string
+		dir  bool
+		want bool
+	}{
+		{rs, "debug.log", false, true},
+		{rs, "sub/debug.log", false, true},
+		{rs, "keep.log", false, false},
+		{rs, "build", true, true},
+		{rs, "sub/build", true, false},
+		{rs, "docs/a/b/x.tmp", false, true},
+		{rs, "docs/x.tmp", false, true},
+		{rs, "cache", true, true},
+		{rs, "cache", false, false},
+		{rs, "main.go", false, false},
+		{child, "web/dist", true, true},
+		{child, "web/trace.log", false, true},
+		{rs, "dist", true, false},
+	}
+	for _, tt := range tests {
+		path := filepath.Join(base, filepath.FromSlash(tt.path))
+		if got := tt.rs.ignored(path, tt.dir); got != tt.want {
+			t.Errorf("ignored(%q, %v) = %v, want %v", tt.path, tt.dir, got, tt.want)
+		}
+	}
+}
+
+func TestCrawlAndSearch(t *testing.T) {
+	root := t.TempDir()
+	writeTree(t, root, map[string]string{
+		".gitignore":                    "*.tmp\n",
+		"quicklaunch/README.md":         "",
+		"quicklaunch/app.go":            "",
+		"quicklaunch/scratch.tmp":       "",
+		"quicklaunch/node_modules/x.js": "",
+		"quicklaunch/web/.gitignore":    "dist/\n",
+		"quicklaunch/web/dist/app.js":   "",
+		"Rechnungen/Übersicht.pdf":      "",
+	})
+
+	x := New(Options{Roots: []string{root}}, nil)
+	if !x.refresh() {
+		t.Fatal("refresh reported a closed index")
+	}
+
+	for _, ignored := range []string{"scratch.tmp", "node_modules", "dist", "x.js"} {
+		if results := x.Search(ignored, 0); len(results) != 0 {
+			t.Errorf("%s should be ignored, got %+v", ignored, results)
+		}
+	}
+
+	results := x.Search("qlread", 0)
+	if len(results) != 1 || results[0].Path != filepath.Join(root, "quicklaunch", "README.md") {
+		t.Fatalf("fuzzy search for qlread = %+v", results)
+	}
+	results = x.Search("quick", 0)
+	if len(results) == 0 || results[0].Name != "quicklaunch" || !results[0].Dir {
+		t.Errorf("the folder starting with the query should rank first, got %+v", results)
+	}
+	if results := x.Search("ubersicht", 0); len(results) != 1 || results[0].Name != "Übersicht.pdf" {
+		t.Errorf("search should ignore diacritics, got %+v", results)
+	}
+}
+
+func TestMaxItems(t *testing.T) {
+	root := t.TempDir()
+	writeTree(t, root, map[string]string{"a": "", "b": "", "c": "", "d/e": ""})
+
+	x := New(Options{Roots: []string{root}, MaxItems: 2}, nil)
+	x.refresh()
+	if s := x.Status(); s.Items != 2 || !s.Truncated {
+		t.Errorf("Status = %+v, want 2 truncated items", s)
+	}
+}
+
+func TestSaveLoad(t *testing.T) {
+	root, dir := t.TempDir(), t.TempDir()
+	writeTree(t, root, map[string]string{"docs/plan.txt": ""})
+
+	x := New(Options{Roots: []string{root}, Dir: dir}, nil)
+	x.refresh()
+	if err := x.Save(); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+
+	loaded := New(Options{Roots: []string{root}, Dir: dir}, nil)
+	if err := loaded.Load(); err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if results := loaded.Search("plan", 0); len(results) != 1 || results[0].Dir {
+		t.Errorf("loaded index should find the file, got %+v", results)
+	}
+
+	// An index of other roots is not used
+	other := New(Options{Roots: []string{t.TempDir()}, Dir: dir}, nil)
+	if err := other.Load(); err != nil || other.Status().Items != 0 {
+		t.Errorf("index of other roots should be skipped, got %+v, %v", other.Status(), err)
+	}
+}
+
+func TestWatch(t *testing.T) {
+	root := t.TempDir()
+	writeTree(t, root, map[string]string{"old.txt": ""})
+
+	x := New(Options{Roots: []string{root}}, nil)
+	x.Start()
+	defer x.Close()
+
+	// Wait for the first crawl, then for the watches
+	waitFor(t, func() bool { return len(x.Search("old", 0)) == 1 })
+	waitFor(t, func() bool { return x.Status().Watching })
+
+	// A folder moved in is crawled as a whole
+	outside := t.TempDir()
+	writeTree(t, outside, map[string]string{"new/deep/notes.md": ""})
+	if err := os.Rename(filepath.Join(outside, "new"), filepath.Join(root, "new")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Rename(filepath.Join(root, "old.txt"), filepath.Join(root, "renamed.txt")); err != nil {
+		t.Fatal(err)
+	}
+
+	waitFor(t, func() bool { return len(x.Search("notes", 0)) == 1 })
+	waitFor(t, func() bool { return len(x.Search("renamed", 0)) == 1 && len(x.Search("old.txt", 0)) == 0 })
+
+	if err := os.RemoveAll(filepath.Join(root, "new")); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, func() bool { return len(x.Search("notes", 0)) == 0 })
+}
+
+// waitFor polls cond for up to five seconds
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met in time")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestIsProgram(t *testing.T) {
+	if runtime.GOOS == "windows" || runtime.GOOS == "darwin" {
+		t.Skip("checks Linux files")
+	}
+	root := t.TempDir()
+	writeTree(t, root, map[string]string{"build.sh": "#!/bin/sh\n", "firefox.desktop": "[Desktop Entry]\n"})
+	script := filepath.Join(root, "build.sh")
+	if err := os.Chmod(script, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	// Executable files must not start when a search result is opened
+	info, _ := os.Stat(script)
+	if IsProgram(script, info) || IsDesktopEntry(script, info) {
+		t.Error("an executable script should open like any other file")
+	}
+	entry := filepath.Join(root, "firefox.desktop")
+	info, _ = os.Stat(entry)
+	if !IsDesktopEntry(entry, info) {
+		t.Error("desktop entries should start their application")
+	}
+}
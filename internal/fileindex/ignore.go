@@ -0,0 +1,173 @@
+package fileindex
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// IgnoreFile is read in every crawled directory
+const IgnoreFile = ".gitignore"
+
+// DefaultIgnore lists directories that are never worth indexing
+var DefaultIgnore = []string{
+	".git/", ".hg/", ".svn/",
+	"node_modules/", "__pycache__/", ".venv/", ".cache/",
+	".Trash*/", "$RECYCLE.BIN/", "System Volume Information/",
+}
+
+// rule is one pattern of an ignore file
+type rule struct {
+	re      *regexp.Regexp
+	negate  bool
+	dirOnly bool
+}
+
+// ruleSet holds the rules of one directory. Rules of parent directories
+// apply first, the last matching rule wins like in git.
+type ruleSet struct {
+	base   string
+	rules  []rule
+	parent *ruleSet
+}
+
+// newRuleSet parses gitignore patterns relative to base
+func newRuleSet(base string, patterns []string, parent *ruleSet) *ruleSet {
+	rs := &ruleSet{base: base, parent: parent}
+	for _, p := range patterns {
+		if r, ok := parseRule(p); ok {
+			rs.rules = append(rs.rules, r)
+		}
+	}
+	return rs
+}
+
+// readRuleSet returns the rule set of dir, which is parent unless dir
+// has an ignore file of its own
+func readRuleSet(dir string, parent *ruleSet) *ruleSet {
+	f, err := os.Open(filepath.Join(dir, IgnoreFile))
+	if err != nil {
+		return parent
+	}
+	defer f.Close()
+
+	var patterns []string
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		patterns = append(patterns, sc.Text())
+	}
+	rs := newRuleSet(dir, patterns, parent)
+	if len(rs.rules) == 0 {
+		return parent
+	}
+	return rs
+}
+
+// parseRule converts a gitignore pattern to a rule. Blank lines and
+// comments yield no rule.
+func parseRule(p string) (rule, bool) {
+	p = strings.TrimRight(p, " \t\r")
+	if p == "" || strings.HasPrefix(p, "#") {
+		return rule{}, false
+	}
+
+	var r rule
+	if strings.HasPrefix(p, "!") {
+		r.negate = true
+		p = p[1:]
+	} else if strings.HasPrefix(p, `\!`) || strings.HasPrefix(p, `\#`) {
+		p = p[1:]
+	}
+	if strings.HasSuffix(p, "/") {
+		r.dirOnly = true
+		p = strings.TrimRight(p, "/")
+	}
+	if p == "" {
+		return rule{}, false
+	}
+
+	// Patterns with a slash are relative to the directory of the ignore
+	// file, others match a name at any depth
+	prefix := "^(?:.*/)?"
+	if strings.Contains(p, "/") {
+		prefix = "^"
+		p = strings.TrimPrefix(p, "/")
+	}
+	re, err := regexp.Compile(prefix + globRegexp(p) + "$")
+	if err != nil {
+		return rule{}, false
+	}
+	r.re = re
+	return r, true
+}
+
+// globRegexp translates a glob with "*", "?", "[...]" and "**" to a
+// regular expression
+func globRegexp(glob string) string {
+	var b strings.Builder
+	for i := 0; i < len(glob); i++ {
+		c := glob[i]
+		switch {
+		case strings.HasPrefix(glob[i:], "**/"):
+			b.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(glob[i:], "/**") && i+3 == len(glob):
+			b.WriteString("/.*")
+			i += 2
+		case strings.HasPrefix(glob[i:], "**"):
+			b.WriteString(".*")
+			i++
+		case c == '*':
+			b.WriteString("[^/]*")
+		case c == '?':
+			b.WriteString("[^/]")
+		case c == '[':
+			end := strings.IndexByte(glob[i+1:], ']')
+			if end < 0 {
+				b.WriteString(`\[`)
+				continue
+			}
+			class := glob[i+1 : i+1+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			b.WriteString("[" + strings.ReplaceAll(class, `\`, `\\`) + "]")
+			i += end + 1
+		case c == '\\' && i+1 < len(glob):
+			i++
+			b.WriteString(regexp.QuoteMeta(glob[i : i+1]))
+		default:
+			b.WriteString(regexp.QuoteMeta(glob[i : i+1]))
+		}
+	}
+	return b.String()
+}
+
+// ignored reports whether path is excluded by rs or its parents
+func (rs *ruleSet) ignored(path string, dir bool) bool {
+	var chain []*ruleSet
+	for s := rs; s != nil; s = s.parent {
+		chain = append(chain, s)
+	}
+
+	ignored := false
+	for i := len(chain) - 1; i >= 0; i-- {
+		s := chain[i]
+		rel, err := filepath.Rel(s.base, path)
+		if err != nil || strings.HasPrefix(rel, "..") {
+			continue
+		}
+		rel = filepath.ToSlash(rel)
+		for _, r := range s.rules {
+			if r.dirOnly && !dir {
+				continue
+			}
+			if r.re.MatchString(rel) {
+				ignored = !r.negate
+			}
+		}
+	}
+	return ignored
+}
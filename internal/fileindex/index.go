@@ -0,0 +1,374 @@
+package fileindex
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+
+	"quicklaunch/internal/search"
+)
+
+const (
+	// DefaultMaxItems limits the index to a size that searches quickly
+	DefaultMaxItems = 200000
+	// DefaultMaxWatches limits the watched directories, the system limits
+	// are shared with all other programs
+	DefaultMaxWatches = 8192
+	// saveInterval is how often changes from notifications are saved
+	saveInterval = time.Minute
+	// rescanInterval is how often the roots are crawled again when not
+	// all directories could be watched
+	rescanInterval = 15 * time.Minute
+)
+
+// Options configures an Index
+type Options struct {
+	// Roots are the directories to index, "~" stands for the home directory
+	Roots []string
+	// Ignore holds gitignore patterns for all roots, in addition to
+	// DefaultIgnore and the ignore files found while crawling
+	Ignore     []string
+	MaxItems   int
+	MaxWatches int
+	// Dir is where the index is saved, empty keeps it in memory only
+	Dir string
+}
+
+// Item is an indexed file or folder
+type Item struct {
+	Path string `json:"path"`
+	Name string `json:"name"`
+	Dir  bool   `json:"dir"`
+}
+
+// Result is an item matching a search
+type Result struct {
+	Item
+	Score      int                `json:"score"`
+	Highlights []search.Highlight `json:"highlights"`
+}
+
+// Status describes the state of an Index
+type Status struct {
+	Roots    []string `json:"roots"`
+	Items    int      `json:"items"`
+	Indexing bool     `json:"indexing"`
+	// Watching is false when not all directories could be watched; the
+	// roots are then crawled again every 15 minutes
+	Watching  bool      `json:"watching"`
+	Truncated bool      `json:"truncated"`
+	Updated   time.Time `json:"updated"`
+	Error     string    `json:"error,omitempty"`
+}
+
+// Index keeps the paths below a set of roots searchable. It crawls the
+// roots in the background and follows changes through filesystem
+// notifications.
+type Index struct {
+	opts     Options
+	onChange func()
+
+	mu        sync.Mutex
+	items     map[string]bool
+	dirs      map[string]*ruleSet
+	rootRules map[string]*ruleSet
+	list      []Item
+	entries   []search.Entry
+	indexing  bool
+	truncated bool
+	watching  bool
+	dirty     bool
+	updated   time.Time
+	err       error
+
+	stop    chan struct{}
+	stopped sync.Once
+	wg      sync.WaitGroup
+}
+
+// New creates an empty index of opts.Roots. onChange, if not nil, is
+// called when indexing starts or ends.
+func New(opts Options, onChange func()) *Index {
+	if opts.MaxItems <= 0 {
+		opts.MaxItems = DefaultMaxItems
+	}
+	if opts.MaxWatches <= 0 {
+		opts.MaxWatches = DefaultMaxWatches
+	}
+	opts.Roots = cleanRoots(opts.Roots)
+	if onChange == nil {
+		onChange = func() {}
+	}
+	return &Index{
+		opts:      opts,
+		onChange:  onChange,
+		items:     make(map[string]bool),
+		dirs:      make(map[string]*ruleSet),
+		rootRules: make(map[string]*ruleSet),
+		stop:      make(chan struct{}),
+	}
+}
+
+// cleanRoots expands "~", makes roots absolute and drops duplicates
+func cleanRoots(roots []string) []string {
+	home, _ := os.UserHomeDir()
+	var cleaned []string
+	seen := make(map[string]bool)
+	for _, root := range roots {
+		root = strings.TrimSpace(root)
+		if root == "" {
+			continue
+		}
+		if home != "" && (root == "~" || strings.HasPrefix(root, "~/") || strings.HasPrefix(root, `~\`)) {
+			root = filepath.Join(home, root[1:])
+		}
+		if abs, err := filepath.Abs(root); err == nil {
+			root = abs
+		}
+		if !seen[root] {
+			seen[root] = true
+			cleaned = append(cleaned, root)
+		}
+	}
+	return cleaned
+}
+
+// Roots returns the indexed roots
+func (x *Index) Roots() []string {
+	return x.opts.Roots
+}
+
+// Start loads the saved index and crawls the roots in the background,
+// then watches them for changes until Close is called
+func (x *Index) Start() {
+	x.wg.Add(1)
+	go x.run()
+}
+
+// Close stops watching and saves the index
+func (x *Index) Close() error {
+	x.stopped.Do(func() { close(x.stop) })
+	x.wg.Wait()
+
+	x.mu.Lock()
+	dirty := x.dirty
+	x.mu.Unlock()
+	if !dirty {
+		return nil
+	}
+	return x.Save()
+}
+
+// Status returns the current state of the index
+func (x *Index) Status() Status {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	s := Status{
+		Roots:     x.opts.Roots,
+		Items:     len(x.items),
+		Indexing:  x.indexing,
+		Watching:  x.watching,
+		Truncated: x.truncated,
+		Updated:   x.updated,
+	}
+	if x.err != nil {
+		s.Error = x.err.Error()
+	}
+	return s
+}
+
+// Search matches query fuzzily against the names and paths of the
+// indexed items and returns up to limit results, best first. Names that
+// start with the query rank highest.
+func (x *Index) Search(query string, limit int) []Result {
+	if strings.TrimSpace(query) == "" {
+		return nil
+	}
+
+	list, entries := x.snapshot()
+	hits := search.Rank(query, entries, limit)
+	results := make([]Result, 0, len(hits))
+	for _, hit := range hits {
+		results = append(results, Result{Item: list[hit.Index], Score: hit.Score, Highlights: hit.Highlights})
+	}
+	return results
+}
+
+// snapshot returns the items with their search entries, rebuilt after
+// changes. Shallow paths come first, so they win ties.
+func (x *Index) snapshot() ([]Item, []search.Entry) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	if x.entries != nil {
+		return x.list, x.entries
+	}
+
+	list := make([]Item, 0, len(x.items))
+	for path, dir := range x.items {
+		list = append(list, Item{Path: path, Name: filepath.Base(path), Dir: dir})
+	}
+	sort.Slice(list, func(i, j int) bool {
+		di, dj := strings.Count(list[i].Path, string(filepath.Separator)), strings.Count(list[j].Path, string(filepath.Separator))
+		if di != dj {
+			return di < dj
+		}
+		return list[i].Path < list[j].Path
+	})
+	entries := make([]search.Entry, len(list))
+	for i, item := range list {
+		entries[i] = search.Entry{Fields: []search.Field{
+			{Name: "name", Text: item.Name, Weight: 100},
+			{Name: "path", Text: item.Path, Weight: 50},
+		}}
+	}
+	x.list, x.entries = list, entries
+	return list, entries
+}
+
+// changed drops the search snapshot after the items changed. The caller
+// holds x.mu.
+func (x *Index) changed() {
+	x.list, x.entries = nil, nil
+	x.dirty = true
+	x.updated = time.Now()
+}
+
+// setIndexing updates the indexing state and reports it
+func (x *Index) setIndexing(indexing bool) {
+	x.mu.Lock()
+	x.indexing = indexing
+	x.mu.Unlock()
+	x.onChange()
+}
+
+// run crawls the roots and follows changes until the index is closed
+func (x *Index) run() {
+	defer x.wg.Done()
+
+	if err := x.Load(); err != nil {
+		x.setErr(err)
+	}
+	if !x.refresh() {
+		return
+	}
+	if err := x.Save(); err != nil {
+		x.setErr(err)
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		x.setErr(err)
+		watcher = nil
+	} else {
+		defer watcher.Close()
+		x.watchAll(watcher)
+	}
+	x.follow(watcher)
+}
+
+// refresh crawls all roots and replaces the items. It returns false if
+// the index was closed meanwhile.
+func (x *Index) refresh() bool {
+	x.setIndexing(true)
+	defer x.setIndexing(false)
+
+	c := x.newCrawler(x.opts.MaxItems)
+	rootRules := make(map[string]*ruleSet)
+	for _, root := range x.opts.Roots {
+		if info, err := os.Stat(root); err != nil || !info.IsDir() {
+			continue
+		}
+		rs := newRuleSet(root, append(append([]string{}, DefaultIgnore...), x.opts.Ignore...), nil)
+		rootRules[root] = rs
+		c.walk(root, rs)
+	}
+	if c.closed() {
+		return false
+	}
+
+	x.mu.Lock()
+	x.items, x.dirs, x.rootRules = c.items, c.dirs, rootRules
+	x.truncated = c.truncated
+	x.err = nil
+	x.changed()
+	x.mu.Unlock()
+	return true
+}
+
+// setErr records the last error for Status
+func (x *Index) setErr(err error) {
+	x.mu.Lock()
+	x.err = err
+	x.mu.Unlock()
+}
+
+// crawler collects the items below a directory
+type crawler struct {
+	items     map[string]bool
+	dirs      map[string]*ruleSet
+	max       int
+	truncated bool
+	stop      <-chan struct{}
+}
+
+func (x *Index) newCrawler(max int) *crawler {
+	return &crawler{
+		items: make(map[string]bool),
+		dirs:  make(map[string]*ruleSet),
+		max:   max,
+		stop:  x.stop,
+	}
+}
+
+// closed reports whether the index was closed during the crawl
+func (c *crawler) closed() bool {
+	select {
+	case <-c.stop:
+		return true
+	default:
+		return false
+	}
+}
+
+// walk adds the contents of dir whose parent directory has the rules
+// parent. Symbolic links are indexed but not followed.
+func (c *crawler) walk(dir string, parent *ruleSet) {
+	if c.truncated || c.closed() {
+		return
+	}
+	rs := readRuleSet(dir, parent)
+	c.dirs[dir] = rs
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return
+	}
+	for _, e := range entries {
+		path := filepath.Join(dir, e.Name())
+		isDir := e.IsDir()
+		if rs.ignored(path, isDir) {
+			continue
+		}
+		if len(c.items) >= c.max {
+			c.truncated = true
+			return
+		}
+		c.items[path] = isDir
+		if isDir && !isBundle(path) {
+			c.walk(path, rs)
+		}
+	}
+}
+
+// isBundle reports whether path is a macOS application bundle, which is
+// indexed as a whole
+func isBundle(path string) bool {
+	return strings.HasSuffix(path, ".app")
+}
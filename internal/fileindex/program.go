@@ -0,0 +1,34 @@
+package fileindex
+
+import (
+	"io/fs"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// IsProgram reports whether the item at path starts a program when opened:
+// executables on Windows and application bundles on macOS. Files with an
+// execute bit are not programs, scripts in a project or any file on a
+// FAT or NTFS mount have one.
+func IsProgram(path string, info fs.FileInfo) bool {
+	switch runtime.GOOS {
+	case "windows":
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".exe", ".bat", ".cmd", ".com":
+			return !info.IsDir()
+		}
+	case "darwin":
+		return isBundle(path) && info.IsDir()
+	}
+	return false
+}
+
+// IsDesktopEntry reports whether the item at path is a desktop entry that
+// starts an application on Linux and other freedesktop systems
+func IsDesktopEntry(path string, info fs.FileInfo) bool {
+	if runtime.GOOS == "windows" || runtime.GOOS == "darwin" {
+		return false
+	}
+	return info.Mode().IsRegular() && strings.EqualFold(filepath.Ext(path), ".desktop")
+}
@@ -0,0 +1,153 @@
+package fileindex
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// FileName is the name of the saved index in Options.Dir
+const FileName = "fileindex.gz"
+
+// storeVersion changes when the file format does
+const storeVersion = 1
+
+// header is the first line of the index file. An index saved for other
+// roots or ignore patterns is not loaded.
+type header struct {
+	Version   int       `json:"version"`
+	Roots     []string  `json:"roots"`
+	Ignore    []string  `json:"ignore"`
+	Truncated bool      `json:"truncated"`
+	Updated   time.Time `json:"updated"`
+}
+
+// Load reads the index saved by a previous run, so searches work before
+// the first crawl has finished. A missing or outdated file is no error.
+func (x *Index) Load() error {
+	if x.opts.Dir == "" {
+		return nil
+	}
+	f, err := os.Open(filepath.Join(x.opts.Dir, FileName))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	zr, err := gzip.NewReader(f)
+	if err != nil {
+		return err
+	}
+	sc := bufio.NewScanner(zr)
+	sc.Buffer(make([]byte, 64*1024), 1024*1024)
+	if !sc.Scan() {
+		return sc.Err()
+	}
+	var h header
+	if err := json.Unmarshal(sc.Bytes(), &h); err != nil {
+		return err
+	}
+	if h.Version != storeVersion || !slices.Equal(h.Roots, x.opts.Roots) || !slices.Equal(h.Ignore, x.opts.Ignore) {
+		return nil
+	}
+
+	// One item per line: "d" or "f", a tab and the path
+	items := make(map[string]bool)
+	for sc.Scan() {
+		kind, path, ok := strings.Cut(sc.Text(), "\t")
+		if !ok || path == "" {
+			continue
+		}
+		items[path] = kind == "d"
+	}
+	if err := sc.Err(); err != nil {
+		return err
+	}
+
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	if len(x.items) > 0 {
+		// A crawl was faster
+		return nil
+	}
+	x.items = items
+	x.truncated = h.Truncated
+	x.changed()
+	x.updated = h.Updated
+	x.dirty = false
+	return nil
+}
+
+// Save writes the index to Options.Dir
+func (x *Index) Save() error {
+	x.mu.Lock()
+	h := header{
+		Version:   storeVersion,
+		Roots:     x.opts.Roots,
+		Ignore:    x.opts.Ignore,
+		Truncated: x.truncated,
+		Updated:   x.updated,
+	}
+	items := make(map[string]bool, len(x.items))
+	for path, dir := range x.items {
+		items[path] = dir
+	}
+	x.dirty = false
+	x.mu.Unlock()
+
+	if x.opts.Dir == "" {
+		return nil
+	}
+	path := filepath.Join(x.opts.Dir, FileName)
+	if err := writeIndex(path+".tmp", h, items); err != nil {
+		return err
+	}
+	// Replace the file only when it was written completely
+	return os.Rename(path+".tmp", path)
+}
+
+// writeIndex writes the index file format to path
+func writeIndex(path string, h header, items map[string]bool) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	zw := gzip.NewWriter(f)
+	w := bufio.NewWriter(zw)
+	data, err := json.Marshal(h)
+	if err != nil {
+		return err
+	}
+	w.Write(data)
+	w.WriteByte('\n')
+	for p, dir := range items {
+		if strings.ContainsAny(p, "\n\r") {
+			continue
+		}
+		if dir {
+			w.WriteString("d\t")
+		} else {
+			w.WriteString("f\t")
+		}
+		w.WriteString(p)
+		w.WriteByte('\n')
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	if err := zw.Close(); err != nil {
+		return err
+	}
+	return f.Close()
+}
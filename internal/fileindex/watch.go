@@ -0,0 +1,264 @@
+package fileindex
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// watchAll watches every crawled directory, up to MaxWatches
+func (x *Index) watchAll(w *fsnotify.Watcher) {
+	x.mu.Lock()
+	dirs := make([]string, 0, len(x.dirs))
+	for dir := range x.dirs {
+		dirs = append(dirs, dir)
+	}
+	x.watching = true
+	x.mu.Unlock()
+
+	x.watch(w, dirs)
+}
+
+// watch adds watches for dirs. Once a directory could not be watched,
+// no further watches are added.
+func (x *Index) watch(w *fsnotify.Watcher, dirs []string) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	if !x.watching {
+		return
+	}
+	if len(dirs) > 0 && len(w.WatchList())+len(dirs) > x.opts.MaxWatches {
+		x.watching = false
+		x.err = fmt.Errorf("more than %d directories to watch", x.opts.MaxWatches)
+		return
+	}
+	for _, dir := range dirs {
+		if err := w.Add(dir); err != nil {
+			// Usually the system limit of watches, e.g. inotify's
+			// max_user_watches
+			x.watching = false
+			x.err = fmt.Errorf("watch %s: %w", dir, err)
+			return
+		}
+	}
+}
+
+// follow applies filesystem notifications until the index is closed.
+// Without a watcher, or when not every directory is watched, the roots
+// are crawled again periodically.
+func (x *Index) follow(w *fsnotify.Watcher) {
+	var events <-chan fsnotify.Event
+	var errs <-chan error
+	if w != nil {
+		events, errs = w.Events, w.Errors
+	}
+
+	save := time.NewTicker(saveInterval)
+	defer save.Stop()
+	rescan := time.NewTicker(rescanInterval)
+	defer rescan.Stop()
+
+	for {
+		select {
+		case <-x.stop:
+			return
+
+		case ev, ok := <-events:
+			if !ok {
+				events = nil
+				continue
+			}
+			x.apply(w, ev)
+
+		case err, ok := <-errs:
+			if !ok {
+				errs = nil
+				continue
+			}
+			if errors.Is(err, fsnotify.ErrEventOverflow) {
+				// Events were lost, only a new crawl can tell what changed
+				if !x.recrawl(w) {
+					return
+				}
+				continue
+			}
+			x.setErr(err)
+
+		case <-rescan.C:
+			x.mu.Lock()
+			watching := x.watching
+			x.mu.Unlock()
+			if !watching && !x.recrawl(w) {
+				return
+			}
+
+		case <-save.C:
+			x.mu.Lock()
+			dirty := x.dirty
+			x.mu.Unlock()
+			if dirty {
+				if err := x.Save(); err != nil {
+					x.setErr(err)
+				}
+			}
+		}
+	}
+}
+
+// recrawl crawls the roots again and renews the watches. It returns
+// false if the index was closed meanwhile.
+func (x *Index) recrawl(w *fsnotify.Watcher) bool {
+	if !x.refresh() {
+		return false
+	}
+	if w != nil {
+		for _, dir := range w.WatchList() {
+			w.Remove(dir)
+		}
+		x.watchAll(w)
+	}
+	return true
+}
+
+// apply updates the index for one notification. Renames are reported as
+// the removal of the old path and the creation of the new one.
+func (x *Index) apply(w *fsnotify.Watcher, ev fsnotify.Event) {
+	path := filepath.Clean(ev.Name)
+	dir := filepath.Dir(path)
+
+	if filepath.Base(path) == IgnoreFile && !ev.Has(fsnotify.Chmod) {
+		x.rescan(w, dir)
+		return
+	}
+	switch {
+	case ev.Has(fsnotify.Remove), ev.Has(fsnotify.Rename):
+		x.remove(w, path)
+	case ev.Has(fsnotify.Create):
+		x.add(w, path)
+	}
+}
+
+// add indexes a new path and, for a directory, its contents
+func (x *Index) add(w *fsnotify.Watcher, path string) {
+	info, err := os.Lstat(path)
+	if err != nil {
+		return
+	}
+	isDir := info.IsDir()
+
+	x.mu.Lock()
+	rs, ok := x.dirs[filepath.Dir(path)]
+	known, exists := x.items[path]
+	room := x.opts.MaxItems - len(x.items)
+	if !ok || (exists && known == isDir) || rs.ignored(path, isDir) {
+		x.mu.Unlock()
+		return
+	}
+	if room <= 0 {
+		x.truncated = true
+		x.mu.Unlock()
+		return
+	}
+	x.items[path] = isDir
+	x.changed()
+	x.mu.Unlock()
+
+	if isDir && !isBundle(path) {
+		x.crawlInto(w, path, rs, room-1)
+	}
+}
+
+// crawlInto crawls dir, whose parent directory has the rules parent,
+// merges the result and watches the new directories
+func (x *Index) crawlInto(w *fsnotify.Watcher, dir string, parent *ruleSet, room int) {
+	c := x.newCrawler(room)
+	c.walk(dir, parent)
+
+	x.mu.Lock()
+	for path, isDir := range c.items {
+		x.items[path] = isDir
+	}
+	for d, rs := range c.dirs {
+		x.dirs[d] = rs
+	}
+	x.truncated = x.truncated || c.truncated
+	x.changed()
+	x.mu.Unlock()
+
+	dirs := make([]string, 0, len(c.dirs))
+	for d := range c.dirs {
+		dirs = append(dirs, d)
+	}
+	x.watch(w, dirs)
+}
+
+// remove drops path and everything below it. Watches of moved
+// directories would report the old paths, so they are removed.
+func (x *Index) remove(w *fsnotify.Watcher, path string) {
+	x.mu.Lock()
+	isDir, ok := x.items[path]
+	if !ok {
+		x.mu.Unlock()
+		return
+	}
+	delete(x.items, path)
+	var unwatch []string
+	if isDir {
+		prefix := path + string(filepath.Separator)
+		for p := range x.items {
+			if strings.HasPrefix(p, prefix) {
+				delete(x.items, p)
+			}
+		}
+		for d := range x.dirs {
+			if d == path || strings.HasPrefix(d, prefix) {
+				delete(x.dirs, d)
+				unwatch = append(unwatch, d)
+			}
+		}
+	}
+	x.changed()
+	x.mu.Unlock()
+
+	for _, d := range unwatch {
+		// Removed directories are no longer watched anyway
+		w.Remove(d)
+	}
+}
+
+// rescan crawls dir again after its ignore file changed
+func (x *Index) rescan(w *fsnotify.Watcher, dir string) {
+	x.mu.Lock()
+	parent, ok := x.rootRules[dir]
+	if !ok {
+		parent, ok = x.dirs[filepath.Dir(dir)]
+	}
+	_, crawled := x.dirs[dir]
+	x.mu.Unlock()
+	if !ok || !crawled {
+		return
+	}
+
+	prefix := dir + string(filepath.Separator)
+	x.mu.Lock()
+	for p := range x.items {
+		if strings.HasPrefix(p, prefix) {
+			delete(x.items, p)
+		}
+	}
+	for d := range x.dirs {
+		if d == dir || strings.HasPrefix(d, prefix) {
+			delete(x.dirs, d)
+		}
+	}
+	room := x.opts.MaxItems - len(x.items)
+	x.mu.Unlock()
+
+	x.crawlInto(w, dir, parent, room)
+}
@@ -0,0 +1,74 @@
+package focus
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+)
+
+// ErrUnsupported is returned where windows of other applications cannot
+// be listed or activated, e.g. on Wayland
+var ErrUnsupported = errors.New("window lookup not supported")
+
+// Window is a top-level window of another application
+type Window struct {
+	ID    uintptr
+	PID   int
+	Title string
+	Class string
+	// Exe is the full path of the process owning the window, if known
+	Exe string
+}
+
+// Match selects the windows of a running application by executable or
+// window class. Empty fields are ignored; an empty Match matches nothing.
+type Match struct {
+	// Exe is an executable name or path. Names match the base name of
+	// the window's executable without extension, paths the full path.
+	Exe string
+	// Class is the window class, e.g. "Chrome_WidgetWin_1" or "code"
+	Class string
+}
+
+// Matches reports whether w belongs to the application described by m
+func (m Match) Matches(w Window) bool {
+	if m.Exe == "" && m.Class == "" {
+		return false
+	}
+	if m.Class != "" && !strings.EqualFold(m.Class, w.Class) {
+		return false
+	}
+	if m.Exe == "" {
+		return true
+	}
+	if w.Exe == "" {
+		return false
+	}
+
+	if filepath.IsAbs(m.Exe) {
+		return samePath(m.Exe, w.Exe)
+	}
+	return strings.EqualFold(exeName(m.Exe), exeName(w.Exe))
+}
+
+// exeName returns the base name of an executable without extension
+func exeName(path string) string {
+	base := filepath.Base(strings.ReplaceAll(path, `\`, "/"))
+	return strings.TrimSuffix(base, filepath.Ext(base))
+}
+
+// FocusExisting activates the first window matching m. It reports false
+// if no window matches; ErrUnsupported means the caller should launch
+// the application as usual.
+func FocusExisting(m Match) (bool, error) {
+	windows, err := listWindows()
+	if err != nil {
+		return false, err
+	}
+	for _, w := range windows {
+		if m.Matches(w) {
+			return true, activate(w)
+		}
+	}
+	return false, nil
+}
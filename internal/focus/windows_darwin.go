@@ -0,0 +1,24 @@
+//go:build darwin
+
+package focus
+
+import "strings"
+
+// Windows of other applications are not looked up on macOS. Launch
+// Services already brings a running application to the front instead of
+// starting it again.
+
+// listWindows is not supported on macOS
+func listWindows() ([]Window, error) {
+	return nil, ErrUnsupported
+}
+
+// activate is not supported on macOS
+func activate(w Window) error {
+	return ErrUnsupported
+}
+
+// samePath compares paths case-insensitively like the default file system
+func samePath(a, b string) bool {
+	return strings.EqualFold(a, b)
+}
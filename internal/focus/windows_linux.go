@@ -0,0 +1,132 @@
+//go:build linux
+
+package focus
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// Windows are looked up through EWMH with xprop and activated with
+// wmctrl or xdotool, so no X11 libraries are needed. Without an X display
+// (plain Wayland) or these tools, lookups return ErrUnsupported.
+
+// output runs a command and returns its standard output
+var output = func(name string, args ...string) ([]byte, error) {
+	return exec.Command(name, args...).Output()
+}
+
+// listWindows returns the client windows the window manager reports in
+// _NET_CLIENT_LIST
+func listWindows() ([]Window, error) {
+	if os.Getenv("DISPLAY") == "" {
+		return nil, ErrUnsupported
+	}
+	if _, err := exec.LookPath("xprop"); err != nil {
+		return nil, ErrUnsupported
+	}
+
+	out, err := output("xprop", "-root", "-notype", "_NET_CLIENT_LIST")
+	if err != nil {
+		return nil, fmt.Errorf("list windows: %w", err)
+	}
+
+	ourPID := os.Getpid()
+	var windows []Window
+	for _, id := range parseClientList(string(out)) {
+		out, err := output("xprop", "-id", fmt.Sprintf("0x%x", id), "-notype", "_NET_WM_PID", "WM_CLASS", "_NET_WM_NAME")
+		if err != nil {
+			// Windows may close while we look at them
+			continue
+		}
+		w := parseWindow(string(out))
+		if w.PID == ourPID {
+			continue
+		}
+		w.ID = id
+		if w.PID > 0 {
+			w.Exe, _ = os.Readlink(fmt.Sprintf("/proc/%d/exe", w.PID))
+		}
+		windows = append(windows, w)
+	}
+	return windows, nil
+}
+
+// parseClientList parses "_NET_CLIENT_LIST: window id # 0x1e00007, 0x2200003"
+func parseClientList(out string) []uintptr {
+	_, list, ok := strings.Cut(out, "#")
+	if !ok {
+		return nil
+	}
+
+	var ids []uintptr
+	for _, field := range strings.Split(list, ",") {
+		id, err := strconv.ParseUint(strings.TrimSpace(field), 0, 64)
+		if err == nil {
+			ids = append(ids, uintptr(id))
+		}
+	}
+	return ids
+}
+
+// parseWindow parses the xprop properties of a single window
+func parseWindow(out string) Window {
+	var w Window
+	for _, line := range strings.Split(out, "\n") {
+		name, value, ok := strings.Cut(line, " = ")
+		if !ok {
+			continue
+		}
+		switch strings.TrimSpace(name) {
+		case "_NET_WM_PID":
+			w.PID, _ = strconv.Atoi(strings.TrimSpace(value))
+		case "WM_CLASS":
+			// Instance name first, then the class
+			if values := parseStrings(value); len(values) > 0 {
+				w.Class = values[len(values)-1]
+			}
+		case "_NET_WM_NAME":
+			if values := parseStrings(value); len(values) > 0 {
+				w.Title = values[0]
+			}
+		}
+	}
+	return w
+}
+
+// parseStrings parses a comma-separated list of quoted xprop strings
+func parseStrings(value string) []string {
+	var values []string
+	for {
+		value = strings.TrimLeft(value, " ,")
+		quoted, err := strconv.QuotedPrefix(value)
+		if err != nil {
+			return values
+		}
+		s, _ := strconv.Unquote(quoted)
+		values = append(values, s)
+		value = value[len(quoted):]
+	}
+}
+
+// activate asks the window manager to raise and focus a window
+func activate(w Window) error {
+	id := fmt.Sprintf("0x%x", w.ID)
+	if _, err := exec.LookPath("wmctrl"); err == nil {
+		_, err := output("wmctrl", "-i", "-a", id)
+		return err
+	}
+	if _, err := exec.LookPath("xdotool"); err == nil {
+		_, err := output("xdotool", "windowactivate", id)
+		return err
+	}
+	return ErrUnsupported
+}
+
+// samePath compares paths case-sensitively
+func samePath(a, b string) bool {
+	return a == b
+}
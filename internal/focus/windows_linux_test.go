@@ -0,0 +1,23 @@
+//go:build linux
+
+package focus
+
+import "testing"
+
+func TestParseClientList(t *testing.T) {
+	ids := parseClientList("_NET_CLIENT_LIST: window id # 0x1e00007, 0x2200003\n")
+	if len(ids) != 2 || ids[0] != 0x1e00007 || ids[1] != 0x2200003 {
+		t.Errorf("ids = %#x", ids)
+	}
+	if ids := parseClientList("_NET_CLIENT_LIST:  not found.\n"); len(ids) != 0 {
+		t.Errorf("expected no ids, got %#x", ids)
+	}
+}
+
+func TestParseWindow(t *testing.T) {
+	out := "_NET_WM_PID = 4242\nWM_CLASS = \"code\", \"Code\"\n_NET_WM_NAME = \"main.go - \\\"quicklaunch\\\" - Visual Studio Code\"\n"
+	w := parseWindow(out)
+	if w.PID != 4242 || w.Class != "Code" || w.Title != `main.go - "quicklaunch" - Visual Studio Code` {
+		t.Errorf("parsed %+v", w)
+	}
+}
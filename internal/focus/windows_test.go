@@ -0,0 +1,31 @@
+package focus
+
+import "testing"
+
+func TestMatch(t *testing.T) {
+	code := Window{Exe: "/usr/share/code/code", Class: "Code"}
+	chrome := Window{Exe: `C:\Program Files\Google\Chrome\Application\chrome.exe`, Class: "Chrome_WidgetWin_1"}
+
+	tests := []struct {
+		name  string
+		match Match
+		win   Window
+		want  bool
+	}{
+		{"name", Match{Exe: "code"}, code, true},
+		{"name with extension", Match{Exe: "CHROME.EXE"}, chrome, true},
+		{"name without extension", Match{Exe: "chrome"}, chrome, true},
+		{"other name", Match{Exe: "firefox"}, code, false},
+		{"full path", Match{Exe: "/usr/share/code/code"}, code, true},
+		{"other path", Match{Exe: "/opt/code/code"}, code, false},
+		{"class", Match{Class: "code"}, code, true},
+		{"exe and class", Match{Exe: "chrome", Class: "Code"}, chrome, false},
+		{"unknown exe", Match{Exe: "code"}, Window{Class: "Code"}, false},
+		{"empty", Match{}, code, false},
+	}
+	for _, tt := range tests {
+		if got := tt.match.Matches(tt.win); got != tt.want {
+			t.Errorf("%s: Matches = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
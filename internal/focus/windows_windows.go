@@ -0,0 +1,124 @@
+//go:build windows
+
+package focus
+
+import (
+	"os"
+	"strings"
+	"sync"
+	"syscall"
+	"unsafe"
+)
+
+var (
+	procGetClassNameW              = user32.NewProc("GetClassNameW")
+	procGetWindowTextW             = user32.NewProc("GetWindowTextW")
+	procGetWindow                  = user32.NewProc("GetWindow")
+	procIsIconic                   = user32.NewProc("IsIconic")
+	procOpenProcess                = kernel32.NewProc("OpenProcess")
+	procQueryFullProcessImageNameW = kernel32.NewProc("QueryFullProcessImageNameW")
+	procCloseHandle                = kernel32.NewProc("CloseHandle")
+)
+
+const (
+	GW_OWNER                          = 4
+	PROCESS_QUERY_LIMITED_INFORMATION = 0x1000
+)
+
+var (
+	// enumMu guards enumWindows; the callback is created once because
+	// Windows limits the number of callbacks a process may create
+	enumMu      sync.Mutex
+	enumWindows []uintptr
+	enumProc    = syscall.NewCallback(func(hwnd uintptr, lparam uintptr) uintptr {
+		enumWindows = append(enumWindows, hwnd)
+		return 1 // Continue enumeration
+	})
+)
+
+// listWindows returns the visible, unowned top-level windows of other
+// processes in z-order
+func listWindows() ([]Window, error) {
+	enumMu.Lock()
+	enumWindows = nil
+	procEnumWindows.Call(enumProc, 0)
+	handles := enumWindows
+	enumMu.Unlock()
+
+	ourPID := os.Getpid()
+	exes := map[int]string{}
+
+	var windows []Window
+	for _, hwnd := range handles {
+		if visible, _, _ := procIsWindowVisible.Call(hwnd); visible == 0 {
+			continue
+		}
+		if owner, _, _ := procGetWindow.Call(hwnd, GW_OWNER); owner != 0 {
+			continue
+		}
+
+		var pid uint32
+		procGetWindowThreadProcessId.Call(hwnd, uintptr(unsafe.Pointer(&pid)))
+		if int(pid) == ourPID {
+			continue
+		}
+
+		exe, ok := exes[int(pid)]
+		if !ok {
+			exe = processImage(pid)
+			exes[int(pid)] = exe
+		}
+
+		windows = append(windows, Window{
+			ID:    hwnd,
+			PID:   int(pid),
+			Title: windowString(procGetWindowTextW, hwnd),
+			Class: windowString(procGetClassNameW, hwnd),
+			Exe:   exe,
+		})
+	}
+	return windows, nil
+}
+
+// windowString reads a window text or class name with proc
+func windowString(proc *syscall.LazyProc, hwnd uintptr) string {
+	buf := make([]uint16, 256)
+	n, _, _ := proc.Call(hwnd, uintptr(unsafe.Pointer(&buf[0])), uintptr(len(buf)))
+	return syscall.UTF16ToString(buf[:n])
+}
+
+// processImage returns the executable path of a process, or "" if the
+// process cannot be queried (e.g. an elevated process)
+func processImage(pid uint32) string {
+	handle, _, _ := procOpenProcess.Call(PROCESS_QUERY_LIMITED_INFORMATION, 0, uintptr(pid))
+	if handle == 0 {
+		return ""
+	}
+	defer procCloseHandle.Call(handle)
+
+	buf := make([]uint16, syscall.MAX_LONG_PATH)
+	size := uint32(len(buf))
+	ok, _, _ := procQueryFullProcessImageNameW.Call(handle, 0, uintptr(unsafe.Pointer(&buf[0])), uintptr(unsafe.Pointer(&size)))
+	if ok == 0 {
+		return ""
+	}
+	return syscall.UTF16ToString(buf[:size])
+}
+
+// activate restores a minimized window and brings it to the foreground
+func activate(w Window) error {
+	simulateAltKeyPress()
+	procAllowSetForegroundWindow.Call(ASFW_ANY)
+
+	if iconic, _, _ := procIsIconic.Call(w.ID); iconic != 0 {
+		procShowWindow.Call(w.ID, SW_RESTORE)
+	}
+	procBringWindowToTop.Call(w.ID)
+	procSetForegroundWindow.Call(w.ID)
+	return nil
+}
+
+// samePath compares paths case-insensitively like the file system does
+func samePath(a, b string) bool {
+	return strings.EqualFold(a, b)
+}
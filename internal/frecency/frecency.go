@@ -0,0 +1,152 @@
+package frecency
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// FileName is the name of the store file in the config directory
+	FileName = "frecency.json"
+	// DefaultHalfLife is the time after which a use counts half
+	DefaultHalfLife = 7 * 24 * time.Hour
+)
+
+// minScore is the score below which records are dropped on save
+const minScore = 0.01
+
+// record is the decayed score of a key as of Updated
+type record struct {
+	Score   float64   `json:"score"`
+	Count   int       `json:"count"`
+	Updated time.Time `json:"updated"`
+}
+
+// Store tracks how often and how recently keys were used. Every use adds
+// one to a score that halves every half-life, so frequent and recent uses
+// both rank high. Since all scores decay alike, their order only changes
+// when a key is used.
+type Store struct {
+	mu       sync.Mutex
+	path     string
+	halfLife time.Duration
+	records  map[string]record
+	now      func() time.Time
+}
+
+// Load reads the store from dir. A missing file yields an empty store; an
+// empty dir keeps the store in memory only. The returned store is usable
+// even if reading failed.
+func Load(dir string, halfLife time.Duration) (*Store, error) {
+	if halfLife <= 0 {
+		halfLife = DefaultHalfLife
+	}
+	s := &Store{
+		halfLife: halfLife,
+		records:  make(map[string]record),
+		now:      time.Now,
+	}
+	if dir == "" {
+		return s, nil
+	}
+	s.path = filepath.Join(dir, FileName)
+
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return s, err
+	}
+	if err := json.Unmarshal(data, &s.records); err != nil {
+		s.records = make(map[string]record)
+		return s, err
+	}
+	return s, nil
+}
+
+// decayed returns the score of r at t
+func (s *Store) decayed(r record, t time.Time) float64 {
+	age := t.Sub(r.Updated)
+	if age <= 0 {
+		return r.Score
+	}
+	return r.Score * math.Exp2(-float64(age)/float64(s.halfLife))
+}
+
+// Touch records a use of key
+func (s *Store) Touch(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	r := s.records[key]
+	s.records[key] = record{
+		Score:   s.decayed(r, now) + 1,
+		Count:   r.Count + 1,
+		Updated: now,
+	}
+}
+
+// Score returns the current score of key, 0 for unknown keys
+func (s *Store) Score(key string) float64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r, ok := s.records[key]
+	if !ok {
+		return 0
+	}
+	return s.decayed(r, s.now())
+}
+
+// Delete removes key
+func (s *Store) Delete(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.records, key)
+}
+
+// Forget removes all keys starting with prefix
+func (s *Store) Forget(prefix string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for key := range s.records {
+		if strings.HasPrefix(key, prefix) {
+			delete(s.records, key)
+		}
+	}
+}
+
+// Save writes the store, dropping keys whose score has decayed to nearly
+// nothing
+func (s *Store) Save() error {
+	s.mu.Lock()
+	now := s.now()
+	for key, r := range s.records {
+		if s.decayed(r, now) < minScore {
+			delete(s.records, key)
+		}
+	}
+	data, err := json.MarshalIndent(s.records, "", "  ")
+	s.mu.Unlock()
+
+	if err != nil || s.path == "" {
+		return err
+	}
+
+	// Write to a temporary file first so a crash cannot leave half a file
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.path)
+}
@@ -0,0 +1,90 @@
+package frecency
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// clock is a settable time source
+type clock struct{ t time.Time }
+
+func (c *clock) now() time.Time { return c.t }
+
+func TestScoreDecays(t *testing.T) {
+	s, _ := Load("", time.Hour)
+	c := &clock{t: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)}
+	s.now = c.now
+
+	s.Touch("a")
+	s.Touch("a")
+	if got := s.Score("a"); got != 2 {
+		t.Errorf("Score = %v, want 2", got)
+	}
+
+	c.t = c.t.Add(time.Hour)
+	if got := s.Score("a"); math.Abs(got-1) > 1e-9 {
+		t.Errorf("Score after one half-life = %v, want 1", got)
+	}
+
+	// One recent use beats two old ones
+	s.Touch("b")
+	s.Touch("b")
+	c.t = c.t.Add(10 * time.Minute)
+	s.Touch("c")
+	if s.Score("b") <= s.Score("a") || s.Score("a") >= s.Score("c") {
+		t.Errorf("a=%v b=%v c=%v", s.Score("a"), s.Score("b"), s.Score("c"))
+	}
+	if s.Score("unknown") != 0 {
+		t.Error("unknown keys should score 0")
+	}
+}
+
+func TestDelete(t *testing.T) {
+	s, _ := Load("", time.Hour)
+	s.Touch("tile:a")
+	s.Touch("tile:ab")
+
+	// Tile IDs have no terminator, so only the exact key may go
+	s.Delete("tile:a")
+	if s.Score("tile:a") != 0 {
+		t.Error("the deleted key should score 0")
+	}
+	if s.Score("tile:ab") == 0 {
+		t.Error("keys sharing the prefix should keep their score")
+	}
+}
+
+func TestSaveAndLoad(t *testing.T) {
+	dir := t.TempDir()
+	s, err := Load(dir, time.Hour)
+	if err != nil {
+		t.Fatalf("Load of a missing file returned %v", err)
+	}
+	c := &clock{t: time.Now()}
+	s.now = c.now
+
+	s.Touch("tile:old")
+	c.t = c.t.Add(10 * time.Hour)
+	s.Touch("tile:new")
+	s.Touch("path:new:/tmp")
+	s.Forget("path:new:")
+	if err := s.Save(); err != nil {
+		t.Fatalf("Save returned %v", err)
+	}
+
+	loaded, err := Load(dir, time.Hour)
+	if err != nil {
+		t.Fatalf("Load returned %v", err)
+	}
+	loaded.now = c.now
+	if got := loaded.Score("tile:new"); got != 1 {
+		t.Errorf("Score(tile:new) = %v, want 1", got)
+	}
+	if _, ok := loaded.records["tile:old"]; ok {
+		t.Error("decayed records should be dropped on save")
+	}
+	if _, ok := loaded.records["path:new:/tmp"]; ok {
+		t.Error("forgotten records should not be saved")
+	}
+}
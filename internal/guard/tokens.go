@@ -0,0 +1,92 @@
+package guard
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrConfirmationRequired is returned when a guarded tile is executed
+	// without a confirmation token
+	ErrConfirmationRequired = errors.New("confirmation required")
+	// ErrInvalidToken is returned for unknown, used, expired or foreign
+	// confirmation tokens
+	ErrInvalidToken = errors.New("invalid or expired confirmation token")
+)
+
+// DefaultTokenTTL is how long a confirmation token stays valid
+const DefaultTokenTTL = time.Minute
+
+// token is an issued confirmation token
+type token struct {
+	subject string
+	expires time.Time
+}
+
+// Tokens issues single-use confirmation tokens bound to a subject such as
+// a tile ID
+type Tokens struct {
+	mu     sync.Mutex
+	tokens map[string]token
+	ttl    time.Duration
+	now    func() time.Time
+}
+
+// NewTokens creates a token store whose tokens expire after ttl
+func NewTokens(ttl time.Duration) *Tokens {
+	if ttl <= 0 {
+		ttl = DefaultTokenTTL
+	}
+	return &Tokens{
+		tokens: make(map[string]token),
+		ttl:    ttl,
+		now:    time.Now,
+	}
+}
+
+// Issue creates a new token for subject
+func (t *Tokens) Issue(subject string) (string, time.Time, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", time.Time{}, err
+	}
+	value := hex.EncodeToString(b)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := t.now()
+	for v, tok := range t.tokens {
+		if now.After(tok.expires) {
+			delete(t.tokens, v)
+		}
+	}
+
+	expires := now.Add(t.ttl)
+	t.tokens[value] = token{subject: subject, expires: expires}
+	return value, expires, nil
+}
+
+// Consume checks a token for subject and invalidates it. An empty token
+// returns ErrConfirmationRequired.
+func (t *Tokens) Consume(value, subject string) error {
+	if value == "" {
+		return ErrConfirmationRequired
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	tok, ok := t.tokens[value]
+	if !ok || tok.subject != subject {
+		return ErrInvalidToken
+	}
+	delete(t.tokens, value)
+	if t.now().After(tok.expires) {
+		return ErrInvalidToken
+	}
+	return nil
+}
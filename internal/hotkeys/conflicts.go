@@ -0,0 +1,63 @@
+package hotkeys
+
+import "fmt"
+
+// Assignment binds a hotkey string to an owner such as a tile ID
+type Assignment struct {
+	Owner  string
+	Hotkey string
+}
+
+// Resolved is an assignment whose hotkey parsed and is not taken
+type Resolved struct {
+	Owner string
+	Combo Combo
+}
+
+// Conflict describes a hotkey that could not be assigned to its owner
+type Conflict struct {
+	Owner  string `json:"owner"`
+	Hotkey string `json:"hotkey"`
+	Reason string `json:"reason"`
+}
+
+// Resolve parses all assignments and filters out invalid and duplicate
+// hotkeys. Reserved combos (e.g. the panel hotkey) always win; among the
+// assignments the first one wins. Empty hotkeys are skipped silently.
+func Resolve(reserved map[string]string, assignments []Assignment) ([]Resolved, []Conflict) {
+	taken := make(map[Combo]string)
+	for owner, s := range reserved {
+		if combo, err := Parse(s); err == nil {
+			taken[combo] = owner
+		}
+	}
+
+	var resolved []Resolved
+	var conflicts []Conflict
+
+	for _, as := range assignments {
+		if as.Hotkey == "" {
+			continue
+		}
+
+		combo, err := Parse(as.Hotkey)
+		if err != nil {
+			conflicts = append(conflicts, Conflict{Owner: as.Owner, Hotkey: as.Hotkey, Reason: err.Error()})
+			continue
+		}
+
+		if other, ok := taken[combo]; ok {
+			conflicts = append(conflicts, Conflict{
+				Owner:  as.Owner,
+				Hotkey: combo.String(),
+				Reason: fmt.Sprintf("%s is already used by %s", combo, other),
+			})
+			continue
+		}
+
+		taken[combo] = as.Owner
+		resolved = append(resolved, Resolved{Owner: as.Owner, Combo: combo})
+	}
+
+	return resolved, conflicts
+}
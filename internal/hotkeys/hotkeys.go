@@ -0,0 +1,177 @@
+package hotkeys
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Modifier is a bit set of modifier keys
+type Modifier uint8
+
+const (
+	ModCtrl Modifier = 1 << iota
+	ModAlt
+	ModShift
+	ModSuper
+)
+
+// modifierOrder defines the canonical order used by Combo.String
+var modifierOrder = []struct {
+	mod  Modifier
+	name string
+}{
+	{ModCtrl, "Ctrl"},
+	{ModAlt, "Alt"},
+	{ModShift, "Shift"},
+	{ModSuper, "Super"},
+}
+
+// modifierAliases maps lower-case modifier names to modifiers
+var modifierAliases = map[string]Modifier{
+	"ctrl":    ModCtrl,
+	"control": ModCtrl,
+	"strg":    ModCtrl,
+	"alt":     ModAlt,
+	"option":  ModAlt,
+	"opt":     ModAlt,
+	"shift":   ModShift,
+	"super":   ModSuper,
+	"win":     ModSuper,
+	"windows": ModSuper,
+	"meta":    ModSuper,
+	"cmd":     ModSuper,
+	"command": ModSuper,
+}
+
+// keyAliases maps lower-case key names to their canonical name
+var keyAliases = map[string]string{
+	"space":     "Space",
+	"enter":     "Enter",
+	"return":    "Enter",
+	"esc":       "Escape",
+	"escape":    "Escape",
+	"tab":       "Tab",
+	"del":       "Delete",
+	"delete":    "Delete",
+	"left":      "Left",
+	"right":     "Right",
+	"up":        "Up",
+	"down":      "Down",
+	"leertaste": "Space",
+	"entf":      "Delete",
+}
+
+func init() {
+	for c := 'a'; c <= 'z'; c++ {
+		keyAliases[string(c)] = strings.ToUpper(string(c))
+	}
+	for c := '0'; c <= '9'; c++ {
+		keyAliases[string(c)] = string(c)
+	}
+	for i := 1; i <= 20; i++ {
+		name := fmt.Sprintf("F%d", i)
+		keyAliases[strings.ToLower(name)] = name
+	}
+}
+
+var (
+	// ErrEmpty is returned when the hotkey string is empty
+	ErrEmpty = errors.New("hotkey is empty")
+	// ErrUnknownModifier is returned for modifiers that are not supported
+	ErrUnknownModifier = errors.New("unknown modifier")
+	// ErrUnknownKey is returned for keys that are not supported
+	ErrUnknownKey = errors.New("unknown key")
+	// ErrDuplicateModifier is returned when a modifier is given twice
+	ErrDuplicateModifier = errors.New("duplicate modifier")
+	// ErrMissingKey is returned when the hotkey consists of modifiers only
+	ErrMissingKey = errors.New("missing key")
+	// ErrMissingModifier is returned when a non function key has no modifier
+	ErrMissingModifier = errors.New("missing modifier")
+)
+
+// Combo is a parsed hotkey combination such as Ctrl+Alt+K
+type Combo struct {
+	Modifiers Modifier `json:"modifiers"`
+	Key       string   `json:"key"`
+}
+
+// Has reports whether the combo contains the given modifier
+func (c Combo) Has(mod Modifier) bool {
+	return c.Modifiers&mod != 0
+}
+
+// String returns the canonical representation, e.g. "Ctrl+Shift+Space"
+func (c Combo) String() string {
+	parts := make([]string, 0, len(modifierOrder)+1)
+	for _, m := range modifierOrder {
+		if c.Has(m.mod) {
+			parts = append(parts, m.name)
+		}
+	}
+	return strings.Join(append(parts, c.Key), "+")
+}
+
+// IsFunctionKey reports whether the key is one of F1-F20
+func (c Combo) IsFunctionKey() bool {
+	return len(c.Key) > 1 && c.Key[0] == 'F' && c.Key[1] >= '0' && c.Key[1] <= '9'
+}
+
+// Parse parses a hotkey string like "Ctrl+Alt+K" or "Super+Shift+Space".
+// Names are case-insensitive; the last element is the key, all others
+// must be modifiers. Function keys may be used without a modifier.
+func Parse(s string) (Combo, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return Combo{}, ErrEmpty
+	}
+
+	parts := strings.Split(s, "+")
+	var combo Combo
+
+	for i, part := range parts {
+		name := strings.ToLower(strings.TrimSpace(part))
+		if name == "" {
+			return Combo{}, fmt.Errorf("%w in %q", ErrMissingKey, s)
+		}
+
+		if i < len(parts)-1 {
+			mod, ok := modifierAliases[name]
+			if !ok {
+				if _, isKey := keyAliases[name]; isKey {
+					return Combo{}, fmt.Errorf("%w: %q is a key, only the last element may be a key", ErrUnknownModifier, strings.TrimSpace(part))
+				}
+				return Combo{}, fmt.Errorf("%w: %q", ErrUnknownModifier, strings.TrimSpace(part))
+			}
+			if combo.Has(mod) {
+				return Combo{}, fmt.Errorf("%w: %q", ErrDuplicateModifier, strings.TrimSpace(part))
+			}
+			combo.Modifiers |= mod
+			continue
+		}
+
+		key, ok := keyAliases[name]
+		if !ok {
+			if _, isMod := modifierAliases[name]; isMod {
+				return Combo{}, fmt.Errorf("%w in %q", ErrMissingKey, s)
+			}
+			return Combo{}, fmt.Errorf("%w: %q", ErrUnknownKey, strings.TrimSpace(part))
+		}
+		combo.Key = key
+	}
+
+	if combo.Modifiers == 0 && !combo.IsFunctionKey() {
+		return Combo{}, fmt.Errorf("%w: %q needs at least one of Ctrl, Alt, Shift or Super", ErrMissingModifier, s)
+	}
+
+	return combo, nil
+}
+
+// Normalize parses s and returns its canonical representation
+func Normalize(s string) (string, error) {
+	combo, err := Parse(s)
+	if err != nil {
+		return "", err
+	}
+	return combo.String(), nil
+}
@@ -0,0 +1,108 @@
+package hotkeys
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"Ctrl+Space", "Ctrl+Space"},
+		{"ctrl+alt+k", "Ctrl+Alt+K"},
+		{"Super+Shift+Space", "Shift+Super+Space"},
+		{" Alt + Shift + 1 ", "Alt+Shift+1"},
+		{"Cmd+Option+Return", "Alt+Super+Enter"},
+		{"Win+E", "Super+E"},
+		{"F13", "F13"},
+		{"Ctrl+F5", "Ctrl+F5"},
+		{"Control+Esc", "Ctrl+Escape"},
+	}
+
+	for _, tt := range tests {
+		combo, err := Parse(tt.input)
+		if err != nil {
+			t.Errorf("Parse(%q) returned error: %v", tt.input, err)
+			continue
+		}
+		if got := combo.String(); got != tt.want {
+			t.Errorf("Parse(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		input string
+		want  error
+	}{
+		{"", ErrEmpty},
+		{"   ", ErrEmpty},
+		{"Ctrl+Hyper+K", ErrUnknownModifier},
+		{"K+Ctrl", ErrUnknownModifier},
+		{"Ctrl+Pause", ErrUnknownKey},
+		{"Ctrl+Ctrl+K", ErrDuplicateModifier},
+		{"Ctrl+Strg+K", ErrDuplicateModifier},
+		{"Ctrl+Alt", ErrMissingKey},
+		{"Ctrl+", ErrMissingKey},
+		{"K", ErrMissingModifier},
+		{"Space", ErrMissingModifier},
+	}
+
+	for _, tt := range tests {
+		_, err := Parse(tt.input)
+		if !errors.Is(err, tt.want) {
+			t.Errorf("Parse(%q) error = %v, want %v", tt.input, err, tt.want)
+		}
+	}
+}
+
+func TestCombo(t *testing.T) {
+	combo, err := Parse("Ctrl+Shift+F1")
+	if err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+	if !combo.Has(ModCtrl) || !combo.Has(ModShift) || combo.Has(ModAlt) {
+		t.Errorf("unexpected modifiers %b", combo.Modifiers)
+	}
+	if !combo.IsFunctionKey() {
+		t.Error("F1 should be a function key")
+	}
+
+	if got, _ := Normalize("shift+ctrl+a"); got != "Ctrl+Shift+A" {
+		t.Errorf("Normalize = %q, want Ctrl+Shift+A", got)
+	}
+}
+
+func TestResolve(t *testing.T) {
+	reserved := map[string]string{"panel": "Ctrl+Space"}
+	assignments := []Assignment{
+		{Owner: "a", Hotkey: "Ctrl+Alt+1"},
+		{Owner: "b", Hotkey: ""},
+		{Owner: "c", Hotkey: "ctrl+space"},
+		{Owner: "d", Hotkey: "Alt+Ctrl+1"},
+		{Owner: "e", Hotkey: "Ctrl+Nope"},
+		{Owner: "f", Hotkey: "Super+T"},
+	}
+
+	resolved, conflicts := Resolve(reserved, assignments)
+
+	if len(resolved) != 2 || resolved[0].Owner != "a" || resolved[1].Owner != "f" {
+		t.Errorf("unexpected resolved assignments: %+v", resolved)
+	}
+
+	owners := make(map[string]bool)
+	for _, c := range conflicts {
+		owners[c.Owner] = true
+	}
+	for _, owner := range []string{"c", "d", "e"} {
+		if !owners[owner] {
+			t.Errorf("expected conflict for %q, got %+v", owner, conflicts)
+		}
+	}
+	if len(conflicts) != 3 {
+		t.Errorf("expected 3 conflicts, got %d", len(conflicts))
+	}
+}
@@ -0,0 +1,65 @@
+package keywords
+
+import (
+	"errors"
+	"strings"
+	"unicode"
+)
+
+// ErrInvalid is returned for keywords that are empty or contain spaces
+var ErrInvalid = errors.New("keyword must be a single word")
+
+// Keyword binds a search keyword to an owner such as a tile ID
+type Keyword struct {
+	Owner   string
+	Keyword string
+}
+
+// Match is the result of matching search bar input against keywords
+type Match struct {
+	Owner   string `json:"owner"`
+	Keyword string `json:"keyword"`
+	Query   string `json:"query"`
+}
+
+// Normalize trims and lower-cases a keyword and checks that it is a
+// single word
+func Normalize(keyword string) (string, error) {
+	keyword = strings.ToLower(strings.TrimSpace(keyword))
+	if keyword == "" || strings.IndexFunc(keyword, unicode.IsSpace) >= 0 {
+		return "", ErrInvalid
+	}
+	return keyword, nil
+}
+
+// Split splits input like "gh quicklaunch" into the first word and the
+// rest with surrounding whitespace removed
+func Split(input string) (keyword, query string) {
+	input = strings.TrimLeftFunc(input, unicode.IsSpace)
+	end := strings.IndexFunc(input, unicode.IsSpace)
+	if end < 0 {
+		return input, ""
+	}
+	return input[:end], strings.TrimSpace(input[end:])
+}
+
+// Find matches the first word of input case-insensitively against the
+// keywords. Invalid keywords are ignored and the first match wins. A
+// keyword typed without a query matches with an empty query.
+func Find(keywords []Keyword, input string) (Match, bool) {
+	word, query := Split(input)
+	if word == "" {
+		return Match{}, false
+	}
+
+	for _, k := range keywords {
+		kw, err := Normalize(k.Keyword)
+		if err != nil {
+			continue
+		}
+		if strings.EqualFold(word, kw) {
+			return Match{Owner: k.Owner, Keyword: kw, Query: query}, true
+		}
+	}
+	return Match{}, false
+}
@@ -0,0 +1,49 @@
+package keywords
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestFind(t *testing.T) {
+	keywords := []Keyword{
+		{Owner: "github", Keyword: "gh"},
+		{Owner: "broken", Keyword: "two words"},
+		{Owner: "jira", Keyword: " Jira "},
+		{Owner: "github2", Keyword: "GH"},
+	}
+
+	tests := []struct {
+		input string
+		owner string
+		query string
+		ok    bool
+	}{
+		{"gh quicklaunch", "github", "quicklaunch", true},
+		{"  GH   wails  v2 ", "github", "wails  v2", true},
+		{"gh", "github", "", true},
+		{"jira QL-42", "jira", "QL-42", true},
+		{"ghx quicklaunch", "", "", false},
+		{"two words", "", "", false},
+		{"", "", "", false},
+		{"quicklaunch", "", "", false},
+	}
+
+	for _, tt := range tests {
+		m, ok := Find(keywords, tt.input)
+		if ok != tt.ok || m.Owner != tt.owner || m.Query != tt.query {
+			t.Errorf("Find(%q) = %+v, %v; want owner %q query %q ok %v", tt.input, m, ok, tt.owner, tt.query, tt.ok)
+		}
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	if got, err := Normalize("  GH "); err != nil || got != "gh" {
+		t.Errorf("Normalize = %q, %v", got, err)
+	}
+	for _, kw := range []string{"", "  ", "a b", "a\tb"} {
+		if _, err := Normalize(kw); !errors.Is(err, ErrInvalid) {
+			t.Errorf("Normalize(%q) error = %v, want ErrInvalid", kw, err)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package launcher
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+var (
+	// ErrElevationCancelled is returned when the user dismissed the
+	// prompt for administrator rights
+	ErrElevationCancelled = errors.New("elevation was cancelled")
+	// ErrElevationUnsupported is returned when elevated launches are not
+	// possible on this system, e.g. because pkexec is not installed
+	ErrElevationUnsupported = errors.New("elevated launch not supported")
+)
+
+// Capabilities reports which optional launch features this system
+// supports, so the UI only offers what works
+type Capabilities struct {
+	ElevatedApps  bool `json:"elevatedApps"`
+	ElevatedShell bool `json:"elevatedShell"`
+}
+
+// Elevation is a command that runs its target with administrator rights
+type Elevation struct {
+	Cmd *exec.Cmd
+	// Prompt reports whether Cmd exits as soon as the elevation prompt
+	// was answered. The caller should wait for it and pass the result to
+	// Err. Otherwise the prompt appears in a terminal and Cmd is started
+	// like any other terminal command.
+	Prompt bool
+
+	stderr *bytes.Buffer
+}
+
+// newPromptElevation wraps a command that exits after the prompt and
+// captures its error output for Err
+func newPromptElevation(cmd *exec.Cmd) Elevation {
+	stderr := &bytes.Buffer{}
+	cmd.Stderr = stderr
+	return Elevation{Cmd: cmd, Prompt: true, stderr: stderr}
+}
+
+// Err maps the error of running Cmd. A dismissed prompt becomes
+// ErrElevationCancelled, other failures include the error output.
+func (el Elevation) Err(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var stderr string
+	if el.stderr != nil {
+		stderr = strings.TrimSpace(el.stderr.String())
+	}
+
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && elevationCancelled(exitErr.ExitCode(), stderr) {
+		return ErrElevationCancelled
+	}
+	if stderr != "" {
+		return fmt.Errorf("%w: %s", err, stderr)
+	}
+	return err
+}
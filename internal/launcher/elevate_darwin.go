@@ -0,0 +1,50 @@
+//go:build darwin
+
+package launcher
+
+import (
+	"os/exec"
+	"strings"
+)
+
+// Capabilities reports the optional features, all of which macOS has
+func (e *Executor) Capabilities() Capabilities {
+	return Capabilities{ElevatedApps: true, ElevatedShell: true}
+}
+
+// ElevatedAppCommand starts an application as root through osascript,
+// which shows the system password dialog. The command is started in the
+// background so osascript returns once the dialog was answered.
+func (e *Executor) ElevatedAppCommand(s Spec) (Elevation, error) {
+	var argv []string
+	if exe, err := e.lookPath(s.Target); err == nil && !strings.HasSuffix(s.Target, ".app") {
+		argv = append([]string{exe}, s.appArgs()...)
+	} else {
+		argv = []string{"open", "-a", s.Target}
+		if s.Path != "" {
+			argv = append(argv, s.Path)
+		}
+		if len(s.Args) > 0 {
+			argv = append(append(argv, "--args"), s.Args...)
+		}
+	}
+
+	quoted := make([]string, len(argv))
+	for i, arg := range argv {
+		quoted[i] = shellQuote(arg)
+	}
+	script := strings.Join(quoted, " ") + " </dev/null >/dev/null 2>&1 &"
+	if s.WorkDir != "" {
+		script = "cd -- " + shellQuote(absPath(s.WorkDir)) + " && " + script
+	}
+
+	cmd := exec.Command("osascript", "-e",
+		"do shell script "+appleScriptQuote(script)+" with administrator privileges")
+	return newPromptElevation(cmd), nil
+}
+
+// elevationCancelled reports whether osascript failed with AppleScript
+// error -128, "User canceled"
+func elevationCancelled(exitCode int, stderr string) bool {
+	return strings.Contains(stderr, "(-128)")
+}
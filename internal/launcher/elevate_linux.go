@@ -0,0 +1,70 @@
+//go:build linux
+
+package launcher
+
+import (
+	"fmt"
+	"os/exec"
+)
+
+// pkexecDismissed is the exit code of pkexec when the dialog was dismissed
+const pkexecDismissed = 126
+
+// pkexecLaunchScript changes into the directory given as $1 and starts
+// the remaining arguments in the background, so pkexec returns as soon
+// as the prompt was answered
+const pkexecLaunchScript = `cd -- "$1" || exit 1; shift; "$@" </dev/null >/dev/null 2>&1 &`
+
+// Capabilities reports whether pkexec, sudo and a terminal are available
+func (e *Executor) Capabilities() Capabilities {
+	_, pkexecErr := e.lookPath("pkexec")
+	_, sudoErr := e.lookPath("sudo")
+	_, termErr := e.terminal()
+	return Capabilities{
+		ElevatedApps:  pkexecErr == nil,
+		ElevatedShell: sudoErr == nil && termErr == nil,
+	}
+}
+
+// ElevatedAppCommand starts an executable as root via pkexec. pkexec
+// clears the environment, so the display variables are passed on.
+func (e *Executor) ElevatedAppCommand(s Spec) (Elevation, error) {
+	pkexec, err := e.lookPath("pkexec")
+	if err != nil {
+		return Elevation{}, ErrElevationUnsupported
+	}
+	exe, err := e.lookPath(s.Target)
+	if err != nil {
+		return Elevation{}, fmt.Errorf("%s is not an executable: %w", s.Target, err)
+	}
+	env, err := e.lookPath("env")
+	if err != nil {
+		return Elevation{}, err
+	}
+	sh, err := e.lookPath("sh")
+	if err != nil {
+		return Elevation{}, err
+	}
+
+	dir := absPath(s.WorkDir)
+	if s.WorkDir == "" {
+		dir = e.homeDir()
+	}
+
+	args := []string{env}
+	for _, key := range []string{"DISPLAY", "XAUTHORITY", "WAYLAND_DISPLAY", "XDG_RUNTIME_DIR"} {
+		if value := e.getenv(key); value != "" {
+			args = append(args, key+"="+value)
+		}
+	}
+	args = append(args, sh, "-c", pkexecLaunchScript, "sh", dir, exe)
+	args = append(args, s.appArgs()...)
+
+	return newPromptElevation(exec.Command(pkexec, args...)), nil
+}
+
+// elevationCancelled reports whether pkexec exited because the dialog
+// was dismissed
+func elevationCancelled(exitCode int, stderr string) bool {
+	return exitCode == pkexecDismissed
+}
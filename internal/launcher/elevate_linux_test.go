@@ -0,0 +1,91 @@
+//go:build linux
+
+package launcher
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLinuxElevatedAppCommand(t *testing.T) {
+	env := map[string]string{"DISPLAY": ":0", "HOME": "/home/me"}
+	e := fakeExecutor(Options{}, env, "pkexec", "env", "sh", "gparted")
+
+	el, err := e.ElevatedAppCommand(Spec{Target: "gparted", Args: []string{"/dev/sda"}})
+	if err != nil {
+		t.Fatalf("ElevatedAppCommand returned error: %v", err)
+	}
+	if !el.Prompt {
+		t.Error("pkexec exits after the prompt and should be waited for")
+	}
+	assertArgs(t, el.Cmd, nil, "/usr/bin/pkexec", "/usr/bin/env", "DISPLAY=:0",
+		"/usr/bin/sh", "-c", pkexecLaunchScript, "sh", "/home/me", "/usr/bin/gparted", "/dev/sda")
+
+	if _, err := fakeExecutor(Options{}, env, "gparted").ElevatedAppCommand(Spec{Target: "gparted"}); !errors.Is(err, ErrElevationUnsupported) {
+		t.Errorf("expected ErrElevationUnsupported without pkexec, got %v", err)
+	}
+	if _, err := e.ElevatedAppCommand(Spec{Target: "missing"}); err == nil {
+		t.Error("expected an error for targets that are not executables")
+	}
+}
+
+func TestLinuxElevatedShellCommand(t *testing.T) {
+	env := map[string]string{"SHELL": "/bin/bash"}
+	e := fakeExecutor(Options{Terminal: "xterm"}, env, "xterm", "sudo")
+
+	el, err := e.ElevatedShellCommand(Spec{Target: "apt upgrade"})
+	if err != nil {
+		t.Fatalf("ElevatedShellCommand returned error: %v", err)
+	}
+	if el.Prompt {
+		t.Error("sudo prompts inside the terminal, the terminal must not be waited for")
+	}
+	assertArgs(t, el.Cmd, nil, "/usr/bin/xterm", "-e", "sudo", "--", "/bin/bash", "-c", "apt upgrade\nexec '/bin/bash'")
+
+	caps := e.Capabilities()
+	if caps.ElevatedApps || !caps.ElevatedShell {
+		t.Errorf("unexpected capabilities %+v", caps)
+	}
+	if _, err := fakeExecutor(Options{Terminal: "xterm"}, env, "xterm").ElevatedShellCommand(Spec{Target: "ls"}); !errors.Is(err, ErrElevationUnsupported) {
+		t.Errorf("expected ErrElevationUnsupported without sudo, got %v", err)
+	}
+}
+
+func TestPkexecLaunchScript(t *testing.T) {
+	dir := t.TempDir()
+	cmd := exec.Command("sh", "-c", pkexecLaunchScript, "sh", dir, "sh", "-c", `pwd > "$0"`, dir+"/out")
+	if err := cmd.Run(); err != nil {
+		t.Fatalf("launch script failed: %v", err)
+	}
+
+	// The target runs in the background, in the given directory
+	for i := 0; i < 100; i++ {
+		if out, err := os.ReadFile(dir + "/out"); err == nil && strings.TrimSpace(string(out)) == dir {
+			return
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	t.Error("target did not run in the working directory")
+}
+
+func TestElevationErr(t *testing.T) {
+	run := func(code string) error {
+		el := newPromptElevation(exec.Command("sh", "-c", "echo denied >&2; exit "+code))
+		return el.Err(el.Cmd.Run())
+	}
+
+	if err := run("126"); !errors.Is(err, ErrElevationCancelled) {
+		t.Errorf("exit 126 should be a cancelled prompt, got %v", err)
+	}
+	err := run("1")
+	if errors.Is(err, ErrElevationCancelled) || err == nil || err.Error() != "exit status 1: denied" {
+		t.Errorf("other failures should keep the error output, got %v", err)
+	}
+	if err := (Elevation{}).Err(nil); err != nil {
+		t.Errorf("Err(nil) = %v", err)
+	}
+}
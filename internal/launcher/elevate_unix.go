@@ -0,0 +1,21 @@
+//go:build linux || darwin
+
+package launcher
+
+// elevationPrefix runs a shell invocation as root inside the terminal,
+// where sudo asks for the password itself
+var elevationPrefix = []string{"sudo", "--"}
+
+// ElevatedShellCommand runs a shell command as root in a terminal. sudo
+// prompts in the terminal, so a cancelled prompt is only visible there.
+func (e *Executor) ElevatedShellCommand(s Spec) (Elevation, error) {
+	if _, err := e.lookPath("sudo"); err != nil {
+		return Elevation{}, ErrElevationUnsupported
+	}
+	s.Elevated = true
+	cmd, err := e.ShellCommand(s)
+	if err != nil {
+		return Elevation{}, err
+	}
+	return Elevation{Cmd: cmd}, nil
+}
@@ -0,0 +1,81 @@
+//go:build windows
+
+package launcher
+
+import (
+	"os/exec"
+	"strings"
+	"syscall"
+)
+
+// errorCancelled is ERROR_CANCELLED, returned when the UAC prompt was
+// dismissed
+const errorCancelled = 1223
+
+// runAsScript starts a process with the "runas" verb. Win32 errors are
+// wrapped by PowerShell, so the script looks for ERROR_CANCELLED in the
+// exception chain and exits with it.
+const runAsScript = `$ErrorActionPreference = 'Stop'
+try { %s }
+catch {
+	$e = $_.Exception
+	while ($e -and -not $e.NativeErrorCode) { $e = $e.InnerException }
+	if ($e -and $e.NativeErrorCode -eq 1223) { exit 1223 }
+	[Console]::Error.WriteLine($_.Exception.Message)
+	exit 1
+}`
+
+// Capabilities reports the optional features, all of which Windows has
+func (e *Executor) Capabilities() Capabilities {
+	return Capabilities{ElevatedApps: true, ElevatedShell: true}
+}
+
+// ElevatedAppCommand starts an application with the runas verb, which
+// shows the UAC prompt
+func (e *Executor) ElevatedAppCommand(s Spec) (Elevation, error) {
+	target := s.Target
+	if exe, err := e.lookPath(s.Target); err == nil {
+		target = exe
+	}
+	return e.runAs(target, s.appArgs(), s.WorkDir), nil
+}
+
+// ElevatedShellCommand opens the shell or profile terminal of a shell
+// command with the runas verb
+func (e *Executor) ElevatedShellCommand(s Spec) (Elevation, error) {
+	cmd, err := e.ShellCommand(s)
+	if err != nil {
+		return Elevation{}, err
+	}
+	return e.runAs(cmd.Path, cmd.Args[1:], cmd.Dir), nil
+}
+
+// runAs builds the PowerShell command that starts file elevated. The
+// arguments are joined into one Windows command line because
+// Start-Process does not quote an argument list itself.
+func (e *Executor) runAs(file string, args []string, dir string) Elevation {
+	start := "Start-Process -Verb RunAs -FilePath " + psQuote(file)
+	if len(args) > 0 {
+		escaped := make([]string, len(args))
+		for i, arg := range args {
+			escaped[i] = syscall.EscapeArg(arg)
+		}
+		start += " -ArgumentList " + psQuote(strings.Join(escaped, " "))
+	}
+	if dir != "" {
+		start += " -WorkingDirectory " + psQuote(absPath(dir))
+	}
+
+	cmd := exec.Command("powershell", "-NoProfile", "-NonInteractive", "-Command",
+		strings.Replace(runAsScript, "%s", start, 1))
+	hideWindow(cmd)
+	return newPromptElevation(cmd)
+}
+
+// elevationCancelled reports whether the UAC prompt was dismissed
+func elevationCancelled(exitCode int, stderr string) bool {
+	return exitCode == errorCancelled
+}
+
+// elevationPrefix is empty, Windows elevates the whole process instead
+var elevationPrefix []string
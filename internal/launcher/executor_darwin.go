@@ -0,0 +1,112 @@
+//go:build darwin
+
+package launcher
+
+import (
+	"os/exec"
+	"strings"
+)
+
+// AppCommand launches an application. Executables (from PATH or a plain
+// path) are started directly; everything else is passed to "open -a" so
+// both "Safari" and "/Applications/Safari.app" work.
+func (e *Executor) AppCommand(s Spec) (*exec.Cmd, error) {
+	if !strings.HasSuffix(s.Target, ".app") {
+		if exe, err := e.lookPath(s.Target); err == nil {
+			cmd := exec.Command(exe, s.appArgs()...)
+			cmd.Dir = s.WorkDir
+			return cmd, nil
+		}
+	}
+
+	args := []string{"-a", s.Target}
+	if s.Path != "" {
+		args = append(args, s.Path)
+	}
+	if len(s.Args) > 0 {
+		args = append(append(args, "--args"), s.Args...)
+	}
+	cmd := exec.Command("open", args...)
+	cmd.Dir = s.WorkDir
+	return cmd, nil
+}
+
+// FolderCommand opens a folder in Finder
+func (e *Executor) FolderCommand(path string) (*exec.Cmd, error) {
+	return exec.Command("open", path), nil
+}
+
+// URLCommand opens a URL in the default browser
+func (e *Executor) URLCommand(url string) (*exec.Cmd, error) {
+	return exec.Command("open", url), nil
+}
+
+// ShellCommand runs a command in a terminal. Without a profile terminal
+// a new Terminal.app window is opened. Terminal.app starts the user's
+// login shell, so the script is quoted for that shell.
+func (e *Executor) ShellCommand(s Spec) (*exec.Cmd, error) {
+	if s.Profile.Terminal != "" {
+		return e.profileCommand(s)
+	}
+
+	login, err := e.shell(Profile{})
+	if err != nil {
+		return nil, err
+	}
+
+	var script []string
+	if dir := s.dir(); dir != "" {
+		cd, err := login.kind.ChangeDir(dir)
+		if err != nil {
+			return nil, err
+		}
+		script = append(script, cd)
+	}
+
+	if s.Profile.Shell != "" || s.Elevated {
+		// Run the command in the requested shell instead of the login
+		// shell, or in a root shell for elevated commands
+		sh, err := e.shell(s.Profile)
+		if err != nil {
+			return nil, err
+		}
+		invocation, err := sh.invocation(s)
+		if err != nil {
+			return nil, err
+		}
+		quoted := make([]string, len(invocation))
+		for i, arg := range invocation {
+			if quoted[i], err = login.kind.Quote(arg); err != nil {
+				return nil, err
+			}
+		}
+		script = append(script, "exec "+strings.Join(quoted, " "))
+	} else {
+		command, err := login.commandLine(s)
+		if err != nil {
+			return nil, err
+		}
+		if command != "" {
+			script = append(script, command)
+		}
+	}
+
+	return exec.Command("osascript",
+		"-e", `tell application "Terminal" to do script `+appleScriptQuote(strings.Join(script, " && ")),
+		"-e", `tell application "Terminal" to activate`,
+	), nil
+}
+
+// fallbackShell is used when $SHELL is unset or not supported
+const fallbackShell = "/bin/zsh"
+
+// defaultShell returns $SHELL, or the fallback shell if it is not set
+func (e *Executor) defaultShell() string {
+	if shell := e.getenv("SHELL"); shell != "" {
+		return shell
+	}
+	return fallbackShell
+}
+
+// hideWindow is a no-op, background commands never get a window here
+func hideWindow(cmd *exec.Cmd) {}
@@ -0,0 +1,42 @@
+//go:build darwin
+
+package launcher
+
+import "testing"
+
+func TestDarwinOpenCommands(t *testing.T) {
+	e := fakeExecutor(Options{}, nil)
+
+	cmd, err := e.FolderCommand("/Users/me/code")
+	assertArgs(t, cmd, err, "open", "/Users/me/code")
+
+	cmd, err = e.URLCommand("https://example.com")
+	assertArgs(t, cmd, err, "open", "https://example.com")
+}
+
+func TestDarwinAppCommand(t *testing.T) {
+	e := fakeExecutor(Options{}, nil, "code")
+
+	cmd, err := e.AppCommand(Spec{Target: "code", Args: []string{"-n"}, Path: "/Users/me/project"})
+	assertArgs(t, cmd, err, "/usr/bin/code", "-n", "/Users/me/project")
+
+	cmd, err = e.AppCommand(Spec{Target: "Safari"})
+	assertArgs(t, cmd, err, "open", "-a", "Safari")
+
+	cmd, err = e.AppCommand(Spec{
+		Target: "/Applications/Visual Studio Code.app",
+		Args:   []string{"--new-window"},
+		Path:   "/Users/me/project",
+	})
+	assertArgs(t, cmd, err, "open", "-a", "/Applications/Visual Studio Code.app", "/Users/me/project", "--args", "--new-window")
+}
+
+func TestDarwinShellCommand(t *testing.T) {
+	e := fakeExecutor(Options{}, nil)
+
+	cmd, err := e.ShellCommand(Spec{Target: "lazygit", Args: []string{"-p", "a b"}, Path: "/Users/me/it's here"})
+	assertArgs(t, cmd, err, "osascript",
+		"-e", `tell application "Terminal" to do script "cd -- '/Users/me/it'\\''s here' && lazygit '-p' 'a b'"`,
+		"-e", `tell application "Terminal" to activate`,
+	)
+}
@@ -0,0 +1,147 @@
+//go:build linux
+
+package launcher
+
+import (
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// terminalExecFlags maps terminal emulators to the arguments that precede
+// the command they should run. Unknown terminals get "-e".
+var terminalExecFlags = map[string][]string{
+	"gnome-terminal":      {"--"},
+	"kgx":                 {"--"},
+	"ptyxis":              {"--"},
+	"konsole":             {"-e"},
+	"xfce4-terminal":      {"-x"},
+	"terminator":          {"-x"},
+	"alacritty":           {"-e"},
+	"tilix":               {"-e"},
+	"xterm":               {"-e"},
+	"urxvt":               {"-e"},
+	"x-terminal-emulator": {"-e"},
+	"wezterm":             {"start", "--"},
+	"kitty":               {},
+	"foot":                {},
+}
+
+// fallbackTerminals is the search order when no terminal is configured
+var fallbackTerminals = []string{
+	"x-terminal-emulator",
+	"gnome-terminal",
+	"konsole",
+	"xfce4-terminal",
+	"kitty",
+	"alacritty",
+	"wezterm",
+	"foot",
+	"tilix",
+	"xterm",
+}
+
+// AppCommand launches an executable from PATH or an absolute path with
+// its arguments. Anything else is handed to the desktop opener.
+func (e *Executor) AppCommand(s Spec) (*exec.Cmd, error) {
+	if exe, err := e.lookPath(s.Target); err == nil {
+		cmd := exec.Command(exe, s.appArgs()...)
+		cmd.Dir = s.WorkDir
+		return cmd, nil
+	}
+	return e.openCommand(s.Target)
+}
+
+// FolderCommand opens a folder in the default file manager
+func (e *Executor) FolderCommand(path string) (*exec.Cmd, error) {
+	return e.openCommand(path)
+}
+
+// URLCommand opens a URL in the default browser
+func (e *Executor) URLCommand(url string) (*exec.Cmd, error) {
+	return e.openCommand(url)
+}
+
+// ShellCommand runs a command with the user's shell in a terminal window.
+// Without a profile terminal the configured or detected emulator is used.
+// The shell stays open after the command finishes.
+func (e *Executor) ShellCommand(s Spec) (*exec.Cmd, error) {
+	if s.Profile.Terminal != "" {
+		return e.profileCommand(s)
+	}
+
+	term, err := e.terminal()
+	if err != nil {
+		return nil, err
+	}
+
+	flags, known := terminalExecFlags[filepath.Base(term[0])]
+	if !known {
+		flags = []string{"-e"}
+	}
+
+	sh, err := e.shell(s.Profile)
+	if err != nil {
+		return nil, err
+	}
+	invocation, err := sh.invocation(s)
+	if err != nil {
+		return nil, err
+	}
+
+	args := append([]string{}, term[1:]...)
+	args = append(args, flags...)
+	args = append(args, invocation...)
+
+	cmd := exec.Command(term[0], args...)
+	cmd.Dir = s.dir()
+	return cmd, nil
+}
+
+// fallbackShell is used when $SHELL is unset or not supported
+const fallbackShell = "/bin/sh"
+
+// defaultShell returns $SHELL, or the fallback shell if it is not set
+func (e *Executor) defaultShell() string {
+	if shell := e.getenv("SHELL"); shell != "" {
+		return shell
+	}
+	return fallbackShell
+}
+
+// openCommand opens target with xdg-open, falling back to gio
+func (e *Executor) openCommand(target string) (*exec.Cmd, error) {
+	if exe, err := e.lookPath("xdg-open"); err == nil {
+		return exec.Command(exe, target), nil
+	}
+	if exe, err := e.lookPath("gio"); err == nil {
+		return exec.Command(exe, "open", target), nil
+	}
+	return nil, ErrNoOpener
+}
+
+// terminal returns the configured terminal command line, or the first
+// terminal from $TERMINAL and the fallback list that is installed
+func (e *Executor) terminal() ([]string, error) {
+	if fields := strings.Fields(e.opts.Terminal); len(fields) > 0 {
+		exe, err := e.lookPath(fields[0])
+		if err != nil {
+			return nil, err
+		}
+		return append([]string{exe}, fields[1:]...), nil
+	}
+
+	candidates := fallbackTerminals
+	if env := e.getenv("TERMINAL"); env != "" {
+		candidates = append([]string{env}, candidates...)
+	}
+	for _, name := range candidates {
+		if exe, err := e.lookPath(name); err == nil {
+			return []string{exe}, nil
+		}
+	}
+	return nil, ErrNoTerminal
+}
+
+// hideWindow is a no-op, background commands never get a window here
+func hideWindow(cmd *exec.Cmd) {}
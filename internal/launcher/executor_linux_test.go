@@ -0,0 +1,175 @@
+//go:build linux
+
+package launcher
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestLinuxOpenCommands(t *testing.T) {
+	e := fakeExecutor(Options{}, nil, "xdg-open")
+
+	cmd, err := e.FolderCommand("/home/me/code")
+	assertArgs(t, cmd, err, "/usr/bin/xdg-open", "/home/me/code")
+
+	cmd, err = e.URLCommand("https://example.com")
+	assertArgs(t, cmd, err, "/usr/bin/xdg-open", "https://example.com")
+
+	gio := fakeExecutor(Options{}, nil, "gio")
+	cmd, err = gio.FolderCommand("/tmp")
+	assertArgs(t, cmd, err, "/usr/bin/gio", "open", "/tmp")
+
+	none := fakeExecutor(Options{}, nil)
+	if _, err := none.URLCommand("https://example.com"); !errors.Is(err, ErrNoOpener) {
+		t.Errorf("expected ErrNoOpener, got %v", err)
+	}
+}
+
+func TestLinuxAppCommand(t *testing.T) {
+	e := fakeExecutor(Options{}, nil, "xdg-open", "code")
+
+	cmd, err := e.AppCommand(Spec{Target: "code", Path: "/home/me/project"})
+	assertArgs(t, cmd, err, "/usr/bin/code", "/home/me/project")
+
+	cmd, err = e.AppCommand(Spec{Target: "code"})
+	assertArgs(t, cmd, err, "/usr/bin/code")
+
+	// Args come before the submenu path, WorkDir becomes the cwd
+	cmd, err = e.AppCommand(Spec{
+		Target:  "code",
+		Args:    []string{"--new-window", "it's a file"},
+		Path:    "/home/me/project",
+		WorkDir: "/home/me",
+	})
+	assertArgs(t, cmd, err, "/usr/bin/code", "--new-window", "it's a file", "/home/me/project")
+	if cmd.Dir != "/home/me" {
+		t.Errorf("Dir = %q, want /home/me", cmd.Dir)
+	}
+
+	// Unknown executables are treated as documents
+	cmd, err = e.AppCommand(Spec{Target: "/home/me/notes.pdf"})
+	assertArgs(t, cmd, err, "/usr/bin/xdg-open", "/home/me/notes.pdf")
+}
+
+func TestLinuxShellCommand(t *testing.T) {
+	env := map[string]string{"SHELL": "/bin/zsh"}
+
+	tests := []struct {
+		name     string
+		opts     Options
+		env      map[string]string
+		binaries []string
+		want     []string
+	}{
+		{
+			name:     "configured gnome-terminal",
+			opts:     Options{Terminal: "gnome-terminal"},
+			binaries: []string{"gnome-terminal", "xterm"},
+			want:     []string{"/usr/bin/gnome-terminal", "--", "/bin/zsh", "-c", "htop\nexec '/bin/zsh'"},
+		},
+		{
+			name:     "terminal with extra arguments",
+			opts:     Options{Terminal: "kitty --single-instance"},
+			binaries: []string{"kitty"},
+			want:     []string{"/usr/bin/kitty", "--single-instance", "/bin/zsh", "-c", "htop\nexec '/bin/zsh'"},
+		},
+		{
+			name:     "unknown terminal uses -e",
+			opts:     Options{Terminal: "myterm"},
+			binaries: []string{"myterm"},
+			want:     []string{"/usr/bin/myterm", "-e", "/bin/zsh", "-c", "htop\nexec '/bin/zsh'"},
+		},
+		{
+			name:     "TERMINAL environment variable",
+			env:      map[string]string{"SHELL": "/bin/zsh", "TERMINAL": "wezterm"},
+			binaries: []string{"wezterm", "xterm"},
+			want:     []string{"/usr/bin/wezterm", "start", "--", "/bin/zsh", "-c", "htop\nexec '/bin/zsh'"},
+		},
+		{
+			name:     "fallback list",
+			binaries: []string{"xterm"},
+			want:     []string{"/usr/bin/xterm", "-e", "/bin/zsh", "-c", "htop\nexec '/bin/zsh'"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := fakeExecutor(tt.opts, env, tt.binaries...)
+			if tt.env != nil {
+				e = fakeExecutor(tt.opts, tt.env, tt.binaries...)
+			}
+			cmd, err := e.ShellCommand(Spec{Target: "htop", Path: "/srv/app"})
+			assertArgs(t, cmd, err, tt.want...)
+			if cmd.Dir != "/srv/app" {
+				t.Errorf("Dir = %q, want /srv/app", cmd.Dir)
+			}
+		})
+	}
+}
+
+func TestLinuxShellCommandWithoutTerminal(t *testing.T) {
+	e := fakeExecutor(Options{}, nil)
+	if _, err := e.ShellCommand(Spec{Target: "htop"}); !errors.Is(err, ErrNoTerminal) {
+		t.Errorf("expected ErrNoTerminal, got %v", err)
+	}
+
+	e = fakeExecutor(Options{}, map[string]string{}, "xterm")
+	cmd, err := e.ShellCommand(Spec{})
+	assertArgs(t, cmd, err, "/usr/bin/xterm", "-e", "/bin/sh")
+}
+
+func TestLinuxShellCommandArgsAndWorkDir(t *testing.T) {
+	e := fakeExecutor(Options{Terminal: "xterm"}, map[string]string{"SHELL": "/bin/bash"}, "xterm")
+
+	cmd, err := e.ShellCommand(Spec{
+		Target:  "git log",
+		Args:    []string{"--author=O'Brien", "$(id)"},
+		WorkDir: "/srv/repo",
+	})
+	assertArgs(t, cmd, err, "/usr/bin/xterm", "-e", "/bin/bash", "-c",
+		"git log '--author=O'\\''Brien' '$(id)'\nexec '/bin/bash'")
+	if cmd.Dir != "/srv/repo" {
+		t.Errorf("Dir = %q, want /srv/repo", cmd.Dir)
+	}
+
+	// The submenu path wins over WorkDir
+	cmd, _ = e.ShellCommand(Spec{Target: "ls", WorkDir: "/srv/repo", Path: "/tmp"})
+	if cmd.Dir != "/tmp" {
+		t.Errorf("Dir = %q, want /tmp", cmd.Dir)
+	}
+}
+
+func TestLinuxShellCommandHostilePaths(t *testing.T) {
+	e := fakeExecutor(Options{Terminal: "xterm"}, map[string]string{"SHELL": "/usr/bin/fish"}, "xterm")
+
+	for _, path := range hostileInputs {
+		if path == "" {
+			continue
+		}
+		dir := "/srv/" + path
+		cmd, err := e.ShellCommand(Spec{Target: "ls", Path: dir})
+		if err != nil {
+			t.Fatalf("ShellCommand(%q) returned error: %v", dir, err)
+		}
+
+		// The path is only used as working directory, never in the script
+		assertArgs(t, cmd, err, "/usr/bin/xterm", "-e", "/usr/bin/fish", "-c", "ls\nexec '/usr/bin/fish'")
+		if cmd.Dir != dir {
+			t.Errorf("Dir = %q, want %q", cmd.Dir, dir)
+		}
+	}
+}
+
+func TestLinuxShellCommandUnsupportedShell(t *testing.T) {
+	e := fakeExecutor(Options{Terminal: "xterm"}, map[string]string{"SHELL": "/usr/bin/nu"}, "xterm")
+
+	// An unsupported login shell falls back to /bin/sh
+	cmd, err := e.ShellCommand(Spec{Target: "ls"})
+	assertArgs(t, cmd, err, "/usr/bin/xterm", "-e", "/bin/sh", "-c", "ls\nexec '/bin/sh'")
+
+	// An unsupported shell chosen explicitly is an error
+	if _, err := e.ShellCommand(Spec{Target: "ls", Profile: Profile{Shell: "nu"}}); !errors.Is(err, ErrUnsupportedShell) {
+		t.Errorf("expected ErrUnsupportedShell, got %v", err)
+	}
+}
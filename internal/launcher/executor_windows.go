@@ -0,0 +1,82 @@
+//go:build windows
+
+package launcher
+
+import (
+	"os/exec"
+	"strings"
+	"syscall"
+)
+
+// AppCommand launches an application with its arguments. Executables
+// found on PATH are started directly; other targets (App Paths entries,
+// documents) go through "cmd /c start" with every argument quoted.
+func (e *Executor) AppCommand(s Spec) (*exec.Cmd, error) {
+	if exe, err := e.lookPath(s.Target); err == nil {
+		cmd := exec.Command(exe, s.appArgs()...)
+		cmd.Dir = s.WorkDir
+		return cmd, nil
+	}
+
+	parts := []string{"cmd", "/c", "start", `""`, cmdQuote(s.Target)}
+	for _, arg := range s.appArgs() {
+		parts = append(parts, cmdQuote(arg))
+	}
+
+	cmd := exec.Command("cmd")
+	cmd.SysProcAttr = &syscall.SysProcAttr{CmdLine: strings.Join(parts, " ")}
+	cmd.Dir = s.WorkDir
+	return cmd, nil
+}
+
+// FolderCommand opens a folder in Windows Explorer
+func (e *Executor) FolderCommand(path string) (*exec.Cmd, error) {
+	return exec.Command("explorer", path), nil
+}
+
+// URLCommand opens a URL in the default browser
+func (e *Executor) URLCommand(url string) (*exec.Cmd, error) {
+	cmd := exec.Command("cmd")
+	cmd.SysProcAttr = &syscall.SysProcAttr{CmdLine: `cmd /c start "" ` + cmdQuote(url)}
+	return cmd, nil
+}
+
+// ShellCommand runs a shell command in a terminal. Without a profile
+// terminal the shell (PowerShell by default) opens its own console window.
+func (e *Executor) ShellCommand(s Spec) (*exec.Cmd, error) {
+	if s.Profile.Terminal != "" {
+		return e.profileCommand(s)
+	}
+
+	sh, err := e.shell(s.Profile)
+	if err != nil {
+		return nil, err
+	}
+	argv, err := sh.invocation(s)
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := exec.Command(argv[0], argv[1:]...)
+	cmd.Dir = s.dir()
+	return cmd, nil
+}
+
+// fallbackShell is used when the default shell is not supported
+const fallbackShell = "powershell"
+
+// defaultShell returns the shell used when a profile names none
+func (e *Executor) defaultShell() string {
+	return "powershell"
+}
+
+// createNoWindow keeps console programs from opening a console window
+const createNoWindow = 0x08000000
+
+// hideWindow starts cmd without a visible console window
+func hideWindow(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{
+		HideWindow:    true,
+		CreationFlags: createNoWindow,
+	}
+}
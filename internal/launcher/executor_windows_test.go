@@ -0,0 +1,63 @@
+//go:build windows
+
+package launcher
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWindowsCommands(t *testing.T) {
+	e := fakeExecutor(Options{}, nil)
+
+	cmd, err := e.AppCommand(Spec{Target: "msedge", Args: []string{"--inprivate", "a&b"}, Path: `C:\src\app`})
+	assertArgs(t, cmd, err, "cmd")
+	if want := `cmd /c start "" "msedge" "--inprivate" "a&b" "C:\src\app"`; cmd.SysProcAttr.CmdLine != want {
+		t.Errorf("CmdLine = %s, want %s", cmd.SysProcAttr.CmdLine, want)
+	}
+
+	cmd, err = e.FolderCommand(`C:\src`)
+	assertArgs(t, cmd, err, "explorer", `C:\src`)
+
+	cmd, err = e.URLCommand("https://example.com/?a=1&b=2")
+	assertArgs(t, cmd, err, "cmd")
+	if want := `cmd /c start "" "https://example.com/?a=1&b=2"`; cmd.SysProcAttr.CmdLine != want {
+		t.Errorf("CmdLine = %s, want %s", cmd.SysProcAttr.CmdLine, want)
+	}
+
+	cmd, err = e.ShellCommand(Spec{Target: "claude", Path: `C:\src\app`})
+	assertArgs(t, cmd, err, "powershell", "-NoExit", "-Command", "claude")
+	if cmd.Dir != `C:\src\app` {
+		t.Errorf("Dir = %q", cmd.Dir)
+	}
+
+	cmd, err = e.ShellCommand(Spec{Target: "npm run", Args: []string{"dev", "it's"}})
+	assertArgs(t, cmd, err, "powershell", "-NoExit", "-Command", "npm run 'dev' 'it''s'")
+}
+
+func TestWindowsAppCommandOnPath(t *testing.T) {
+	e := fakeExecutor(Options{}, nil, "code")
+
+	cmd, err := e.AppCommand(Spec{Target: "code", Args: []string{"-n"}, WorkDir: `C:\src`})
+	assertArgs(t, cmd, err, "/usr/bin/code", "-n")
+	if cmd.Dir != `C:\src` {
+		t.Errorf("Dir = %q", cmd.Dir)
+	}
+}
+
+func TestWindowsElevatedAppCommand(t *testing.T) {
+	e := fakeExecutor(Options{}, nil, "regedit")
+
+	el, err := e.ElevatedAppCommand(Spec{Target: "regedit", Args: []string{"a b", "it's"}})
+	if err != nil {
+		t.Fatalf("ElevatedAppCommand returned error: %v", err)
+	}
+	if !el.Prompt || el.Cmd.Args[0] != "powershell" {
+		t.Fatalf("unexpected elevation %+v", el.Cmd.Args)
+	}
+	script := el.Cmd.Args[len(el.Cmd.Args)-1]
+	want := `Start-Process -Verb RunAs -FilePath '/usr/bin/regedit' -ArgumentList '"a b" it''s'`
+	if !strings.Contains(script, want) {
+		t.Errorf("script %q does not contain %q", script, want)
+	}
+}
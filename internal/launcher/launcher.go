@@ -0,0 +1,87 @@
+package launcher
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+)
+
+var (
+	// ErrNoOpener is returned when no desktop opener (xdg-open, gio) is installed
+	ErrNoOpener = errors.New("no opener found, install xdg-utils or gio")
+	// ErrNoTerminal is returned when no terminal emulator can be found
+	ErrNoTerminal = errors.New("no terminal emulator found")
+)
+
+// Options configures platform specific launch behavior
+type Options struct {
+	// Terminal is the terminal emulator used for shell tiles on Linux.
+	// When empty, $TERMINAL and a list of well-known emulators are tried.
+	Terminal string
+}
+
+// Spec describes an application or shell command to launch
+type Spec struct {
+	// Target is the application or shell command
+	Target string
+	// Args are passed to the target, quoted for the platform
+	Args []string
+	// Path is an optional folder chosen in the submenu. Applications get
+	// it as last argument, shell commands use it as working directory.
+	Path string
+	// WorkDir is the working directory when no Path is given
+	WorkDir string
+	// Profile selects the terminal for shell commands
+	Profile Profile
+	// Elevated runs shell invocations through the platform elevation
+	// prefix; set by ElevatedShellCommand
+	Elevated bool
+}
+
+// dir returns the working directory for shell commands
+func (s Spec) dir() string {
+	if s.Path != "" {
+		return absPath(s.Path)
+	}
+	if s.WorkDir != "" {
+		return absPath(s.WorkDir)
+	}
+	return ""
+}
+
+// appArgs returns Args followed by Path, if set
+func (s Spec) appArgs() []string {
+	args := append([]string{}, s.Args...)
+	if s.Path != "" {
+		args = append(args, s.Path)
+	}
+	return args
+}
+
+// Executor builds the OS commands for tile actions on the current platform.
+// Each platform provides AppCommand, FolderCommand, URLCommand and
+// ShellCommand in its own build-tagged file.
+type Executor struct {
+	opts     Options
+	lookPath func(file string) (string, error)
+	getenv   func(key string) string
+}
+
+// New creates a new Executor for the current platform
+func New(opts Options) *Executor {
+	return &Executor{
+		opts:     opts,
+		lookPath: exec.LookPath,
+		getenv:   os.Getenv,
+	}
+}
+
+// absPath returns the absolute form of path, or path itself on error
+func absPath(path string) string {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return path
+	}
+	return abs
+}
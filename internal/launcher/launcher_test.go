@@ -0,0 +1,60 @@
+package launcher
+
+import (
+	"os/exec"
+	"testing"
+)
+
+// fakeExecutor returns an executor that only finds the given binaries
+func fakeExecutor(opts Options, env map[string]string, binaries ...string) *Executor {
+	installed := make(map[string]bool)
+	for _, b := range binaries {
+		installed[b] = true
+	}
+	return &Executor{
+		opts: opts,
+		lookPath: func(file string) (string, error) {
+			if installed[file] {
+				return "/usr/bin/" + file, nil
+			}
+			return "", exec.ErrNotFound
+		},
+		getenv: func(key string) string { return env[key] },
+	}
+}
+
+// assertArgs compares the arguments of cmd with want
+func assertArgs(t *testing.T, cmd *exec.Cmd, err error, want ...string) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(cmd.Args) != len(want) {
+		t.Fatalf("args = %q, want %q", cmd.Args, want)
+	}
+	for i := range want {
+		if cmd.Args[i] != want[i] {
+			t.Fatalf("args = %q, want %q", cmd.Args, want)
+		}
+	}
+}
+
+func TestShellQuote(t *testing.T) {
+	tests := map[string]string{
+		"plain":       "'plain'",
+		"with space":  "'with space'",
+		"it's":        `'it'\''s'`,
+		"$(rm -rf ~)": "'$(rm -rf ~)'",
+	}
+	for in, want := range tests {
+		if got := shellQuote(in); got != want {
+			t.Errorf("shellQuote(%q) = %s, want %s", in, got, want)
+		}
+	}
+}
+
+func TestAppleScriptQuote(t *testing.T) {
+	if got := appleScriptQuote(`say "hi" \ bye`); got != `"say \"hi\" \\ bye"` {
+		t.Errorf("appleScriptQuote = %s", got)
+	}
+}
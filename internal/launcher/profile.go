@@ -0,0 +1,76 @@
+package launcher
+
+import (
+	"os/exec"
+	"strings"
+)
+
+// Profile describes how a shell command is opened in a terminal emulator.
+// The zero value uses the platform default terminal and shell.
+type Profile struct {
+	// Terminal is the terminal emulator binary. Empty uses the platform
+	// default (console window, detected emulator or Terminal.app).
+	Terminal string
+	// Args is the argument template for Terminal. "{dir}" is replaced by
+	// the working directory and may be part of a larger argument, an
+	// argument that is exactly "{command}" is replaced by the shell
+	// invocation. Without "{command}" the invocation is appended.
+	Args []string
+	// Shell runs the command inside the terminal, given as shell kind
+	// (bash, zsh, fish, sh, pwsh, powershell, cmd) or path to one of
+	// them. Empty uses the platform default shell.
+	Shell string
+}
+
+// profileCommand builds the command for a profile with an explicit terminal
+func (e *Executor) profileCommand(s Spec) (*exec.Cmd, error) {
+	term, err := e.lookPath(s.Profile.Terminal)
+	if err != nil {
+		return nil, err
+	}
+
+	dir := s.dir()
+	if dir == "" {
+		dir = e.homeDir()
+	}
+
+	sh, err := e.shell(s.Profile)
+	if err != nil {
+		return nil, err
+	}
+	invocation, err := sh.invocation(s)
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := exec.Command(term, expandProfileArgs(s.Profile.Args, dir, invocation)...)
+	cmd.Dir = dir
+	return cmd, nil
+}
+
+// expandProfileArgs fills an argument template with the working directory
+// and the shell invocation
+func expandProfileArgs(tmpl []string, dir string, command []string) []string {
+	args := make([]string, 0, len(tmpl)+len(command))
+	replaced := false
+	for _, arg := range tmpl {
+		if arg == "{command}" {
+			args = append(args, command...)
+			replaced = true
+			continue
+		}
+		args = append(args, strings.ReplaceAll(arg, "{dir}", dir))
+	}
+	if !replaced {
+		args = append(args, command...)
+	}
+	return args
+}
+
+// homeDir returns the user's home directory, or "" if unknown
+func (e *Executor) homeDir() string {
+	if home := e.getenv("HOME"); home != "" {
+		return home
+	}
+	return e.getenv("USERPROFILE")
+}
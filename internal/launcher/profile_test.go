@@ -0,0 +1,75 @@
+//go:build !windows
+
+package launcher
+
+import "testing"
+
+func TestProfileCommand(t *testing.T) {
+	e := fakeExecutor(Options{}, map[string]string{"HOME": "/home/me"}, "wt", "kitty", "wezterm")
+
+	tests := []struct {
+		name string
+		spec Spec
+		want []string
+		dir  string
+	}{
+		{
+			name: "windows terminal with powershell",
+			spec: Spec{
+				Target:  "claude",
+				Path:    "/src/app",
+				Profile: Profile{Terminal: "wt", Args: []string{"-d", "{dir}", "{command}"}, Shell: "powershell"},
+			},
+			want: []string{"/usr/bin/wt", "-d", "/src/app", "powershell", "-NoExit", "-Command", "claude"},
+			dir:  "/src/app",
+		},
+		{
+			name: "kitty with bash and arguments",
+			spec: Spec{
+				Target:  "lazygit",
+				Args:    []string{"-p", "it's"},
+				WorkDir: "/src/repo",
+				Profile: Profile{Terminal: "kitty", Args: []string{"--directory", "{dir}"}, Shell: "/bin/bash"},
+			},
+			want: []string{"/usr/bin/kitty", "--directory", "/src/repo", "/bin/bash", "-c", "lazygit '-p' 'it'\\''s'\nexec '/bin/bash'"},
+			dir:  "/src/repo",
+		},
+		{
+			name: "embedded dir placeholder defaults to home",
+			spec: Spec{
+				Target:  "htop",
+				Profile: Profile{Terminal: "wezterm", Args: []string{"start", "--cwd={dir}", "--", "{command}"}, Shell: "/bin/zsh"},
+			},
+			want: []string{"/usr/bin/wezterm", "start", "--cwd=/home/me", "--", "/bin/zsh", "-c", "htop\nexec '/bin/zsh'"},
+			dir:  "/home/me",
+		},
+		{
+			name: "cmd shell",
+			spec: Spec{
+				Target:  "dir",
+				Args:    []string{"a&b"},
+				Path:    "/src",
+				Profile: Profile{Terminal: "wt", Args: []string{"{command}"}, Shell: "cmd.exe"},
+			},
+			want: []string{"/usr/bin/wt", "cmd.exe", "/k", `dir "a&b"`},
+			dir:  "/src",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd, err := e.ShellCommand(tt.spec)
+			assertArgs(t, cmd, err, tt.want...)
+			if cmd.Dir != tt.dir {
+				t.Errorf("Dir = %q, want %q", cmd.Dir, tt.dir)
+			}
+		})
+	}
+}
+
+func TestProfileCommandMissingTerminal(t *testing.T) {
+	e := fakeExecutor(Options{}, nil)
+	if _, err := e.ShellCommand(Spec{Target: "htop", Profile: Profile{Terminal: "alacritty"}}); err == nil {
+		t.Error("expected an error for a terminal that is not installed")
+	}
+}
@@ -0,0 +1,61 @@
+package launcher
+
+import (
+	"errors"
+	"strings"
+)
+
+// ErrUnsafeArgument is returned for arguments that cannot be quoted safely
+// for the target shell
+var ErrUnsafeArgument = errors.New("argument cannot be passed safely to this shell")
+
+// shellQuote quotes s for POSIX shells using single quotes
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// fishQuote quotes s for fish. Inside single quotes fish still treats
+// \\ and \' as escapes, so both are escaped.
+func fishQuote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `'`, `\'`)
+	return "'" + s + "'"
+}
+
+// psSingleQuotes are the characters PowerShell accepts as single quotes
+var psSingleQuotes = strings.NewReplacer(
+	"'", "''",
+	"\u2018", "\u2018\u2018",
+	"\u2019", "\u2019\u2019",
+	"\u201a", "\u201a\u201a",
+	"\u201b", "\u201b\u201b",
+)
+
+// psQuote quotes s as a PowerShell single-quoted string literal.
+// Typographic quotes end a literal too and are doubled as well.
+func psQuote(s string) string {
+	return "'" + psSingleQuotes.Replace(s) + "'"
+}
+
+// cmdQuote quotes s for cmd.exe. Everything is wrapped in double quotes so
+// that &, | and friends stay literal; embedded quotes are doubled.
+func cmdQuote(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, `""`) + `"`
+}
+
+// cmdQuoteStrict quotes s for commands interpreted by cmd.exe itself.
+// cmd expands %VAR% even inside quotes and a quote would end the quoted
+// section, so such arguments are rejected instead of being mangled.
+func cmdQuoteStrict(s string) (string, error) {
+	if strings.ContainsAny(s, "\"%\r\n") {
+		return "", ErrUnsafeArgument
+	}
+	return `"` + s + `"`, nil
+}
+
+// appleScriptQuote quotes s as an AppleScript string literal
+func appleScriptQuote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	return `"` + s + `"`
+}
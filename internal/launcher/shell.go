@@ -0,0 +1,194 @@
+package launcher
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// ShellKind identifies a shell family with its own quoting rules
+type ShellKind string
+
+const (
+	ShellSh         ShellKind = "sh"
+	ShellBash       ShellKind = "bash"
+	ShellZsh        ShellKind = "zsh"
+	ShellFish       ShellKind = "fish"
+	ShellPwsh       ShellKind = "pwsh"
+	ShellPowerShell ShellKind = "powershell"
+	ShellCmd        ShellKind = "cmd"
+)
+
+// ShellKinds lists all supported shell kinds
+var ShellKinds = []ShellKind{ShellSh, ShellBash, ShellZsh, ShellFish, ShellPwsh, ShellPowerShell, ShellCmd}
+
+// ErrUnsupportedShell is returned for shells whose quoting rules are unknown
+var ErrUnsupportedShell = errors.New("unsupported shell")
+
+// ShellKindOf returns the kind of a shell given by name or path,
+// e.g. "fish", "/usr/bin/zsh" or `C:\Windows\System32\cmd.exe`
+func ShellKindOf(shell string) (ShellKind, error) {
+	name := strings.ToLower(filepath.Base(strings.ReplaceAll(shell, `\`, "/")))
+	name = strings.TrimSuffix(name, ".exe")
+
+	switch name {
+	case "sh", "dash", "ash":
+		return ShellSh, nil
+	case "bash", "zsh", "fish", "pwsh", "powershell", "cmd":
+		return ShellKind(name), nil
+	}
+	return "", fmt.Errorf("%w: %q", ErrUnsupportedShell, shell)
+}
+
+// Quote quotes a single argument so the shell passes it on verbatim
+func (k ShellKind) Quote(arg string) (string, error) {
+	if strings.ContainsRune(arg, 0) {
+		return "", ErrUnsafeArgument
+	}
+
+	switch k {
+	case ShellFish:
+		return fishQuote(arg), nil
+	case ShellPwsh, ShellPowerShell:
+		return psQuote(arg), nil
+	case ShellCmd:
+		return cmdQuoteStrict(arg)
+	default:
+		return shellQuote(arg), nil
+	}
+}
+
+// ChangeDir returns a command that changes into dir
+func (k ShellKind) ChangeDir(dir string) (string, error) {
+	quoted, err := k.Quote(dir)
+	if err != nil {
+		return "", err
+	}
+
+	switch k {
+	case ShellFish:
+		return "cd " + quoted, nil
+	case ShellPwsh, ShellPowerShell:
+		return "Set-Location -LiteralPath " + quoted, nil
+	case ShellCmd:
+		return "cd /d " + quoted, nil
+	default:
+		return "cd -- " + quoted, nil
+	}
+}
+
+// shell is a shell binary together with its kind
+type shell struct {
+	path string
+	kind ShellKind
+}
+
+// newShell resolves a shell name or path to a shell
+func newShell(path string) (shell, error) {
+	kind, err := ShellKindOf(path)
+	if err != nil {
+		return shell{}, err
+	}
+	return shell{path: path, kind: kind}, nil
+}
+
+// argv returns the invocation of the shell running script. The shell
+// stays open afterwards so the output can be read.
+func (sh shell) argv(script string) []string {
+	if script == "" {
+		return []string{sh.path}
+	}
+
+	switch sh.kind {
+	case ShellPwsh, ShellPowerShell:
+		return []string{sh.path, "-NoExit", "-Command", script}
+	case ShellCmd:
+		return []string{sh.path, "/k", script}
+	default:
+		self, _ := sh.kind.Quote(sh.path)
+		return []string{sh.path, "-c", script + "\nexec " + self}
+	}
+}
+
+// runArgv returns a non-interactive invocation of the shell running
+// script that exits when the script is done
+func (sh shell) runArgv(script string) []string {
+	switch sh.kind {
+	case ShellPwsh, ShellPowerShell:
+		return []string{sh.path, "-NoProfile", "-NonInteractive", "-Command", script}
+	case ShellCmd:
+		return []string{sh.path, "/c", script}
+	default:
+		return []string{sh.path, "-c", script}
+	}
+}
+
+// commandLine joins the target command with its arguments quoted for the
+// shell. The target itself is the user's command and stays unquoted.
+func (sh shell) commandLine(s Spec) (string, error) {
+	command := s.Target
+	for _, arg := range s.Args {
+		quoted, err := sh.kind.Quote(arg)
+		if err != nil {
+			return "", fmt.Errorf("argument %q: %w", arg, err)
+		}
+		command += " " + quoted
+	}
+	return command, nil
+}
+
+// invocation returns the full shell argv for a spec. Elevated specs run
+// the shell through the elevation prefix.
+func (sh shell) invocation(s Spec) ([]string, error) {
+	script, err := sh.commandLine(s)
+	if err != nil {
+		return nil, err
+	}
+	if s.Elevated {
+		return append(append([]string{}, elevationPrefix...), sh.argv(script)...), nil
+	}
+	return sh.argv(script), nil
+}
+
+// shell returns the shell for a spec: the profile shell if set, otherwise
+// the platform default. An unsupported default shell falls back to the
+// platform fallback shell instead of failing.
+func (e *Executor) shell(p Profile) (shell, error) {
+	if p.Shell != "" {
+		return newShell(p.Shell)
+	}
+	if sh, err := newShell(e.defaultShell()); err == nil {
+		return sh, nil
+	}
+	return newShell(fallbackShell)
+}
+
+// ShellKind returns the kind of shell a spec with profile p runs in
+func (e *Executor) ShellKind(p Profile) (ShellKind, error) {
+	sh, err := e.shell(p)
+	if err != nil {
+		return "", err
+	}
+	return sh.kind, nil
+}
+
+// BackgroundCommand runs a shell command without a terminal window so its
+// output and exit status can be captured by the caller
+func (e *Executor) BackgroundCommand(s Spec) (*exec.Cmd, error) {
+	sh, err := e.shell(s.Profile)
+	if err != nil {
+		return nil, err
+	}
+	script, err := sh.commandLine(s)
+	if err != nil {
+		return nil, err
+	}
+
+	argv := sh.runArgv(script)
+	cmd := exec.Command(argv[0], argv[1:]...)
+	cmd.Dir = s.dir()
+	hideWindow(cmd)
+	return cmd, nil
+}
@@ -0,0 +1,253 @@
+package launcher
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+// hostileInputs are paths and arguments that break naive concatenation
+var hostileInputs = []string{
+	"plain",
+	"with space",
+	"it's",
+	"it’s",
+	`say "hi"`,
+	"$(touch pwned)",
+	"`touch pwned`",
+	"; rm -rf ~",
+	"a && b || c",
+	"semi;colon|pipe&amp",
+	`back\slash\\double`,
+	"new\nline",
+	"$HOME",
+	"-n",
+	"",
+}
+
+func TestShellKindOf(t *testing.T) {
+	tests := []struct {
+		shell string
+		want  ShellKind
+	}{
+		{"bash", ShellBash},
+		{"/usr/bin/zsh", ShellZsh},
+		{"/usr/local/bin/fish", ShellFish},
+		{"/bin/dash", ShellSh},
+		{"pwsh", ShellPwsh},
+		{`C:\Windows\System32\WindowsPowerShell\v1.0\powershell.exe`, ShellPowerShell},
+		{`C:\Windows\System32\CMD.EXE`, ShellCmd},
+	}
+	for _, tt := range tests {
+		got, err := ShellKindOf(tt.shell)
+		if err != nil || got != tt.want {
+			t.Errorf("ShellKindOf(%q) = %q, %v; want %q", tt.shell, got, err, tt.want)
+		}
+	}
+
+	if _, err := ShellKindOf("/usr/bin/nu"); !errors.Is(err, ErrUnsupportedShell) {
+		t.Errorf("expected ErrUnsupportedShell for nu, got %v", err)
+	}
+}
+
+func TestQuote(t *testing.T) {
+	tests := []struct {
+		kind ShellKind
+		arg  string
+		want string
+	}{
+		{ShellBash, "it's", `'it'\''s'`},
+		{ShellZsh, "$(id)", `'$(id)'`},
+		{ShellSh, `a\b`, `'a\b'`},
+		{ShellFish, "it's", `'it\'s'`},
+		{ShellFish, `a\b`, `'a\\b'`},
+		{ShellPwsh, "it's", `'it''s'`},
+		{ShellPowerShell, "it’s", `'it’’s'`},
+		{ShellPowerShell, "$env:HOME; calc", `'$env:HOME; calc'`},
+		{ShellCmd, "a & b", `"a & b"`},
+	}
+	for _, tt := range tests {
+		got, err := tt.kind.Quote(tt.arg)
+		if err != nil || got != tt.want {
+			t.Errorf("%s.Quote(%q) = %s, %v; want %s", tt.kind, tt.arg, got, err, tt.want)
+		}
+	}
+}
+
+func TestQuoteRejectsUnsafe(t *testing.T) {
+	tests := []struct {
+		kind ShellKind
+		arg  string
+	}{
+		{ShellCmd, "%PATH%"},
+		{ShellCmd, `say "hi"`},
+		{ShellCmd, "new\nline"},
+		{ShellBash, "nul\x00byte"},
+		{ShellPwsh, "nul\x00byte"},
+	}
+	for _, tt := range tests {
+		if _, err := tt.kind.Quote(tt.arg); !errors.Is(err, ErrUnsafeArgument) {
+			t.Errorf("%s.Quote(%q) error = %v, want ErrUnsafeArgument", tt.kind, tt.arg, err)
+		}
+	}
+}
+
+func TestChangeDir(t *testing.T) {
+	tests := []struct {
+		kind ShellKind
+		want string
+	}{
+		{ShellBash, `cd -- '/srv/it'\''s'`},
+		{ShellFish, `cd '/srv/it\'s'`},
+		{ShellPwsh, `Set-Location -LiteralPath '/srv/it''s'`},
+		{ShellCmd, `cd /d "/srv/it's"`},
+	}
+	for _, tt := range tests {
+		got, err := tt.kind.ChangeDir("/srv/it's")
+		if err != nil || got != tt.want {
+			t.Errorf("%s.ChangeDir = %s, %v; want %s", tt.kind, got, err, tt.want)
+		}
+	}
+}
+
+// TestQuoteRoundTrip runs the quoted arguments through the real shells
+// that are installed and checks they arrive unchanged
+func TestQuoteRoundTrip(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("POSIX shells are not available")
+	}
+
+	for _, kind := range []ShellKind{ShellSh, ShellBash, ShellZsh, ShellFish} {
+		path, err := exec.LookPath(string(kind))
+		if err != nil {
+			continue
+		}
+
+		t.Run(string(kind), func(t *testing.T) {
+			for _, input := range hostileInputs {
+				quoted, err := kind.Quote(input)
+				if err != nil {
+					t.Fatalf("Quote(%q) returned error: %v", input, err)
+				}
+
+				out, err := exec.Command(path, "-c", "printf '%s' "+quoted).Output()
+				if err != nil {
+					t.Fatalf("%s failed for %q: %v", kind, input, err)
+				}
+				if string(out) != input {
+					t.Errorf("%s turned %q into %q", kind, input, out)
+				}
+			}
+		})
+	}
+}
+
+// TestChangeDirRoundTrip changes into directories with hostile names
+func TestChangeDirRoundTrip(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("POSIX shells are not available")
+	}
+	sh, err := exec.LookPath("sh")
+	if err != nil {
+		t.Skip("sh is not installed")
+	}
+
+	root := t.TempDir()
+	for _, name := range hostileInputs {
+		if name == "" {
+			continue
+		}
+		dir := filepath.Join(root, name)
+		if err := os.Mkdir(dir, 0755); err != nil {
+			t.Fatalf("mkdir %q: %v", name, err)
+		}
+
+		cd, err := ShellSh.ChangeDir(dir)
+		if err != nil {
+			t.Fatalf("ChangeDir(%q) returned error: %v", dir, err)
+		}
+
+		cmd := exec.Command(sh, "-c", cd+" && pwd -P")
+		cmd.Dir = root
+		out, err := cmd.Output()
+		if err != nil {
+			t.Fatalf("cd into %q failed: %v", name, err)
+		}
+
+		want, _ := filepath.EvalSymlinks(dir)
+		if got := string(out[:len(out)-1]); got != want {
+			t.Errorf("cd into %q ended in %q", want, got)
+		}
+	}
+
+	if _, err := os.Stat(filepath.Join(root, "pwned")); err == nil {
+		t.Error("a hostile directory name executed a command")
+	}
+}
+
+func TestShellCommandLineHostileArgs(t *testing.T) {
+	sh := shell{path: "/bin/bash", kind: ShellBash}
+	for _, input := range hostileInputs {
+		got, err := sh.commandLine(Spec{Target: "echo", Args: []string{input}})
+		if err != nil {
+			t.Fatalf("commandLine(%q) returned error: %v", input, err)
+		}
+		if want := "echo " + shellQuote(input); got != want {
+			t.Errorf("commandLine(%q) = %s, want %s", input, got, want)
+		}
+	}
+
+	cmd := shell{path: "cmd", kind: ShellCmd}
+	if _, err := cmd.commandLine(Spec{Target: "echo", Args: []string{"%USERPROFILE%"}}); !errors.Is(err, ErrUnsafeArgument) {
+		t.Errorf("expected ErrUnsafeArgument for cmd, got %v", err)
+	}
+}
+
+func TestBackgroundCommand(t *testing.T) {
+	e := fakeExecutor(Options{}, map[string]string{"SHELL": "/bin/bash"})
+
+	tests := []struct {
+		profile Profile
+		want    []string
+	}{
+		{Profile{}, []string{"/bin/bash", "-c", "make 'build all'"}},
+		{Profile{Shell: "fish"}, []string{"fish", "-c", "make 'build all'"}},
+		{Profile{Shell: "pwsh"}, []string{"pwsh", "-NoProfile", "-NonInteractive", "-Command", "make 'build all'"}},
+		{Profile{Shell: "cmd"}, []string{"cmd", "/c", `make "build all"`}},
+	}
+
+	for _, tt := range tests {
+		cmd, err := e.BackgroundCommand(Spec{Target: "make", Args: []string{"build all"}, Profile: tt.profile})
+		assertArgs(t, cmd, err, tt.want...)
+	}
+}
+
+func TestBackgroundCommandRuns(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("POSIX shells are not available")
+	}
+	e := New(Options{})
+
+	dir := t.TempDir()
+	cmd, err := e.BackgroundCommand(Spec{
+		Target:  "printf '%s' \"$PWD\"; echo",
+		Args:    []string{"it's"},
+		Path:    dir,
+		Profile: Profile{Shell: "/bin/sh"},
+	})
+	if err != nil {
+		t.Fatalf("BackgroundCommand returned error: %v", err)
+	}
+
+	out, err := cmd.Output()
+	if err != nil {
+		t.Fatalf("command failed: %v", err)
+	}
+	want, _ := filepath.EvalSymlinks(dir)
+	if got := string(out); got != want+"it's\n" && got != dir+"it's\n" {
+		t.Errorf("output = %q, want %q", got, want+"it's\n")
+	}
+}
@@ -2,8 +2,7 @@ package notification
 
 import (
 	"fmt"
-
-	"git.sr.ht/~jackmordaunt/go-toast/v2/wintoast"
+	"html"
 )
 
 const (
@@ -15,7 +14,8 @@ const (
 // ToastCallback is called when the user clicks on a toast notification
 type ToastCallback func(action string)
 
-// Toast handles Windows toast notifications
+// Toast handles desktop notifications. Windows gets toasts with actions,
+// Linux and macOS a plain notification with title and body
 type Toast struct {
 	callback ToastCallback
 }
@@ -25,29 +25,6 @@ func NewToast() *Toast {
 	return &Toast{}
 }
 
-// Initialize sets up the toast notification system
-func (t *Toast) Initialize(exePath string, iconPath string) error {
-	appData := wintoast.AppData{
-		AppID:         appID,
-		GUID:          appGUID,
-		ActivationExe: exePath,
-		IconPath:      iconPath,
-	}
-
-	if err := wintoast.SetAppData(appData); err != nil {
-		return fmt.Errorf("failed to set app data: %w", err)
-	}
-
-	// Set up activation callback
-	wintoast.SetActivationCallback(func(appUserModelId string, invokedArgs string, userData []wintoast.UserData) {
-		if t.callback != nil {
-			t.callback(invokedArgs)
-		}
-	})
-
-	return nil
-}
-
 // SetCallback sets the callback function for toast activation
 func (t *Toast) SetCallback(cb ToastCallback) {
 	t.callback = cb
@@ -69,7 +46,7 @@ func (t *Toast) ShowUpdateAvailable(currentVersion, newVersion string) error {
     </actions>
 </toast>`, newVersion, currentVersion)
 
-	return wintoast.Push(appID, xml)
+	return t.push("Update verfügbar", fmt.Sprintf("QuickLaunch %s ist verfügbar (aktuell: %s)", newVersion, currentVersion), xml)
 }
 
 // ShowUpdateReady shows a toast notification when update is ready to install
@@ -88,5 +65,46 @@ func (t *Toast) ShowUpdateReady(newVersion string) error {
     </actions>
 </toast>`, newVersion)
 
-	return wintoast.Push(appID, xml)
+	return t.push("Update bereit", fmt.Sprintf("QuickLaunch %s wurde heruntergeladen und ist bereit zur Installation.", newVersion), xml)
+}
+
+// ShowRunFinished shows a toast notification when a background run has finished
+func (t *Toast) ShowRunFinished(name string, success bool, exitCode int) error {
+	title := "Ausführung abgeschlossen"
+	body := fmt.Sprintf("%s wurde erfolgreich beendet.", name)
+	if !success {
+		title = "Ausführung fehlgeschlagen"
+		body = fmt.Sprintf("%s wurde mit Exit-Code %d beendet.", name, exitCode)
+	}
+
+	xml := fmt.Sprintf(`
+<toast launch="show-runs" activationType="foreground">
+    <visual>
+        <binding template="ToastGeneric">
+            <text>%s</text>
+            <text>%s</text>
+        </binding>
+    </visual>
+    <actions>
+        <action content="Ausgabe anzeigen" arguments="show-runs" activationType="foreground"/>
+        <action content="Schließen" arguments="dismiss" activationType="system"/>
+    </actions>
+</toast>`, title, html.EscapeString(body))
+
+	return t.push(title, body, xml)
+}
+
+// ShowMessage shows a toast notification with a title and a message
+func (t *Toast) ShowMessage(title, message string) error {
+	xml := fmt.Sprintf(`
+<toast activationType="foreground">
+    <visual>
+        <binding template="ToastGeneric">
+            <text>%s</text>
+            <text>%s</text>
+        </binding>
+    </visual>
+</toast>`, html.EscapeString(title), html.EscapeString(message))
+
+	return t.push(title, message, xml)
 }
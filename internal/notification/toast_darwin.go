@@ -0,0 +1,22 @@
+package notification
+
+import (
+	"fmt"
+	"os/exec"
+	"strconv"
+)
+
+// Initialize is a no-op, osascript needs no registration and has no
+// click actions, so the callback is never called
+func (t *Toast) Initialize(exePath string, iconPath string) error {
+	return nil
+}
+
+// push shows title and body via AppleScript's display notification
+func (t *Toast) push(title, body, xml string) error {
+	script := fmt.Sprintf("display notification %s with title %s", strconv.Quote(body), strconv.Quote(title))
+	if out, err := exec.Command("osascript", "-e", script).CombinedOutput(); err != nil {
+		return fmt.Errorf("osascript failed: %w: %s", err, out)
+	}
+	return nil
+}
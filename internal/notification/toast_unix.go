@@ -0,0 +1,27 @@
+//go:build !windows && !darwin
+
+package notification
+
+import (
+	"fmt"
+	"os/exec"
+)
+
+// Initialize is a no-op, notify-send needs no registration and has no
+// click actions, so the callback is never called
+func (t *Toast) Initialize(exePath string, iconPath string) error {
+	return nil
+}
+
+// push shows title and body via notify-send. Without notify-send (no
+// libnotify installed) the notification is silently dropped
+func (t *Toast) push(title, body, xml string) error {
+	path, err := exec.LookPath("notify-send")
+	if err != nil {
+		return nil
+	}
+	if out, err := exec.Command(path, "--app-name="+appID, title, body).CombinedOutput(); err != nil {
+		return fmt.Errorf("notify-send failed: %w: %s", err, out)
+	}
+	return nil
+}
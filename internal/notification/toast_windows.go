@@ -0,0 +1,35 @@
+package notification
+
+import (
+	"fmt"
+
+	"git.sr.ht/~jackmordaunt/go-toast/v2/wintoast"
+)
+
+// Initialize sets up the toast notification system
+func (t *Toast) Initialize(exePath string, iconPath string) error {
+	appData := wintoast.AppData{
+		AppID:         appID,
+		GUID:          appGUID,
+		ActivationExe: exePath,
+		IconPath:      iconPath,
+	}
+
+	if err := wintoast.SetAppData(appData); err != nil {
+		return fmt.Errorf("failed to set app data: %w", err)
+	}
+
+	// Set up activation callback
+	wintoast.SetActivationCallback(func(appUserModelId string, invokedArgs string, userData []wintoast.UserData) {
+		if t.callback != nil {
+			t.callback(invokedArgs)
+		}
+	})
+
+	return nil
+}
+
+// push shows the toast XML, title and body are only used on other platforms
+func (t *Toast) push(title, body, xml string) error {
+	return wintoast.Push(appID, xml)
+}
@@ -0,0 +1,128 @@
+package plugins
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+
+	"quicklaunch/internal/procs"
+)
+
+// DirName is the name of the plugins directory in the config directory
+const DirName = "plugins"
+
+// Host runs the plugins found in a directory
+type Host struct {
+	dir     string
+	plugins []*Plugin
+}
+
+// NewHost creates a host for the executables in dir, see Discover. The
+// plugins are started by Start. onChange is called whenever the status
+// of a plugin changed, it may be nil.
+func NewHost(dir string, opts Options, onChange func()) (*Host, error) {
+	paths, err := Discover(dir)
+	h := &Host{dir: dir}
+	tracker := procs.NewTracker(nil)
+	for _, path := range paths {
+		h.plugins = append(h.plugins, New(pluginID(path), path, opts, tracker, onChange))
+	}
+	return h, err
+}
+
+// Dir returns the plugins directory
+func (h *Host) Dir() string {
+	return h.dir
+}
+
+// Plugins returns the plugins ordered by ID
+func (h *Host) Plugins() []*Plugin {
+	return h.plugins
+}
+
+// Statuses returns the status of every plugin, ordered by ID
+func (h *Host) Statuses() []Status {
+	statuses := make([]Status, 0, len(h.plugins))
+	for _, p := range h.plugins {
+		statuses = append(statuses, p.Status())
+	}
+	return statuses
+}
+
+// Start starts all plugins
+func (h *Host) Start() {
+	for _, p := range h.plugins {
+		p.Start()
+	}
+}
+
+// Close stops all plugins in parallel
+func (h *Host) Close() {
+	var wg sync.WaitGroup
+	for _, p := range h.plugins {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			p.Stop()
+		}()
+	}
+	wg.Wait()
+}
+
+// Discover returns the executables in dir, ordered by name. Hidden files
+// and subdirectories are skipped, and of two executables with the same
+// ID only the first is used. A missing directory has no plugins.
+func Discover(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var paths []string
+	seen := make(map[string]bool)
+	for _, e := range entries {
+		if strings.HasPrefix(e.Name(), ".") {
+			continue
+		}
+		path := filepath.Join(dir, e.Name())
+		// Follows symlinks, plugins may be linked from a checkout
+		info, err := os.Stat(path)
+		if err != nil || !executable(info) {
+			continue
+		}
+		if id := pluginID(path); !seen[id] {
+			seen[id] = true
+			paths = append(paths, path)
+		}
+	}
+	return paths, nil
+}
+
+// executable reports whether a file can be run as a plugin: by extension
+// on Windows, by its permissions elsewhere
+func executable(info fs.FileInfo) bool {
+	if !info.Mode().IsRegular() {
+		return false
+	}
+	if runtime.GOOS == "windows" {
+		switch strings.ToLower(filepath.Ext(info.Name())) {
+		case ".exe", ".bat", ".cmd", ".com":
+			return true
+		}
+		return false
+	}
+	return info.Mode().Perm()&0o111 != 0
+}
+
+// pluginID is the file name of a plugin without its extension
+func pluginID(path string) string {
+	name := filepath.Base(path)
+	return strings.TrimSuffix(name, filepath.Ext(name))
+}
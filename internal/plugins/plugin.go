@@ -0,0 +1,707 @@
+package plugins
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"quicklaunch/internal/procs"
+	"quicklaunch/internal/providers"
+	"quicklaunch/internal/search"
+)
+
+// Statuses of a plugin
+const (
+	StatusStarting   = "starting"
+	StatusRunning    = "running"
+	StatusRestarting = "restarting"
+	// StatusFailed plugins speak another protocol version and are not
+	// restarted
+	StatusFailed  = "failed"
+	StatusStopped = "stopped"
+)
+
+// ResultKind is the kind of all plugin results
+const ResultKind = "plugin"
+
+// NamePrefix starts the provider names of plugins, e.g. "plugin:jira"
+const NamePrefix = "plugin:"
+
+// MaxScore caps the scores of plugin results. Good title matches of the
+// built-in providers score a few hundred.
+const MaxScore = 1000
+
+const (
+	// maxMessage is the size limit of a message from a plugin, larger
+	// ones are dropped
+	maxMessage = 1 << 20
+	// maxResults is the number of results taken from a query
+	maxResults = 50
+	// stderrLines is the number of lines of error output kept
+	stderrLines = 20
+	// waitDelay is how long output is still read after a plugin exited
+	waitDelay = time.Second
+	// defaultIcon is the icon of results without one
+	defaultIcon = "Puzzle"
+)
+
+var (
+	// ErrNotRunning is returned for calls to a plugin that is not running,
+	// e.g. while it is restarted
+	ErrNotRunning = errors.New("plugin is not running")
+	// ErrProtocol is returned for plugins speaking another protocol
+	// version
+	ErrProtocol = errors.New("unsupported plugin protocol")
+)
+
+// Options configure the timeouts and restarts of plugins
+type Options struct {
+	// CallTimeout bounds describe and execute calls; plugins that do not
+	// answer in time are restarted. Queries use the search timeout.
+	CallTimeout time.Duration
+	// MaxMissed is the number of queries in a row a plugin may leave
+	// unanswered before it is restarted
+	MaxMissed int
+	// MinBackoff is the delay before the first restart; it doubles with
+	// every crash up to MaxBackoff
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+	// StableAfter resets the backoff once a plugin ran this long
+	StableAfter time.Duration
+	// StopTimeout is how long a plugin may take to exit once its input
+	// was closed
+	StopTimeout time.Duration
+	// Version is the app version sent with describe
+	Version string
+	// Open and Copy carry out the answers to execute, they may be nil
+	Open func(target string) error
+	Copy func(text string) error
+}
+
+// DefaultOptions give calls 5s, restart crashed plugins after 1s and back
+// off up to a minute
+var DefaultOptions = Options{
+	CallTimeout: 5 * time.Second,
+	MaxMissed:   3,
+	MinBackoff:  time.Second,
+	MaxBackoff:  time.Minute,
+	StableAfter: 30 * time.Second,
+	StopTimeout: 2 * time.Second,
+}
+
+// withDefaults fills unset options from DefaultOptions
+func (o Options) withDefaults() Options {
+	d := DefaultOptions
+	if o.CallTimeout <= 0 {
+		o.CallTimeout = d.CallTimeout
+	}
+	if o.MaxMissed <= 0 {
+		o.MaxMissed = d.MaxMissed
+	}
+	if o.MinBackoff <= 0 {
+		o.MinBackoff = d.MinBackoff
+	}
+	if o.MaxBackoff < o.MinBackoff {
+		o.MaxBackoff = max(d.MaxBackoff, o.MinBackoff)
+	}
+	if o.StableAfter <= 0 {
+		o.StableAfter = d.StableAfter
+	}
+	if o.StopTimeout <= 0 {
+		o.StopTimeout = d.StopTimeout
+	}
+	return o
+}
+
+// Status describes a plugin for the UI
+type Status struct {
+	ID          string    `json:"id"`
+	Path        string    `json:"path"`
+	Name        string    `json:"name"`
+	Description string    `json:"description,omitempty"`
+	Keyword     string    `json:"keyword,omitempty"`
+	Status      string    `json:"status"`
+	PID         int       `json:"pid,omitempty"`
+	Restarts    int       `json:"restarts"`
+	LastError   string    `json:"lastError,omitempty"`
+	NextRestart time.Time `json:"nextRestart,omitempty"`
+	// Stderr holds the last lines of error output
+	Stderr []string `json:"stderr"`
+}
+
+// Plugin is an external program answering queries over stdin and stdout.
+// It implements providers.Provider and is restarted when it crashes.
+type Plugin struct {
+	id       string
+	path     string
+	opts     Options
+	tracker  *procs.Tracker
+	onChange func()
+	stderr   *tail
+
+	mu       sync.Mutex
+	proc     *process
+	manifest Manifest
+	state    Status
+	// missed counts the queries in a row that ran out of time
+	missed  int
+	started bool
+	stop    chan struct{}
+	done    chan struct{}
+}
+
+// New creates a stopped plugin for the executable at path. Its processes
+// are registered with tracker under the plugin's provider name. onChange
+// is called without locks held whenever the status changed, it may be
+// nil.
+func New(id, path string, opts Options, tracker *procs.Tracker, onChange func()) *Plugin {
+	return &Plugin{
+		id:       id,
+		path:     path,
+		opts:     opts.withDefaults(),
+		tracker:  tracker,
+		onChange: onChange,
+		stderr:   &tail{max: stderrLines},
+		state:    Status{ID: id, Path: path, Name: id, Status: StatusStopped},
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+}
+
+// ID returns the plugin's ID, its file name without extension
+func (p *Plugin) ID() string {
+	return p.id
+}
+
+// Name returns the provider name of the plugin
+func (p *Plugin) Name() string {
+	return NamePrefix + p.id
+}
+
+// Status returns the current status of the plugin
+func (p *Plugin) Status() Status {
+	p.mu.Lock()
+	state := p.state
+	p.mu.Unlock()
+	state.Stderr = p.stderr.lines()
+	return state
+}
+
+// Start starts the plugin and keeps it running until Stop. It returns
+// at once, the plugin is ready when its status is StatusRunning.
+func (p *Plugin) Start() {
+	p.mu.Lock()
+	if p.started {
+		p.mu.Unlock()
+		return
+	}
+	p.started = true
+	p.mu.Unlock()
+
+	go p.run()
+}
+
+// Stop closes the plugin's input, kills it if it does not exit within
+// the stop timeout and returns once it has exited
+func (p *Plugin) Stop() {
+	p.mu.Lock()
+	select {
+	case <-p.stop:
+	default:
+		close(p.stop)
+	}
+	started, proc := p.started, p.proc
+	p.mu.Unlock()
+
+	if proc != nil {
+		proc.closeInput()
+		select {
+		case <-proc.exited:
+		case <-time.After(p.opts.StopTimeout):
+			p.tracker.Stop(p.Name(), p.opts.StopTimeout)
+		}
+	}
+	if started {
+		<-p.done
+	}
+}
+
+// stopping reports whether Stop was called
+func (p *Plugin) stopping() bool {
+	select {
+	case <-p.stop:
+		return true
+	default:
+		return false
+	}
+}
+
+// run starts the plugin and restarts it with backoff whenever it exits,
+// until it is stopped
+func (p *Plugin) run() {
+	defer close(p.done)
+	backoff := p.opts.MinBackoff
+
+	for {
+		started := time.Now()
+		proc, err := p.launch()
+		if err == nil {
+			err = p.handshake(proc)
+			if err != nil {
+				p.kill()
+			}
+			if exitErr := proc.wait(); err == nil {
+				err = exitErr
+				if err == nil {
+					err = errors.New("plugin exited")
+				}
+			}
+		}
+
+		p.mu.Lock()
+		p.proc = nil
+		p.state.PID = 0
+		if p.stopping() {
+			p.state.Status = StatusStopped
+			p.mu.Unlock()
+			p.changed()
+			return
+		}
+		p.state.LastError = err.Error()
+		if errors.Is(err, ErrProtocol) {
+			p.state.Status = StatusFailed
+			p.mu.Unlock()
+			p.changed()
+			return
+		}
+		if line := p.stderr.last(); line != "" {
+			p.state.LastError += ": " + line
+		}
+		if time.Since(started) >= p.opts.StableAfter {
+			backoff = p.opts.MinBackoff
+		}
+		p.state.Status = StatusRestarting
+		p.state.NextRestart = time.Now().Add(backoff)
+		p.mu.Unlock()
+		p.changed()
+
+		select {
+		case <-p.stop:
+			p.mu.Lock()
+			p.state.Status = StatusStopped
+			p.state.NextRestart = time.Time{}
+			p.mu.Unlock()
+			p.changed()
+			return
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, p.opts.MaxBackoff)
+
+		p.mu.Lock()
+		p.state.Restarts++
+		p.mu.Unlock()
+	}
+}
+
+// launch starts the plugin process. It holds p.mu, so Stop either sees
+// the process or launch sees that the plugin is stopping.
+func (p *Plugin) launch() (*process, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.stopping() {
+		return nil, errors.New("plugin stopped")
+	}
+
+	proc := &process{
+		out:     make(chan []byte, 16),
+		pending: make(map[int64]chan response),
+		exited:  make(chan struct{}),
+	}
+	cmd := exec.Command(p.path)
+	cmd.Dir = filepath.Dir(p.path)
+	cmd.Env = append(os.Environ(), fmt.Sprintf("QUICKLAUNCH_PLUGIN_PROTOCOL=%d", ProtocolVersion))
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	cmd.Stdout = &lineWriter{max: maxMessage, line: proc.dispatch}
+	cmd.Stderr = &lineWriter{max: maxMessage, line: p.stderr.add}
+	// Children that inherited the output must not keep a crashed plugin
+	// from being restarted
+	cmd.WaitDelay = waitDelay
+
+	procs.Prepare(cmd)
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+	proc.cmd = cmd
+	proc.stdin = stdin
+	proc.untrack = p.tracker.Add(p.Name(), cmd)
+	go proc.write()
+
+	p.proc = proc
+	p.missed = 0
+	p.state.Status = StatusStarting
+	p.state.PID = cmd.Process.Pid
+	p.state.NextRestart = time.Time{}
+	return proc, nil
+}
+
+// handshake asks a freshly started plugin to describe itself
+func (p *Plugin) handshake(proc *process) error {
+	ctx, cancel := context.WithTimeout(context.Background(), p.opts.CallTimeout)
+	defer cancel()
+
+	var m Manifest
+	params := DescribeParams{Protocol: ProtocolVersion, App: "QuickLaunch", Version: p.opts.Version, OS: runtime.GOOS}
+	if err := proc.call(ctx, MethodDescribe, params, &m); err != nil {
+		return fmt.Errorf("describe: %w", err)
+	}
+	if m.Protocol != ProtocolVersion {
+		return fmt.Errorf("%w %d, want %d", ErrProtocol, m.Protocol, ProtocolVersion)
+	}
+	if m.Name == "" {
+		m.Name = p.id
+	}
+	m.Keyword = strings.TrimSpace(m.Keyword)
+
+	p.mu.Lock()
+	p.manifest = m
+	p.state.Name = m.Name
+	p.state.Description = m.Description
+	p.state.Keyword = m.Keyword
+	p.state.Status = StatusRunning
+	p.mu.Unlock()
+	p.changed()
+	return nil
+}
+
+// kill kills the running plugin process, which is then restarted
+func (p *Plugin) kill() {
+	go p.tracker.Stop(p.Name(), 0)
+}
+
+// running returns the process and manifest of a plugin that is ready
+func (p *Plugin) running() (*process, Manifest, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.proc == nil || p.state.Status != StatusRunning {
+		return nil, Manifest{}, ErrNotRunning
+	}
+	return p.proc, p.manifest, nil
+}
+
+// Query asks the plugin for results. Plugins with a keyword only get
+// input starting with it, and the text after it.
+func (p *Plugin) Query(ctx context.Context, text string) ([]providers.Result, error) {
+	proc, m, err := p.running()
+	if err != nil {
+		return nil, err
+	}
+	text, ok := scope(m.Keyword, text)
+	if !ok {
+		return nil, nil
+	}
+
+	var answer QueryResult
+	err = proc.call(ctx, MethodQuery, QueryParams{Text: text}, &answer)
+	p.mu.Lock()
+	missed := errors.Is(err, context.DeadlineExceeded) && p.proc == proc
+	if missed {
+		p.missed++
+		missed = p.missed >= p.opts.MaxMissed
+	} else if err == nil {
+		p.missed = 0
+	}
+	p.mu.Unlock()
+	if missed {
+		// A plugin that stopped answering is restarted
+		p.kill()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	items := answer.Results
+	if len(items) > maxResults {
+		items = items[:maxResults]
+	}
+	results := make([]providers.Result, 0, len(items))
+	for _, it := range items {
+		if it.ID == "" || it.Title == "" {
+			continue
+		}
+		icon := it.Icon
+		if icon == "" {
+			icon = m.Icon
+		}
+		if icon == "" {
+			icon = defaultIcon
+		}
+		res := providers.Result{
+			Kind:       ResultKind,
+			ID:         it.ID,
+			Title:      it.Title,
+			Subtitle:   it.Subtitle,
+			Icon:       icon,
+			Score:      min(max(it.Score, 0), MaxScore),
+			Highlights: []search.Highlight{},
+		}
+		for _, a := range it.Actions {
+			if a.ID != "" && a.Title != "" {
+				res.Actions = append(res.Actions, providers.Action{ID: a.ID, Title: a.Title})
+			}
+		}
+		results = append(results, res)
+	}
+	return results, nil
+}
+
+// scope returns the text a plugin with keyword gets for input, and false
+// if input is not meant for it. Plugins without a keyword get all
+// non-empty input.
+func scope(keyword, input string) (string, bool) {
+	input = strings.TrimSpace(input)
+	if keyword == "" {
+		return input, input != ""
+	}
+	if len(input) < len(keyword) || !strings.EqualFold(input[:len(keyword)], keyword) {
+		return "", false
+	}
+	rest := input[len(keyword):]
+	if rest != "" && rest[0] != ' ' {
+		// "jiranimo" is not meant for "jira"
+		return "", false
+	}
+	return strings.TrimSpace(rest), true
+}
+
+// Execute runs a result of the plugin with the chosen action and opens or
+// copies what the plugin answers with
+func (p *Plugin) Execute(r providers.Result) error {
+	proc, _, err := p.running()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), p.opts.CallTimeout)
+	defer cancel()
+
+	var answer ExecuteResult
+	if err := proc.call(ctx, MethodExecute, ExecuteParams{ID: r.ID, Action: r.Action}, &answer); err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			p.kill()
+		}
+		return err
+	}
+	if answer.Copy != "" && p.opts.Copy != nil {
+		if err := p.opts.Copy(answer.Copy); err != nil {
+			return err
+		}
+	}
+	if answer.Open != "" && p.opts.Open != nil {
+		return p.opts.Open(answer.Open)
+	}
+	return nil
+}
+
+func (p *Plugin) changed() {
+	if p.onChange != nil {
+		p.onChange()
+	}
+}
+
+// process is a running plugin process and its pending calls
+type process struct {
+	cmd     *exec.Cmd
+	stdin   io.WriteCloser
+	untrack func()
+	// out queues messages for the plugin's input, a plugin that does not
+	// read them cannot block callers
+	out       chan []byte
+	closeOnce sync.Once
+
+	mu      sync.Mutex
+	seq     int64
+	pending map[int64]chan response
+	// exited is closed once the process has exited
+	exited chan struct{}
+}
+
+// write sends queued messages to the plugin until it exits
+func (proc *process) write() {
+	for {
+		select {
+		case msg := <-proc.out:
+			if _, err := proc.stdin.Write(msg); err != nil {
+				return
+			}
+		case <-proc.exited:
+			return
+		}
+	}
+}
+
+// closeInput closes the plugin's input, asking it to exit
+func (proc *process) closeInput() {
+	proc.closeOnce.Do(func() {
+		proc.stdin.Close()
+	})
+}
+
+// wait waits for the process to exit and fails the pending calls
+func (proc *process) wait() error {
+	err := proc.cmd.Wait()
+	proc.untrack()
+	close(proc.exited)
+	return err
+}
+
+// send queues a message for the plugin
+func (proc *process) send(ctx context.Context, req request) error {
+	msg, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+	select {
+	case proc.out <- append(msg, '\n'):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-proc.exited:
+		return ErrNotRunning
+	}
+}
+
+// call sends a request and decodes the answer into result
+func (proc *process) call(ctx context.Context, method string, params, result any) error {
+	proc.mu.Lock()
+	proc.seq++
+	id := proc.seq
+	ch := make(chan response, 1)
+	proc.pending[id] = ch
+	proc.mu.Unlock()
+	defer func() {
+		proc.mu.Lock()
+		delete(proc.pending, id)
+		proc.mu.Unlock()
+	}()
+
+	if err := proc.send(ctx, request{JSONRPC: "2.0", ID: id, Method: method, Params: params}); err != nil {
+		return err
+	}
+
+	select {
+	case resp := <-ch:
+		if resp.Error != nil {
+			return resp.Error
+		}
+		if len(resp.Result) == 0 || bytes.Equal(resp.Result, []byte("null")) {
+			return nil
+		}
+		if err := json.Unmarshal(resp.Result, result); err != nil {
+			return fmt.Errorf("invalid answer to %s: %w", method, err)
+		}
+		return nil
+	case <-ctx.Done():
+		// Tell the plugin, without waiting for it
+		cancelCtx, cancel := context.WithTimeout(context.Background(), 0)
+		defer cancel()
+		proc.send(cancelCtx, request{JSONRPC: "2.0", Method: MethodCancel, Params: cancelParams{ID: id}})
+		return ctx.Err()
+	case <-proc.exited:
+		return ErrNotRunning
+	}
+}
+
+// dispatch passes a line of the plugin's output to the waiting call.
+// Lines that are no answers are ignored.
+func (proc *process) dispatch(line string) {
+	var resp response
+	if err := json.Unmarshal([]byte(line), &resp); err != nil || resp.ID == nil {
+		return
+	}
+	proc.mu.Lock()
+	ch := proc.pending[*resp.ID]
+	proc.mu.Unlock()
+	if ch != nil {
+		select {
+		case ch <- resp:
+		default:
+		}
+	}
+}
+
+// lineWriter splits output into lines. Lines longer than max are
+// dropped.
+type lineWriter struct {
+	max  int
+	line func(string)
+	buf  []byte
+	skip bool
+}
+
+func (w *lineWriter) Write(b []byte) (int, error) {
+	n := len(b)
+	for len(b) > 0 {
+		i := bytes.IndexByte(b, '\n')
+		if i < 0 {
+			if !w.skip {
+				w.buf = append(w.buf, b...)
+				if len(w.buf) > w.max {
+					w.buf, w.skip = w.buf[:0], true
+				}
+			}
+			break
+		}
+		if !w.skip && len(w.buf)+i <= w.max {
+			w.buf = append(w.buf, b[:i]...)
+			if line := strings.TrimSpace(string(w.buf)); line != "" {
+				w.line(line)
+			}
+		}
+		w.buf, w.skip = w.buf[:0], false
+		b = b[i+1:]
+	}
+	return n, nil
+}
+
+// tail keeps the last lines of a plugin's error output
+type tail struct {
+	mu  sync.Mutex
+	max int
+	buf []string
+}
+
+func (t *tail) add(line string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.buf = append(t.buf, line)
+	if len(t.buf) > t.max {
+		t.buf = t.buf[len(t.buf)-t.max:]
+	}
+}
+
+func (t *tail) lines() []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]string{}, t.buf...)
+}
+
+func (t *tail) last() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if len(t.buf) == 0 {
+		return ""
+	}
+	return t.buf[len(t.buf)-1]
+}
@@ -0,0 +1,293 @@
+//go:build !windows
+
+package plugins
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"quicklaunch/internal/procs"
+	"quicklaunch/internal/providers"
+)
+
+// testOptions restart quickly
+var testOptions = Options{CallTimeout: time.Second, MaxMissed: 2, MinBackoff: 10 * time.Millisecond, MaxBackoff: 40 * time.Millisecond, StopTimeout: time.Second}
+
+// TestHelperPlugin is not a test, it is the plugin started by the other
+// tests. QL_TEST_PLUGIN selects its behaviour.
+func TestHelperPlugin(t *testing.T) {
+	mode := os.Getenv("QL_TEST_PLUGIN")
+	if mode == "" {
+		return
+	}
+	defer os.Exit(0)
+
+	in := bufio.NewScanner(os.Stdin)
+	out := json.NewEncoder(os.Stdout)
+	for in.Scan() {
+		var req struct {
+			ID     int64           `json:"id"`
+			Method string          `json:"method"`
+			Params json.RawMessage `json:"params"`
+		}
+		json.Unmarshal(in.Bytes(), &req)
+		var result any
+		switch req.Method {
+		case MethodDescribe:
+			protocol := ProtocolVersion
+			if mode == "v2" {
+				protocol = 2
+			}
+			result = Manifest{Protocol: protocol, Name: "Jira", Keyword: "jira", Icon: "Ticket"}
+		case MethodQuery:
+			var params QueryParams
+			json.Unmarshal(req.Params, &params)
+			switch mode {
+			case "crash":
+				fmt.Fprintln(os.Stderr, "crashed on", params.Text)
+				os.Exit(3)
+			case "slow":
+				continue
+			}
+			// Noise on stdout is ignored
+			fmt.Println("not json")
+			result = QueryResult{Results: []Item{
+				{ID: "BUG-1", Title: "Bug: " + params.Text, Score: 5000, Actions: []Action{{ID: "copy", Title: "Link kopieren"}}},
+				{ID: "", Title: "no ID"},
+			}}
+		case MethodExecute:
+			var params ExecuteParams
+			json.Unmarshal(req.Params, &params)
+			result = ExecuteResult{Copy: params.ID + "/" + params.Action}
+		case MethodCancel:
+			continue
+		}
+		out.Encode(map[string]any{"jsonrpc": "2.0", "id": req.ID, "result": result})
+	}
+}
+
+// helperPlugin writes a script starting the helper plugin in mode to dir
+func helperPlugin(t *testing.T, dir, name, mode string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	script := fmt.Sprintf("#!/bin/sh\nQL_TEST_PLUGIN=%s exec %q -test.run=TestHelperPlugin\n", mode, os.Args[0])
+	if err := os.WriteFile(path, []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// startPlugin starts the helper plugin in mode and stops it when the test
+// ends
+func startPlugin(t *testing.T, mode string, opts Options) *Plugin {
+	t.Helper()
+	path := helperPlugin(t, t.TempDir(), "jira", mode)
+	p := New("jira", path, opts, procs.NewTracker(nil), nil)
+	p.Start()
+	t.Cleanup(p.Stop)
+	return p
+}
+
+// waitStatus waits until the plugin reaches a status accepted by ok
+func waitStatus(t *testing.T, p *Plugin, ok func(Status) bool) Status {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		if st := p.Status(); ok(st) {
+			return st
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("plugin did not reach the expected status: %+v", p.Status())
+	return Status{}
+}
+
+func running(st Status) bool {
+	return st.Status == StatusRunning
+}
+
+func TestDiscover(t *testing.T) {
+	dir := t.TempDir()
+	for name, mode := range map[string]os.FileMode{
+		"jira":      0o755,
+		"jira.py":   0o755,
+		"notes.sh":  0o700,
+		"README.md": 0o644,
+		".hidden":   0o755,
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), nil, mode); err != nil {
+			t.Fatal(err)
+		}
+	}
+	os.Mkdir(filepath.Join(dir, "lib"), 0o755)
+
+	paths, err := Discover(dir)
+	if err != nil {
+		t.Fatalf("Discover returned error: %v", err)
+	}
+	want := []string{filepath.Join(dir, "jira"), filepath.Join(dir, "notes.sh")}
+	if !reflect.DeepEqual(paths, want) {
+		t.Errorf("Discover() = %v, want %v", paths, want)
+	}
+
+	if paths, err := Discover(filepath.Join(dir, "missing")); err != nil || len(paths) != 0 {
+		t.Errorf("Discover(missing) = %v, %v", paths, err)
+	}
+}
+
+func TestScope(t *testing.T) {
+	tests := []struct {
+		keyword, input, want string
+		ok                   bool
+	}{
+		{"", "login bug", "login bug", true},
+		{"", "  ", "", false},
+		{"jira", "jira login bug", "login bug", true},
+		{"jira", "JIRA  bug", "bug", true},
+		{"jira", "jira", "", true},
+		{"jira", "jiranimo", "", false},
+		{"jira", "login bug", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := scope(tt.keyword, tt.input)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("scope(%q, %q) = %q, %v, want %q, %v", tt.keyword, tt.input, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestLineWriter(t *testing.T) {
+	var lines []string
+	w := &lineWriter{max: 8, line: func(s string) { lines = append(lines, s) }}
+	w.Write([]byte("one\ntw"))
+	w.Write([]byte("o\nfar too long"))
+	w.Write([]byte(" line\n\nthree\n"))
+
+	if want := []string{"one", "two", "three"}; !reflect.DeepEqual(lines, want) {
+		t.Errorf("lines = %q, want %q", lines, want)
+	}
+}
+
+func TestPluginQueryAndExecute(t *testing.T) {
+	copied := make(chan string, 1)
+	opts := testOptions
+	opts.Copy = func(text string) error {
+		copied <- text
+		return nil
+	}
+	p := startPlugin(t, "ok", opts)
+	st := waitStatus(t, p, running)
+	if st.Name != "Jira" || st.Keyword != "jira" {
+		t.Errorf("status = %+v, want the manifest's name and keyword", st)
+	}
+
+	if results, err := p.Query(context.Background(), "login bug"); err != nil || len(results) != 0 {
+		t.Errorf("input without the keyword returned %+v, %v", results, err)
+	}
+	results, err := p.Query(context.Background(), "jira login bug")
+	if err != nil {
+		t.Fatalf("Query returned error: %v", err)
+	}
+	if len(results) != 1 {
+		t.Fatalf("expected 1 result, got %+v", results)
+	}
+	r := results[0]
+	if r.Kind != ResultKind || r.Title != "Bug: login bug" || r.Icon != "Ticket" || r.Score != MaxScore {
+		t.Errorf("unexpected result %+v", r)
+	}
+	if want := []providers.Action{{ID: "copy", Title: "Link kopieren"}}; !reflect.DeepEqual(r.Actions, want) {
+		t.Errorf("Actions = %+v, want %+v", r.Actions, want)
+	}
+
+	r.Action = "copy"
+	if err := p.Execute(r); err != nil {
+		t.Fatalf("Execute returned error: %v", err)
+	}
+	if got := <-copied; got != "BUG-1/copy" {
+		t.Errorf("copied %q, want BUG-1/copy", got)
+	}
+
+	p.Stop()
+	if st := p.Status(); st.Status != StatusStopped || st.PID != 0 {
+		t.Errorf("status after Stop = %+v", st)
+	}
+	if _, err := p.Query(context.Background(), "jira x"); !errors.Is(err, ErrNotRunning) {
+		t.Errorf("expected ErrNotRunning after Stop, got %v", err)
+	}
+}
+
+func TestPluginRestartsAfterCrash(t *testing.T) {
+	p := startPlugin(t, "crash", testOptions)
+	waitStatus(t, p, running)
+
+	if _, err := p.Query(context.Background(), "jira boom"); !errors.Is(err, ErrNotRunning) {
+		t.Errorf("expected ErrNotRunning from a crashing query, got %v", err)
+	}
+	st := waitStatus(t, p, func(st Status) bool { return st.Restarts >= 1 && running(st) })
+	if !strings.Contains(st.LastError, "crashed on boom") {
+		t.Errorf("LastError = %q, want the last line of error output", st.LastError)
+	}
+}
+
+func TestPluginRestartsWhenUnresponsive(t *testing.T) {
+	p := startPlugin(t, "slow", testOptions)
+	first := waitStatus(t, p, running).PID
+
+	for range testOptions.MaxMissed {
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		if _, err := p.Query(ctx, "jira x"); !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("expected a timeout, got %v", err)
+		}
+		cancel()
+	}
+	st := waitStatus(t, p, func(st Status) bool { return running(st) && st.PID != first })
+	if st.Restarts != 1 {
+		t.Errorf("Restarts = %d, want 1", st.Restarts)
+	}
+}
+
+func TestPluginUnsupportedProtocol(t *testing.T) {
+	p := startPlugin(t, "v2", testOptions)
+	st := waitStatus(t, p, func(st Status) bool { return st.Status == StatusFailed })
+	if !strings.Contains(st.LastError, ErrProtocol.Error()) {
+		t.Errorf("LastError = %q", st.LastError)
+	}
+}
+
+func TestHost(t *testing.T) {
+	dir := t.TempDir()
+	helperPlugin(t, dir, "jira", "ok")
+	helperPlugin(t, dir, "wiki.sh", "ok")
+
+	h, err := NewHost(dir, testOptions, nil)
+	if err != nil {
+		t.Fatalf("NewHost returned error: %v", err)
+	}
+	h.Start()
+	defer h.Close()
+
+	var names []string
+	for _, p := range h.Plugins() {
+		names = append(names, p.Name())
+		waitStatus(t, p, running)
+	}
+	if want := []string{"plugin:jira", "plugin:wiki"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("plugins = %v, want %v", names, want)
+	}
+
+	h.Close()
+	for _, st := range h.Statuses() {
+		if st.Status != StatusStopped {
+			t.Errorf("%s is %s after Close", st.ID, st.Status)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package plugins
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// ProtocolVersion is the version of the plugin protocol. Plugins answer
+// describe with the version they speak; other versions are not started.
+const ProtocolVersion = 1
+
+// Methods of the protocol. The host sends requests, plugins answer them.
+// cancel is a notification for a query whose answer is no longer needed,
+// plugins may ignore it.
+const (
+	MethodDescribe = "describe"
+	MethodQuery    = "query"
+	MethodExecute  = "execute"
+	MethodCancel   = "cancel"
+)
+
+// request is a JSON-RPC 2.0 request, or a notification without ID
+type request struct {
+	JSONRPC string `json:"jsonrpc"`
+	ID      int64  `json:"id,omitempty"`
+	Method  string `json:"method"`
+	Params  any    `json:"params,omitempty"`
+}
+
+// response is a JSON-RPC 2.0 response
+type response struct {
+	ID     *int64          `json:"id"`
+	Result json.RawMessage `json:"result"`
+	Error  *RPCError       `json:"error"`
+}
+
+// RPCError is an error answered by a plugin
+type RPCError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *RPCError) Error() string {
+	return fmt.Sprintf("plugin error %d: %s", e.Code, e.Message)
+}
+
+// DescribeParams are sent with describe, once after every start
+type DescribeParams struct {
+	Protocol int    `json:"protocol"`
+	App      string `json:"app"`
+	Version  string `json:"version"`
+	OS       string `json:"os"`
+}
+
+// Manifest is a plugin's answer to describe
+type Manifest struct {
+	Protocol    int    `json:"protocol"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	// Keyword restricts queries to input starting with it, e.g. "jira"
+	// for "jira login bug". The plugin gets the text after it.
+	Keyword string `json:"keyword,omitempty"`
+	// Icon is the default icon of results, a lucide icon name
+	Icon string `json:"icon,omitempty"`
+}
+
+// QueryParams are sent with query
+type QueryParams struct {
+	Text string `json:"text"`
+}
+
+// QueryResult is a plugin's answer to query
+type QueryResult struct {
+	Results []Item `json:"results"`
+}
+
+// Item is a result of a plugin
+type Item struct {
+	ID       string `json:"id"`
+	Title    string `json:"title"`
+	Subtitle string `json:"subtitle,omitempty"`
+	Icon     string `json:"icon,omitempty"`
+	// Score orders the item among all results, see MaxScore
+	Score   int      `json:"score"`
+	Actions []Action `json:"actions,omitempty"`
+}
+
+// Action is a further action of an item besides the default one
+type Action struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+}
+
+// ExecuteParams are sent with execute. Action is empty for the default
+// action of the item.
+type ExecuteParams struct {
+	ID     string `json:"id"`
+	Action string `json:"action,omitempty"`
+}
+
+// ExecuteResult is a plugin's answer to execute. Plugins may carry out
+// the action themselves and answer with an empty result, or leave opening
+// a URL or file and copying text to the host.
+type ExecuteResult struct {
+	Open string `json:"open,omitempty"`
+	Copy string `json:"copy,omitempty"`
+}
+
+// cancelParams name the request of a cancel notification
+type cancelParams struct {
+	ID int64 `json:"id"`
+}
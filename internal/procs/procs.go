@@ -0,0 +1,195 @@
+package procs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrNotRunning is returned by Stop for tiles without live processes
+var ErrNotRunning = errors.New("no running processes")
+
+// Process is a live process started for a tile
+type Process struct {
+	ID       string    `json:"id"`
+	TileID   string    `json:"tileId"`
+	PID      int       `json:"pid"`
+	Command  string    `json:"command"`
+	Started  time.Time `json:"started"`
+	UptimeMs int64     `json:"uptimeMs"`
+}
+
+// entry is a tracked process and the channel closed once it was reaped
+type entry struct {
+	Process
+	cmd  *exec.Cmd
+	done chan struct{}
+}
+
+// Tracker keeps track of the processes started for tiles until they exit
+type Tracker struct {
+	mu       sync.Mutex
+	procs    map[string]*entry
+	seq      uint64
+	onChange func()
+}
+
+// NewTracker creates a Tracker. onChange is called without locks held
+// whenever a process was added or has exited, it may be nil.
+func NewTracker(onChange func()) *Tracker {
+	return &Tracker{
+		procs:    make(map[string]*entry),
+		onChange: onChange,
+	}
+}
+
+// Start starts cmd for a tile and reaps it once it exits. With wait set
+// Start blocks until the process has exited and returns its error, or
+// returns the error of ctx once it is done; the process keeps running
+// then.
+func (t *Tracker) Start(ctx context.Context, tileID string, cmd *exec.Cmd, wait bool) error {
+	Prepare(cmd)
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+
+	exited := t.Add(tileID, cmd)
+	waited := make(chan error, 1)
+	go func() {
+		err := cmd.Wait()
+		exited()
+		waited <- err
+	}()
+	if !wait {
+		return nil
+	}
+	select {
+	case err := <-waited:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Add tracks a command that was already started by the caller, who must
+// wait for it and call exited afterwards. Commands should be passed to
+// Prepare before they are started so that Stop reaches their children.
+func (t *Tracker) Add(tileID string, cmd *exec.Cmd) (exited func()) {
+	e := &entry{
+		Process: Process{
+			TileID:  tileID,
+			PID:     cmd.Process.Pid,
+			Command: strings.Join(cmd.Args, " "),
+			Started: time.Now(),
+		},
+		cmd:  cmd,
+		done: make(chan struct{}),
+	}
+
+	t.mu.Lock()
+	t.seq++
+	e.ID = fmt.Sprintf("%d", t.seq)
+	t.procs[e.ID] = e
+	t.mu.Unlock()
+	t.changed()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			t.mu.Lock()
+			delete(t.procs, e.ID)
+			t.mu.Unlock()
+			close(e.done)
+			t.changed()
+		})
+	}
+}
+
+// List returns the live processes, oldest first. A non-empty tileID
+// restricts the list to that tile.
+func (t *Tracker) List(tileID string) []Process {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := time.Now()
+	list := []Process{}
+	for _, e := range t.procs {
+		if tileID != "" && e.TileID != tileID {
+			continue
+		}
+		p := e.Process
+		p.UptimeMs = now.Sub(p.Started).Milliseconds()
+		list = append(list, p)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Started.Before(list[j].Started)
+	})
+	return list
+}
+
+// Stop asks all processes of a tile and their children to terminate and
+// kills those still running after timeout. It returns once all of them
+// have exited, or ErrNotRunning if the tile has no live processes.
+func (t *Tracker) Stop(tileID string, timeout time.Duration) error {
+	t.mu.Lock()
+	var entries []*entry
+	for _, e := range t.procs {
+		if e.TileID == tileID {
+			entries = append(entries, e)
+		}
+	}
+	t.mu.Unlock()
+
+	if len(entries) == 0 {
+		return ErrNotRunning
+	}
+
+	for _, e := range entries {
+		terminate(e.cmd.Process)
+	}
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	var left []*entry
+wait:
+	for i, e := range entries {
+		select {
+		case <-e.done:
+		case <-timer.C:
+			left = entries[i:]
+			break wait
+		}
+	}
+
+	// The timeout is over for all remaining processes, so they are all
+	// killed before waiting for any of them
+	var errs []error
+	var killed []*entry
+	for _, e := range left {
+		select {
+		case <-e.done:
+			continue
+		default:
+		}
+		if err := kill(e.cmd.Process); err != nil {
+			errs = append(errs, fmt.Errorf("kill %d: %w", e.PID, err))
+			continue
+		}
+		killed = append(killed, e)
+	}
+	for _, e := range killed {
+		<-e.done
+	}
+	return errors.Join(errs...)
+}
+
+func (t *Tracker) changed() {
+	if t.onChange != nil {
+		t.onChange()
+	}
+}
@@ -0,0 +1,117 @@
+//go:build !windows
+
+package procs
+
+import (
+	"context"
+	"errors"
+	"os/exec"
+	"testing"
+	"time"
+)
+
+func TestTrackerStartAndReap(t *testing.T) {
+	changes := make(chan struct{}, 10)
+	tr := NewTracker(func() { changes <- struct{}{} })
+
+	if err := tr.Start(context.Background(), "build", exec.Command("sh", "-c", "exit 3"), true); err == nil {
+		t.Error("expected the exit error of a waited command")
+	}
+	if len(tr.List("")) != 0 {
+		t.Error("exited processes must not be listed")
+	}
+	if len(changes) != 2 {
+		t.Errorf("expected 2 change notifications, got %d", len(changes))
+	}
+}
+
+func TestTrackerStop(t *testing.T) {
+	tr := NewTracker(nil)
+	if err := tr.Start(context.Background(), "server", exec.Command("sleep", "30"), false); err != nil {
+		t.Fatalf("Start returned error: %v", err)
+	}
+	tr.Start(context.Background(), "other", exec.Command("sleep", "30"), false)
+	defer tr.Stop("other", time.Second)
+
+	list := tr.List("server")
+	if len(list) != 1 || list[0].PID == 0 || list[0].Command != "sleep 30" {
+		t.Fatalf("unexpected processes %+v", list)
+	}
+
+	if err := tr.Stop("server", 5*time.Second); err != nil {
+		t.Fatalf("Stop returned error: %v", err)
+	}
+	if len(tr.List("server")) != 0 {
+		t.Error("stopped process is still listed")
+	}
+	if len(tr.List("")) != 1 {
+		t.Error("processes of other tiles must keep running")
+	}
+	if err := tr.Stop("server", time.Second); !errors.Is(err, ErrNotRunning) {
+		t.Errorf("expected ErrNotRunning, got %v", err)
+	}
+}
+
+func TestTrackerStopKillsAfterTimeout(t *testing.T) {
+	tr := NewTracker(nil)
+	// Ignored signals are inherited, so neither the shell nor sleep exits
+	cmd := exec.Command("sh", "-c", `trap "" TERM; sleep 30; sleep 30`)
+	if err := tr.Start(context.Background(), "stubborn", cmd, false); err != nil {
+		t.Fatalf("Start returned error: %v", err)
+	}
+	time.Sleep(100 * time.Millisecond)
+
+	started := time.Now()
+	if err := tr.Stop("stubborn", 200*time.Millisecond); err != nil {
+		t.Fatalf("Stop returned error: %v", err)
+	}
+	if elapsed := time.Since(started); elapsed > 5*time.Second {
+		t.Errorf("Stop took %v", elapsed)
+	}
+	if len(tr.List("")) != 0 {
+		t.Error("killed process is still listed")
+	}
+}
+
+func TestTrackerStopKillsAllAfterTimeout(t *testing.T) {
+	tr := NewTracker(nil)
+	for i := 0; i < 2; i++ {
+		cmd := exec.Command("sh", "-c", `trap "" TERM; sleep 30; sleep 30`)
+		if err := tr.Start(context.Background(), "stubborn", cmd, false); err != nil {
+			t.Fatalf("Start returned error: %v", err)
+		}
+	}
+	time.Sleep(100 * time.Millisecond)
+
+	stopped := make(chan error, 1)
+	go func() { stopped <- tr.Stop("stubborn", 200*time.Millisecond) }()
+	select {
+	case err := <-stopped:
+		if err != nil {
+			t.Fatalf("Stop returned error: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Stop did not return")
+	}
+	if len(tr.List("")) != 0 {
+		t.Error("killed processes are still listed")
+	}
+}
+
+func TestTrackerStartWaitEndsWithContext(t *testing.T) {
+	tr := NewTracker(nil)
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	err := tr.Start(ctx, "backup", exec.Command("sleep", "30"), true)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the context's error, got %v", err)
+	}
+	// The process was not killed, only the wait ended
+	if len(tr.List("backup")) != 1 {
+		t.Error("the process should keep running")
+	}
+	if err := tr.Stop("backup", time.Second); err != nil {
+		t.Errorf("Stop returned error: %v", err)
+	}
+}
@@ -0,0 +1,34 @@
+//go:build windows
+
+package procs
+
+import (
+	"os"
+	"os/exec"
+	"strconv"
+	"syscall"
+)
+
+// createNoWindow keeps taskkill from opening a console window
+const createNoWindow = 0x08000000
+
+// Prepare is a no-op, taskkill finds child processes by their parent
+func Prepare(cmd *exec.Cmd) {}
+
+// terminate asks p and its children to close their windows
+func terminate(p *os.Process) error {
+	return taskkill(p.Pid)
+}
+
+// kill forcefully terminates p and its children
+func kill(p *os.Process) error {
+	return taskkill(p.Pid, "/F")
+}
+
+// taskkill runs taskkill for the process tree of pid
+func taskkill(pid int, flags ...string) error {
+	args := append([]string{"/T", "/PID", strconv.Itoa(pid)}, flags...)
+	cmd := exec.Command("taskkill", args...)
+	cmd.SysProcAttr = &syscall.SysProcAttr{HideWindow: true, CreationFlags: createNoWindow}
+	return cmd.Run()
+}
@@ -0,0 +1,294 @@
+package providers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"quicklaunch/internal/search"
+)
+
+// DefaultTimeout is how long a provider may take to answer a query
+const DefaultTimeout = 250 * time.Millisecond
+
+// Result is one entry of a provider's answer. ID and Path are passed back
+// to the provider's Execute, their meaning is up to the provider.
+type Result struct {
+	// Provider is the name of the provider, set by Registry.Search
+	Provider   string             `json:"provider"`
+	Kind       string             `json:"kind"`
+	ID         string             `json:"id"`
+	Title      string             `json:"title"`
+	Subtitle   string             `json:"subtitle,omitempty"`
+	Path       string             `json:"path,omitempty"`
+	Icon       string             `json:"icon,omitempty"`
+	Score      int                `json:"score"`
+	Highlights []search.Highlight `json:"highlights"`
+	// Actions are further actions besides the default one. Execute gets
+	// the chosen one in Action, which is empty for the default action.
+	Actions []Action `json:"actions,omitempty"`
+	Action  string   `json:"action,omitempty"`
+	// Inputs and ConfirmToken are passed to Execute for results that
+	// need them, e.g. tiles with {input:...} placeholders or
+	// RequireConfirm. They are never part of a search response.
+	Inputs       map[string]string `json:"-"`
+	ConfirmToken string            `json:"-"`
+}
+
+// Action is a further action of a result, e.g. copying a link instead of
+// opening it
+type Action struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+}
+
+// Provider is a source of search results, e.g. tiles, installed
+// applications or files
+type Provider interface {
+	// Name identifies the provider in results and in the configuration
+	Name() string
+	// Query returns the results for text, best first. It should return
+	// early once ctx is done.
+	Query(ctx context.Context, text string) ([]Result, error)
+	// Execute runs a result returned by Query
+	Execute(r Result) error
+}
+
+// Settings configure a registered provider
+type Settings struct {
+	Disabled bool
+	// Timeout overrides the timeout given at registration when > 0
+	Timeout time.Duration
+}
+
+// Info describes a registered provider for the UI
+type Info struct {
+	Name      string `json:"name"`
+	Enabled   bool   `json:"enabled"`
+	TimeoutMs int64  `json:"timeoutMs"`
+}
+
+// Response holds the merged results of all providers. Errors lists the
+// providers that failed or ran out of time, their results are missing.
+type Response struct {
+	Query   string            `json:"query"`
+	Results []Result          `json:"results"`
+	Errors  map[string]string `json:"errors,omitempty"`
+}
+
+// ErrUnknownProvider is returned by Execute for results of providers that
+// are not registered
+var ErrUnknownProvider = errors.New("unknown search provider")
+
+// Registry queries providers concurrently and merges their results
+type Registry struct {
+	mu        sync.Mutex
+	providers []Provider
+	timeouts  map[string]time.Duration
+	settings  map[string]Settings
+	// cancel stops the previous search once a new one starts
+	cancel context.CancelFunc
+}
+
+// NewRegistry creates an empty registry
+func NewRegistry() *Registry {
+	return &Registry{
+		timeouts: make(map[string]time.Duration),
+		settings: make(map[string]Settings),
+	}
+}
+
+// Register adds a provider, replacing one with the same name. Results of
+// earlier providers win ties. A timeout <= 0 uses DefaultTimeout.
+func (r *Registry) Register(p Provider, timeout time.Duration) {
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.timeouts[p.Name()] = timeout
+	for i, existing := range r.providers {
+		if existing.Name() == p.Name() {
+			r.providers[i] = p
+			return
+		}
+	}
+	r.providers = append(r.providers, p)
+}
+
+// Unregister removes a provider by name
+func (r *Registry) Unregister(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.timeouts, name)
+	for i, p := range r.providers {
+		if p.Name() == name {
+			r.providers = append(r.providers[:i], r.providers[i+1:]...)
+			return
+		}
+	}
+}
+
+// Configure replaces the settings of the providers, by name. Providers
+// without settings are enabled with their registered timeout.
+func (r *Registry) Configure(settings map[string]Settings) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.settings = make(map[string]Settings, len(settings))
+	for name, s := range settings {
+		r.settings[name] = s
+	}
+}
+
+// Providers describes the registered providers in registration order
+func (r *Registry) Providers() []Info {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	infos := make([]Info, 0, len(r.providers))
+	for _, p := range r.providers {
+		infos = append(infos, Info{
+			Name:      p.Name(),
+			Enabled:   !r.settings[p.Name()].Disabled,
+			TimeoutMs: r.timeout(p.Name()).Milliseconds(),
+		})
+	}
+	return infos
+}
+
+// timeout returns the effective timeout of a provider. The caller holds
+// r.mu.
+func (r *Registry) timeout(name string) time.Duration {
+	if t := r.settings[name].Timeout; t > 0 {
+		return t
+	}
+	return r.timeouts[name]
+}
+
+// answer is the outcome of one provider's query
+type answer struct {
+	results []Result
+	err     error
+}
+
+// Search queries all enabled providers concurrently, each within its
+// timeout, and returns up to limit results ordered by score. A new search
+// cancels the previous one, which then returns context.Canceled. A limit
+// <= 0 returns all results.
+func (r *Registry) Search(ctx context.Context, text string, limit int) (Response, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type job struct {
+		p       Provider
+		timeout time.Duration
+	}
+	r.mu.Lock()
+	if r.cancel != nil {
+		r.cancel()
+	}
+	r.cancel = cancel
+	var jobs []job
+	for _, p := range r.providers {
+		if !r.settings[p.Name()].Disabled {
+			jobs = append(jobs, job{p, r.timeout(p.Name())})
+		}
+	}
+	r.mu.Unlock()
+
+	answers := make([]answer, len(jobs))
+	var wg sync.WaitGroup
+	for i, j := range jobs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			answers[i] = query(ctx, j.p, text, j.timeout)
+		}()
+	}
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return Response{}, err
+	}
+
+	resp := Response{Query: text, Results: []Result{}}
+	for i, a := range answers {
+		name := jobs[i].p.Name()
+		if a.err != nil {
+			if resp.Errors == nil {
+				resp.Errors = make(map[string]string)
+			}
+			resp.Errors[name] = a.err.Error()
+			continue
+		}
+		for _, res := range a.results {
+			res.Provider = name
+			if res.Highlights == nil {
+				res.Highlights = []search.Highlight{}
+			}
+			resp.Results = append(resp.Results, res)
+		}
+	}
+
+	sort.SliceStable(resp.Results, func(i, j int) bool {
+		return resp.Results[i].Score > resp.Results[j].Score
+	})
+	if limit > 0 && len(resp.Results) > limit {
+		resp.Results = resp.Results[:limit]
+	}
+	return resp, nil
+}
+
+// query runs one provider's query. A provider that ignores its context
+// is abandoned at the timeout and finishes in the background.
+func query(ctx context.Context, p Provider, text string, timeout time.Duration) answer {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	done := make(chan answer, 1)
+	go func() {
+		defer func() {
+			if v := recover(); v != nil {
+				done <- answer{err: fmt.Errorf("provider panicked: %v", v)}
+			}
+		}()
+		results, err := p.Query(ctx, text)
+		done <- answer{results, err}
+	}()
+
+	select {
+	case a := <-done:
+		if a.err == nil && ctx.Err() != nil {
+			// Late results would arrive after the user moved on
+			a.err = ctx.Err()
+		}
+		return a
+	case <-ctx.Done():
+		return answer{err: ctx.Err()}
+	}
+}
+
+// Execute runs a result with the provider that returned it
+func (r *Registry) Execute(res Result) error {
+	r.mu.Lock()
+	var provider Provider
+	for _, p := range r.providers {
+		if p.Name() == res.Provider {
+			provider = p
+			break
+		}
+	}
+	r.mu.Unlock()
+
+	if provider == nil {
+		return fmt.Errorf("%w: %q", ErrUnknownProvider, res.Provider)
+	}
+	return provider.Execute(res)
+}
@@ -0,0 +1,139 @@
+package providers
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// fakeProvider answers with fixed results after an optional delay
+type fakeProvider struct {
+	name     string
+	results  []Result
+	delay    time.Duration
+	executed chan Result
+}
+
+func (p *fakeProvider) Name() string { return p.name }
+
+func (p *fakeProvider) Query(ctx context.Context, text string) ([]Result, error) {
+	select {
+	case <-time.After(p.delay):
+		return p.results, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (p *fakeProvider) Execute(r Result) error {
+	p.executed <- r
+	return nil
+}
+
+func TestSearchMerges(t *testing.T) {
+	r := NewRegistry()
+	r.Register(&fakeProvider{name: "tiles", results: []Result{{ID: "a", Score: 50}, {ID: "b", Score: 10}}}, 0)
+	r.Register(&fakeProvider{name: "files", results: []Result{{ID: "c", Score: 50}, {ID: "d", Score: 30}}}, 0)
+
+	resp, err := r.Search(context.Background(), "x", 3)
+	if err != nil {
+		t.Fatalf("Search returned error: %v", err)
+	}
+	var ids []string
+	for _, res := range resp.Results {
+		ids = append(ids, res.Provider+"/"+res.ID)
+		if res.Highlights == nil {
+			t.Error("highlights should never be nil")
+		}
+	}
+	want := []string{"tiles/a", "files/c", "files/d"}
+	if len(ids) != len(want) || ids[0] != want[0] || ids[1] != want[1] || ids[2] != want[2] {
+		t.Errorf("results = %v, want %v", ids, want)
+	}
+
+	r.Configure(map[string]Settings{"files": {Disabled: true}})
+	if resp, _ := r.Search(context.Background(), "x", 0); len(resp.Results) != 2 {
+		t.Errorf("disabled providers should not be queried, got %+v", resp.Results)
+	}
+	if infos := r.Providers(); len(infos) != 2 || !infos[0].Enabled || infos[1].Enabled {
+		t.Errorf("Providers = %+v", infos)
+	}
+}
+
+func TestSearchTimeout(t *testing.T) {
+	r := NewRegistry()
+	r.Register(&fakeProvider{name: "fast", results: []Result{{ID: "a"}}}, 0)
+	r.Register(&fakeProvider{name: "slow", results: []Result{{ID: "b"}}, delay: time.Second}, 20*time.Millisecond)
+
+	resp, err := r.Search(context.Background(), "x", 0)
+	if err != nil {
+		t.Fatalf("Search returned error: %v", err)
+	}
+	if len(resp.Results) != 1 || resp.Results[0].ID != "a" {
+		t.Errorf("only the fast provider should answer, got %+v", resp.Results)
+	}
+	if resp.Errors["slow"] == "" {
+		t.Errorf("the slow provider should be reported, got %+v", resp.Errors)
+	}
+
+	r.Configure(map[string]Settings{"slow": {Timeout: 2 * time.Second}})
+	if infos := r.Providers(); infos[1].TimeoutMs != 2000 {
+		t.Errorf("configured timeout should win, got %+v", infos)
+	}
+}
+
+func TestSearchCancelledByNewSearch(t *testing.T) {
+	r := NewRegistry()
+	r.Register(&fakeProvider{name: "slow", delay: 500 * time.Millisecond}, time.Second)
+
+	first := make(chan error, 1)
+	go func() {
+		_, err := r.Search(context.Background(), "a", 0)
+		first <- err
+	}()
+	time.Sleep(20 * time.Millisecond)
+
+	r.Configure(map[string]Settings{"slow": {Disabled: true}})
+	if _, err := r.Search(context.Background(), "ab", 0); err != nil {
+		t.Fatalf("second Search returned error: %v", err)
+	}
+	select {
+	case err := <-first:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("first search should be cancelled, got %v", err)
+		}
+	case <-time.After(200 * time.Millisecond):
+		t.Error("first search was not cancelled")
+	}
+}
+
+func TestExecute(t *testing.T) {
+	r := NewRegistry()
+	p := &fakeProvider{name: "tiles", executed: make(chan Result, 1)}
+	r.Register(p, 0)
+
+	if err := r.Execute(Result{Provider: "tiles", ID: "a"}); err != nil {
+		t.Fatalf("Execute returned error: %v", err)
+	}
+	if got := <-p.executed; got.ID != "a" {
+		t.Errorf("executed %+v", got)
+	}
+	if err := r.Execute(Result{Provider: "nope"}); !errors.Is(err, ErrUnknownProvider) {
+		t.Errorf("expected ErrUnknownProvider, got %v", err)
+	}
+}
+
+func TestUnregister(t *testing.T) {
+	r := NewRegistry()
+	r.Register(&fakeProvider{name: "tiles"}, 0)
+	r.Register(&fakeProvider{name: "plugin:jira"}, 0)
+	r.Unregister("plugin:jira")
+
+	if infos := r.Providers(); len(infos) != 1 || infos[0].Name != "tiles" {
+		t.Errorf("Providers() = %+v after Unregister", infos)
+	}
+	if err := r.Execute(Result{Provider: "plugin:jira"}); !errors.Is(err, ErrUnknownProvider) {
+		t.Errorf("expected ErrUnknownProvider, got %v", err)
+	}
+}
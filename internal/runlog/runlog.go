@@ -0,0 +1,246 @@
+package runlog
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// FileName is the name of the current log file in the log directory
+	FileName = "runs.jsonl"
+	// DefaultMaxSize is the size at which the log file is rotated
+	DefaultMaxSize = 1 << 20
+	// DefaultBackups is the number of rotated files that are kept
+	DefaultBackups = 3
+)
+
+// Status of a logged run
+const (
+	// StatusStarted means the process was launched detached, so its exit
+	// status is unknown
+	StatusStarted = "started"
+	// StatusSucceeded means the process exited with code 0
+	StatusSucceeded = "succeeded"
+	// StatusFailed means the launch failed or the process exited non-zero
+	StatusFailed = "failed"
+	// StatusCancelled means the user dismissed the elevation prompt
+	StatusCancelled = "cancelled"
+)
+
+// Entry is a single record in the run log
+type Entry struct {
+	ID         string    `json:"id"`
+	TileID     string    `json:"tileId,omitempty"`
+	TileName   string    `json:"tileName,omitempty"`
+	Action     string    `json:"action"`
+	Target     string    `json:"target"`
+	Path       string    `json:"path,omitempty"`
+	Query      string    `json:"query,omitempty"`
+	Started    time.Time `json:"started"`
+	DurationMs int64     `json:"durationMs"`
+	Status     string    `json:"status"`
+	ExitCode   *int      `json:"exitCode,omitempty"`
+	Error      string    `json:"error,omitempty"`
+	// RunID refers to the captured output of a background run
+	RunID string `json:"runId,omitempty"`
+}
+
+// Filter restricts the entries returned by Query. Zero values match all.
+type Filter struct {
+	TileID string    `json:"tileId,omitempty"`
+	Action string    `json:"action,omitempty"`
+	Status string    `json:"status,omitempty"`
+	Since  time.Time `json:"since,omitempty"`
+	Until  time.Time `json:"until,omitempty"`
+	// Query matches case-insensitively against tile name, target and path
+	Query string `json:"query,omitempty"`
+	// Limit is the maximum number of entries, 0 means no limit
+	Limit int `json:"limit,omitempty"`
+}
+
+// match reports whether e passes the filter
+func (f Filter) match(e Entry) bool {
+	if f.TileID != "" && e.TileID != f.TileID {
+		return false
+	}
+	if f.Action != "" && e.Action != f.Action {
+		return false
+	}
+	if f.Status != "" && e.Status != f.Status {
+		return false
+	}
+	if !f.Since.IsZero() && e.Started.Before(f.Since) {
+		return false
+	}
+	if !f.Until.IsZero() && e.Started.After(f.Until) {
+		return false
+	}
+	if f.Query != "" {
+		q := strings.ToLower(f.Query)
+		if !strings.Contains(strings.ToLower(e.TileName), q) &&
+			!strings.Contains(strings.ToLower(e.Target), q) &&
+			!strings.Contains(strings.ToLower(e.Path), q) {
+			return false
+		}
+	}
+	return true
+}
+
+// Log is an append-only JSON lines file with size based rotation.
+// Rotated files are named runs.1.jsonl (newest) to runs.N.jsonl (oldest).
+type Log struct {
+	mu      sync.Mutex
+	dir     string
+	maxSize int64
+	backups int
+	seq     uint64
+}
+
+// New creates a log in dir. maxSize and backups fall back to the
+// defaults when they are not positive.
+func New(dir string, maxSize int64, backups int) *Log {
+	if maxSize <= 0 {
+		maxSize = DefaultMaxSize
+	}
+	if backups <= 0 {
+		backups = DefaultBackups
+	}
+	return &Log{dir: dir, maxSize: maxSize, backups: backups}
+}
+
+// file returns the path of the log file with the given rotation index,
+// 0 being the current file
+func (l *Log) file(index int) string {
+	if index == 0 {
+		return filepath.Join(l.dir, FileName)
+	}
+	ext := filepath.Ext(FileName)
+	return filepath.Join(l.dir, fmt.Sprintf("%s.%d%s", strings.TrimSuffix(FileName, ext), index, ext))
+}
+
+// Append writes an entry to the log. An empty ID is filled in and the
+// stored entry is returned.
+func (l *Log) Append(e Entry) (Entry, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if e.Started.IsZero() {
+		e.Started = time.Now()
+	}
+	if e.ID == "" {
+		l.seq++
+		e.ID = fmt.Sprintf("%d-%d", e.Started.UnixMilli(), l.seq)
+	}
+
+	line, err := json.Marshal(e)
+	if err != nil {
+		return e, err
+	}
+	line = append(line, '\n')
+
+	if err := os.MkdirAll(l.dir, 0755); err != nil {
+		return e, err
+	}
+	if err := l.rotate(int64(len(line))); err != nil {
+		return e, err
+	}
+
+	f, err := os.OpenFile(l.file(0), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return e, err
+	}
+	defer f.Close()
+
+	_, err = f.Write(line)
+	return e, err
+}
+
+// rotate shifts the log files if writing n more bytes would exceed maxSize
+func (l *Log) rotate(n int64) error {
+	info, err := os.Stat(l.file(0))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	if info.Size() == 0 || info.Size()+n <= l.maxSize {
+		return nil
+	}
+
+	os.Remove(l.file(l.backups))
+	for i := l.backups - 1; i >= 0; i-- {
+		if err := os.Rename(l.file(i), l.file(i+1)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// Query returns matching entries, newest first. Malformed lines are skipped.
+func (l *Log) Query(f Filter) ([]Entry, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var entries []Entry
+	for i := 0; i <= l.backups; i++ {
+		fileEntries, err := readFile(l.file(i))
+		if err != nil {
+			return nil, err
+		}
+		for j := len(fileEntries) - 1; j >= 0; j-- {
+			if !f.match(fileEntries[j]) {
+				continue
+			}
+			entries = append(entries, fileEntries[j])
+			if f.Limit > 0 && len(entries) >= f.Limit {
+				return entries, nil
+			}
+		}
+	}
+	return entries, nil
+}
+
+// Find returns the entry with the given ID
+func (l *Log) Find(id string) (Entry, bool, error) {
+	entries, err := l.Query(Filter{})
+	if err != nil {
+		return Entry{}, false, err
+	}
+	for _, e := range entries {
+		if e.ID == id {
+			return e, true, nil
+		}
+	}
+	return Entry{}, false, nil
+}
+
+// readFile reads all entries of one log file in file order
+func readFile(path string) ([]Entry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	var entries []Entry
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 1<<20)
+	for scanner.Scan() {
+		var e Entry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			continue
+		}
+		entries = append(entries, e)
+	}
+	return entries, scanner.Err()
+}
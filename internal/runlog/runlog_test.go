@@ -0,0 +1,128 @@
+package runlog
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestAppendAndQuery(t *testing.T) {
+	l := New(t.TempDir(), 0, 0)
+	base := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	code := 2
+	entries := []Entry{
+		{TileID: "a", TileName: "Projekte", Action: "folder", Target: "/home/me/projects", Started: base, Status: StatusStarted},
+		{TileID: "b", TileName: "Build", Action: "shell", Target: "make", Started: base.Add(time.Minute), Status: StatusFailed, ExitCode: &code},
+		{TileID: "a", TileName: "Projekte", Action: "folder", Target: "/home/me/projects", Path: "/home/me/projects/quicklaunch", Started: base.Add(2 * time.Minute), Status: StatusStarted},
+	}
+	for _, e := range entries {
+		stored, err := l.Append(e)
+		if err != nil {
+			t.Fatalf("Append returned error: %v", err)
+		}
+		if stored.ID == "" {
+			t.Error("Append did not assign an ID")
+		}
+	}
+
+	all, err := l.Query(Filter{})
+	if err != nil {
+		t.Fatalf("Query returned error: %v", err)
+	}
+	if len(all) != 3 || all[0].Path != "/home/me/projects/quicklaunch" {
+		t.Errorf("expected newest entry first, got %+v", all)
+	}
+
+	tests := []struct {
+		name   string
+		filter Filter
+		want   int
+	}{
+		{"tile", Filter{TileID: "a"}, 2},
+		{"action", Filter{Action: "shell"}, 1},
+		{"status", Filter{Status: StatusFailed}, 1},
+		{"since", Filter{Since: base.Add(30 * time.Second)}, 2},
+		{"until", Filter{Until: base.Add(30 * time.Second)}, 1},
+		{"query", Filter{Query: "QUICKLAUNCH"}, 1},
+		{"limit", Filter{Limit: 2}, 2},
+	}
+	for _, tt := range tests {
+		got, err := l.Query(tt.filter)
+		if err != nil {
+			t.Fatalf("%s: Query returned error: %v", tt.name, err)
+		}
+		if len(got) != tt.want {
+			t.Errorf("%s: got %d entries, want %d", tt.name, len(got), tt.want)
+		}
+	}
+
+	failed, _ := l.Query(Filter{Status: StatusFailed})
+	if failed[0].ExitCode == nil || *failed[0].ExitCode != 2 {
+		t.Errorf("exit code not preserved: %+v", failed[0])
+	}
+
+	found, ok, err := l.Find(all[1].ID)
+	if err != nil || !ok || found.Target != "make" {
+		t.Errorf("Find = %+v, %v, %v", found, ok, err)
+	}
+}
+
+func TestRotation(t *testing.T) {
+	dir := t.TempDir()
+	l := New(dir, 300, 2)
+
+	var last Entry
+	for i := 0; i < 20; i++ {
+		e, err := l.Append(Entry{Action: "url", Target: "https://example.com", Status: StatusStarted})
+		if err != nil {
+			t.Fatalf("Append returned error: %v", err)
+		}
+		last = e
+	}
+
+	for _, name := range []string{"runs.jsonl", "runs.1.jsonl", "runs.2.jsonl"} {
+		info, err := os.Stat(filepath.Join(dir, name))
+		if err != nil {
+			t.Errorf("expected %s to exist: %v", name, err)
+			continue
+		}
+		if info.Size() > 300 {
+			t.Errorf("%s has %d bytes, exceeds max size", name, info.Size())
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, "runs.3.jsonl")); !os.IsNotExist(err) {
+		t.Error("only 2 backups should be kept")
+	}
+
+	entries, err := l.Query(Filter{})
+	if err != nil {
+		t.Fatalf("Query returned error: %v", err)
+	}
+	if len(entries) == 0 || len(entries) >= 20 {
+		t.Errorf("expected the oldest entries to be dropped, got %d", len(entries))
+	}
+	if entries[0].ID != last.ID {
+		t.Errorf("expected the last appended entry first, got %s", entries[0].ID)
+	}
+}
+
+func TestQuerySkipsMalformedLines(t *testing.T) {
+	dir := t.TempDir()
+	content := `{"id":"1","action":"url","target":"a","status":"started"}
+not json
+{"id":"2","action":"url","target":"b","status":"started"}
+`
+	if err := os.WriteFile(filepath.Join(dir, FileName), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := New(dir, 0, 0).Query(Filter{})
+	if err != nil {
+		t.Fatalf("Query returned error: %v", err)
+	}
+	if len(entries) != 2 || entries[0].ID != "2" {
+		t.Errorf("unexpected entries %+v", entries)
+	}
+}
@@ -0,0 +1,272 @@
+package runner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MaxOutput is the number of bytes kept from stdout and stderr each
+const MaxOutput = 64 << 10
+
+// waitDelay is how long output is still read after a command exited
+const waitDelay = time.Second
+
+// Job describes what a background run was started for
+type Job struct {
+	TileID string
+	Action string
+	Target string
+	Path   string
+}
+
+// Result is the outcome of a background run
+type Result struct {
+	ID         string    `json:"id"`
+	TileID     string    `json:"tileId"`
+	Action     string    `json:"action"`
+	Target     string    `json:"target"`
+	Path       string    `json:"path,omitempty"`
+	Command    string    `json:"command"`
+	Started    time.Time `json:"started"`
+	DurationMs int64     `json:"durationMs"`
+	Running    bool      `json:"running"`
+	ExitCode   int       `json:"exitCode"`
+	Success    bool      `json:"success"`
+	Stdout     string    `json:"stdout"`
+	Stderr     string    `json:"stderr"`
+	Error      string    `json:"error,omitempty"`
+}
+
+// Runner executes commands in the background, captures their output and
+// keeps a bounded history of the results
+type Runner struct {
+	mu       sync.Mutex
+	history  []Result
+	limit    int
+	seq      uint64
+	done     map[string]chan struct{}
+	onFinish func(Result)
+	track    func(Job, *exec.Cmd) func()
+}
+
+// New creates a Runner keeping the last limit results. onFinish is called
+// from the waiting goroutine whenever a started run has finished; runs
+// that fail to start are only recorded, their error is returned by Run.
+func New(limit int, onFinish func(Result)) *Runner {
+	if limit <= 0 {
+		limit = 50
+	}
+	return &Runner{
+		limit:    limit,
+		done:     make(map[string]chan struct{}),
+		onFinish: onFinish,
+	}
+}
+
+// SetTrack sets a function that is called with every started command.
+// The function it returns is called once the command has exited.
+func (r *Runner) SetTrack(track func(job Job, cmd *exec.Cmd) (exited func())) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.track = track
+}
+
+// Run starts cmd and returns its run ID without waiting for it to finish.
+// Stdout and stderr of cmd must not be set by the caller.
+func (r *Runner) Run(job Job, cmd *exec.Cmd) (string, error) {
+	stdout := &tailBuffer{max: MaxOutput}
+	stderr := &tailBuffer{max: MaxOutput}
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	// Children that inherited the output must not keep the run from
+	// finishing
+	cmd.WaitDelay = waitDelay
+
+	res := Result{
+		TileID:  job.TileID,
+		Action:  job.Action,
+		Target:  job.Target,
+		Path:    job.Path,
+		Command: strings.Join(cmd.Args, " "),
+		Started: time.Now(),
+		Running: true,
+	}
+
+	r.mu.Lock()
+	r.seq++
+	res.ID = fmt.Sprintf("%d-%d", res.Started.UnixMilli(), r.seq)
+	r.mu.Unlock()
+
+	if err := cmd.Start(); err != nil {
+		res.Running = false
+		res.ExitCode = -1
+		res.Error = err.Error()
+		r.add(res)
+		return res.ID, err
+	}
+
+	done := make(chan struct{})
+	r.mu.Lock()
+	r.done[res.ID] = done
+	track := r.track
+	r.mu.Unlock()
+
+	exited := func() {}
+	if track != nil {
+		exited = track(job, cmd)
+	}
+
+	r.add(res)
+	go func() {
+		err := cmd.Wait()
+		if errors.Is(err, exec.ErrWaitDelay) {
+			// The command itself succeeded, only its children live on
+			err = nil
+		}
+		exited()
+
+		res.Running = false
+		res.DurationMs = time.Since(res.Started).Milliseconds()
+		res.Stdout = stdout.String()
+		res.Stderr = stderr.String()
+		res.ExitCode = exitCode(cmd, err)
+		res.Success = err == nil
+		if err != nil {
+			res.Error = err.Error()
+		}
+
+		r.update(res)
+		r.mu.Lock()
+		delete(r.done, res.ID)
+		r.mu.Unlock()
+		close(done)
+		r.finish(res)
+	}()
+
+	return res.ID, nil
+}
+
+// History returns the recorded results, newest first. A non-empty tileID
+// restricts the results to that tile.
+func (r *Runner) History(tileID string) []Result {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	results := make([]Result, 0, len(r.history))
+	for i := len(r.history) - 1; i >= 0; i-- {
+		if tileID == "" || r.history[i].TileID == tileID {
+			results = append(results, r.history[i])
+		}
+	}
+	return results
+}
+
+// Wait blocks until the run with the given ID has finished or ctx is done
+// and returns its result, which is still running in the latter case
+func (r *Runner) Wait(ctx context.Context, id string) (Result, bool) {
+	r.mu.Lock()
+	done, ok := r.done[id]
+	r.mu.Unlock()
+	if ok {
+		select {
+		case <-done:
+		case <-ctx.Done():
+		}
+	}
+	return r.Get(id)
+}
+
+// Get returns the result with the given run ID
+func (r *Runner) Get(id string) (Result, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, res := range r.history {
+		if res.ID == id {
+			return res, true
+		}
+	}
+	return Result{}, false
+}
+
+func (r *Runner) add(res Result) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.history = append(r.history, res)
+	if len(r.history) > r.limit {
+		r.history = r.history[len(r.history)-r.limit:]
+	}
+}
+
+func (r *Runner) update(res Result) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := range r.history {
+		if r.history[i].ID == res.ID {
+			r.history[i] = res
+			return
+		}
+	}
+	// Evicted while running, keep the final result anyway
+	r.history = append(r.history, res)
+	if len(r.history) > r.limit {
+		r.history = r.history[len(r.history)-r.limit:]
+	}
+}
+
+func (r *Runner) finish(res Result) {
+	if r.onFinish != nil {
+		r.onFinish(res)
+	}
+}
+
+// exitCode returns the exit code of a finished command, or -1 if the
+// process did not exit normally
+func exitCode(cmd *exec.Cmd, err error) int {
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitCode()
+	}
+	if err != nil || cmd.ProcessState == nil {
+		return -1
+	}
+	return cmd.ProcessState.ExitCode()
+}
+
+// tailBuffer is an io.Writer that keeps only the last max bytes
+type tailBuffer struct {
+	mu        sync.Mutex
+	buf       []byte
+	max       int
+	truncated bool
+}
+
+func (b *tailBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.buf = append(b.buf, p...)
+	if len(b.buf) > b.max {
+		b.buf = append([]byte(nil), b.buf[len(b.buf)-b.max:]...)
+		b.truncated = true
+	}
+	return len(p), nil
+}
+
+// String returns the buffered output, marking cut-off output with "…"
+func (b *tailBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.truncated {
+		return "…" + string(b.buf)
+	}
+	return string(b.buf)
+}
@@ -0,0 +1,169 @@
+package runner
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// waitFor returns the next finished result or fails after a timeout
+func waitFor(t *testing.T, done <-chan Result) Result {
+	t.Helper()
+	select {
+	case res := <-done:
+		return res
+	case <-time.After(5 * time.Second):
+		t.Fatal("run did not finish")
+		return Result{}
+	}
+}
+
+func TestRunCapturesOutputAndExitCode(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses sh")
+	}
+
+	done := make(chan Result, 1)
+	r := New(10, func(res Result) { done <- res })
+
+	id, err := r.Run(Job{TileID: "tile-1", Action: "shell"}, exec.Command("sh", "-c", "echo out; echo err >&2; exit 3"))
+	if err != nil {
+		t.Fatalf("Run returned error: %v", err)
+	}
+
+	res := waitFor(t, done)
+	if res.ID != id || res.TileID != "tile-1" || res.Action != "shell" {
+		t.Errorf("unexpected result identity: %+v", res)
+	}
+	if res.Success || res.ExitCode != 3 || res.Running {
+		t.Errorf("expected finished failure with exit code 3, got %+v", res)
+	}
+	if res.Stdout != "out\n" || res.Stderr != "err\n" {
+		t.Errorf("stdout = %q, stderr = %q", res.Stdout, res.Stderr)
+	}
+
+	if got, ok := r.Get(id); !ok || got.ExitCode != 3 {
+		t.Errorf("history does not contain the final result: %+v", got)
+	}
+}
+
+func TestRunStartError(t *testing.T) {
+	r := New(10, func(res Result) { t.Error("onFinish called for a run that did not start") })
+
+	id, err := r.Run(Job{TileID: "tile"}, exec.Command("/does/not/exist"))
+	if err == nil {
+		t.Fatal("expected an error for a missing binary")
+	}
+	res, _ := r.Get(id)
+	if res.Success || res.ExitCode != -1 || res.Error == "" {
+		t.Errorf("unexpected result %+v", res)
+	}
+}
+
+func TestHistoryLimitAndFilter(t *testing.T) {
+	r := New(3, nil)
+	for i := 0; i < 5; i++ {
+		tile := "a"
+		if i%2 == 1 {
+			tile = "b"
+		}
+		r.add(Result{ID: string(rune('0' + i)), TileID: tile})
+	}
+
+	all := r.History("")
+	if len(all) != 3 || all[0].ID != "4" || all[2].ID != "2" {
+		t.Errorf("unexpected history %+v", all)
+	}
+	if b := r.History("b"); len(b) != 1 || b[0].ID != "3" {
+		t.Errorf("unexpected filtered history %+v", b)
+	}
+}
+
+func TestTailBuffer(t *testing.T) {
+	b := &tailBuffer{max: 4}
+	b.Write([]byte("ab"))
+	b.Write([]byte("cdef"))
+	if got := b.String(); got != "…cdef" {
+		t.Errorf("String() = %q", got)
+	}
+
+	small := &tailBuffer{max: 100}
+	small.Write([]byte(strings.Repeat("x", 10)))
+	if got := small.String(); got != strings.Repeat("x", 10) {
+		t.Errorf("String() = %q", got)
+	}
+}
+
+func TestWait(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses sh")
+	}
+
+	r := New(10, nil)
+	id, err := r.Run(Job{}, exec.Command("sh", "-c", "sleep 0.05; echo done"))
+	if err != nil {
+		t.Fatalf("Run returned error: %v", err)
+	}
+
+	res, ok := r.Wait(context.Background(), id)
+	if !ok || res.Running || !res.Success || res.Stdout != "done\n" {
+		t.Errorf("Wait returned %+v, %v", res, ok)
+	}
+	if _, ok := r.Wait(context.Background(), "unknown"); ok {
+		t.Error("Wait for an unknown ID should report false")
+	}
+}
+
+func TestRunLeavesChildrenBehind(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses sh")
+	}
+
+	done := make(chan Result, 1)
+	r := New(10, func(res Result) { done <- res })
+
+	// The sleeping child keeps stdout open after the shell exited
+	if _, err := r.Run(Job{}, exec.Command("sh", "-c", "sleep 30 & echo $!")); err != nil {
+		t.Fatalf("Run returned error: %v", err)
+	}
+
+	res := waitFor(t, done)
+	if pid, err := strconv.Atoi(strings.TrimSpace(res.Stdout)); err == nil {
+		if p, err := os.FindProcess(pid); err == nil {
+			p.Kill()
+		}
+	}
+	if !res.Success || res.ExitCode != 0 || res.Stdout == "" {
+		t.Errorf("unexpected result %+v", res)
+	}
+}
+
+func TestRunTracksProcess(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses sh")
+	}
+
+	done := make(chan Result, 1)
+	r := New(10, func(res Result) { done <- res })
+
+	var tracked, exited int
+	r.SetTrack(func(job Job, cmd *exec.Cmd) func() {
+		if job.TileID == "tile-1" && cmd.Process != nil {
+			tracked++
+		}
+		return func() { exited++ }
+	})
+
+	if _, err := r.Run(Job{TileID: "tile-1"}, exec.Command("sh", "-c", "exit 0")); err != nil {
+		t.Fatalf("Run returned error: %v", err)
+	}
+	waitFor(t, done)
+	if tracked != 1 || exited != 1 {
+		t.Errorf("tracked = %d, exited = %d, want 1 each", tracked, exited)
+	}
+}
@@ -0,0 +1,119 @@
+package script
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"go.starlark.net/starlark"
+	"go.starlark.net/starlarkstruct"
+)
+
+// http.get(url, headers={}, timeout=None) sends a GET request
+func (r *run) httpGet(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var rawURL string
+	var headers *starlark.Dict
+	var timeout starlark.Value = starlark.None
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "url", &rawURL, "headers?", &headers, "timeout?", &timeout); err != nil {
+		return nil, err
+	}
+	return r.request(b.Name(), http.MethodGet, rawURL, "", headers, timeout)
+}
+
+// http.post(url, body="", headers={}, timeout=None) sends a POST request
+func (r *run) httpPost(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var rawURL, body string
+	var headers *starlark.Dict
+	var timeout starlark.Value = starlark.None
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "url", &rawURL, "body?", &body, "headers?", &headers, "timeout?", &timeout); err != nil {
+		return nil, err
+	}
+	return r.request(b.Name(), http.MethodPost, rawURL, body, headers, timeout)
+}
+
+// request sends a request and returns a response struct. Only invalid
+// arguments fail the script; network errors are returned in the
+// response, so a script can check whether a host is reachable:
+//
+//	if not http.get("https://intranet.example.com", timeout=2).ok: ...
+func (r *run) request(fnname, method, rawURL, body string, headers *starlark.Dict, timeout starlark.Value) (starlark.Value, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("%s: %q is not an http or https URL", fnname, rawURL)
+	}
+	d := r.opts.HTTPTimeout
+	if timeout != starlark.None {
+		if d, err = duration(fnname, timeout); err != nil {
+			return nil, err
+		}
+	}
+	ctx := r.ctx
+	if d > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, d)
+		defer cancel()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), strings.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", fnname, err)
+	}
+	if headers != nil {
+		for _, item := range headers.Items() {
+			k, ok1 := starlark.AsString(item[0])
+			v, ok2 := starlark.AsString(item[1])
+			if !ok1 || !ok2 {
+				return nil, fmt.Errorf("%s: headers must map strings to strings", fnname)
+			}
+			req.Header.Set(k, v)
+		}
+	}
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		if r.ctx.Err() != nil {
+			// The script itself ran out of time
+			return nil, r.ctx.Err()
+		}
+		return response(0, "", nil, err.Error()), nil
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(io.LimitReader(resp.Body, r.opts.maxBody()))
+	if err != nil {
+		return response(resp.StatusCode, "", resp.Header, err.Error()), nil
+	}
+	return response(resp.StatusCode, string(data), resp.Header, ""), nil
+}
+
+// maxBody returns the size limit of response bodies
+func (o Options) maxBody() int64 {
+	if o.MaxBody > 0 {
+		return o.MaxBody
+	}
+	return DefaultOptions.MaxBody
+}
+
+// response builds the value returned by http.get and http.post. ok is
+// set for 2xx status codes; header names are lower case.
+func response(status int, body string, header http.Header, errMsg string) starlark.Value {
+	headers := starlark.NewDict(len(header))
+	names := make([]string, 0, len(header))
+	for name := range header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		headers.SetKey(starlark.String(strings.ToLower(name)), starlark.String(header.Get(name)))
+	}
+	return starlarkstruct.FromStringDict(starlark.String("response"), starlark.StringDict{
+		"ok":      starlark.Bool(errMsg == "" && status >= 200 && status < 300),
+		"status":  starlark.MakeInt(status),
+		"body":    starlark.String(body),
+		"headers": headers,
+		"error":   starlark.String(errMsg),
+	})
+}
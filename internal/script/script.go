@@ -0,0 +1,355 @@
+// Package script runs Starlark scripts of script tiles. Scripts have no
+// access to files or processes of their own; everything they can do goes
+// through the builtins defined here and the Host behind them.
+package script
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"go.starlark.net/lib/json"
+	"go.starlark.net/starlark"
+	"go.starlark.net/starlarkstruct"
+	"go.starlark.net/syntax"
+
+	"quicklaunch/internal/config"
+)
+
+// ActionType is the action type of script tiles, which scripts may not
+// start themselves
+const ActionType = "script"
+
+// ErrTimeout is returned for scripts that ran longer than the timeout
+var ErrTimeout = errors.New("script timed out")
+
+// Host carries out what scripts ask for
+type Host interface {
+	// Launch executes an action with the given target and arguments like
+	// a tile would. With wait set it returns once the process has exited
+	// or ctx is done.
+	Launch(ctx context.Context, action, target string, args []string, wait bool) error
+	// RunTile runs a tile by ID
+	RunTile(id string) error
+	// Tiles returns the configured tiles
+	Tiles() []config.Tile
+	Clipboard() (string, error)
+	SetClipboard(text string) error
+	Notify(title, message string) error
+}
+
+// Options limit what a script may use
+type Options struct {
+	// Timeout bounds a run including its waits
+	Timeout time.Duration
+	// MaxSteps bounds the computation of a run
+	MaxSteps uint64
+	// HTTPTimeout is the timeout of a request without its own
+	HTTPTimeout time.Duration
+	// MaxBody is the size limit of HTTP response bodies, longer ones are
+	// cut off
+	MaxBody int64
+	// Client sends the HTTP requests, nil uses a new client
+	Client *http.Client
+}
+
+// DefaultOptions give scripts two minutes, enough to wait for a VPN, and
+// read up to 1 MB per response
+var DefaultOptions = Options{
+	Timeout:     2 * time.Minute,
+	MaxSteps:    50_000_000,
+	HTTPTimeout: 10 * time.Second,
+	MaxBody:     1 << 20,
+}
+
+// Request is a script to run
+type Request struct {
+	// Name is the script's file name, used in error positions
+	Name   string
+	Source []byte
+	// Tile is the tile running the script
+	Tile config.Tile
+	// Path is the folder chosen in the tile's submenu
+	Path string
+	// Print receives the output of print, it may be nil
+	Print func(msg string)
+}
+
+// fileOptions allow while loops and statements at the top level, which
+// short scripts need; the step limit keeps loops from running forever
+var fileOptions = &syntax.FileOptions{While: true, TopLevelControl: true, GlobalReassign: true}
+
+// Run runs a script until it ends, fails, times out or ctx is done
+func Run(ctx context.Context, host Host, req Request, opts Options) error {
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+
+	r := &run{ctx: ctx, host: host, req: req, opts: opts, client: opts.Client}
+	if r.client == nil {
+		r.client = &http.Client{}
+	}
+
+	thread := &starlark.Thread{
+		Name: req.Name,
+		Print: func(_ *starlark.Thread, msg string) {
+			if req.Print != nil {
+				req.Print(msg)
+			}
+		},
+	}
+	if opts.MaxSteps > 0 {
+		thread.SetMaxExecutionSteps(opts.MaxSteps)
+	}
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			thread.Cancel(ctx.Err().Error())
+		case <-done:
+		}
+	}()
+
+	_, err := starlark.ExecFileOptions(fileOptions, thread, req.Name, req.Source, r.predeclared())
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("%w after %s", ErrTimeout, opts.Timeout)
+	}
+	var evalErr *starlark.EvalError
+	if errors.As(err, &evalErr) {
+		return fmt.Errorf("%s: %s", position(evalErr), evalErr.Msg)
+	}
+	return err
+}
+
+// position returns the innermost position of an error in the script
+func position(err *starlark.EvalError) string {
+	for i := len(err.CallStack) - 1; i >= 0; i-- {
+		if pos := err.CallStack[i].Pos; pos.Line > 0 {
+			return pos.String()
+		}
+	}
+	return "script"
+}
+
+// run is the state of one script run shared by the builtins
+type run struct {
+	ctx    context.Context
+	host   Host
+	req    Request
+	opts   Options
+	client *http.Client
+}
+
+// predeclared returns the names scripts can use
+func (r *run) predeclared() starlark.StringDict {
+	return starlark.StringDict{
+		"launch":   starlark.NewBuiltin("launch", r.launch),
+		"run_tile": starlark.NewBuiltin("run_tile", r.runTile),
+		"tile":     starlark.NewBuiltin("tile", r.tile),
+		"tiles":    starlark.NewBuiltin("tiles", r.tiles),
+		"notify":   starlark.NewBuiltin("notify", r.notify),
+		"sleep":    starlark.NewBuiltin("sleep", r.sleep),
+		"path":     starlark.String(r.req.Path),
+		"clipboard": &starlarkstruct.Module{Name: "clipboard", Members: starlark.StringDict{
+			"get": starlark.NewBuiltin("clipboard.get", r.clipboardGet),
+			"set": starlark.NewBuiltin("clipboard.set", r.clipboardSet),
+		}},
+		"http": &starlarkstruct.Module{Name: "http", Members: starlark.StringDict{
+			"get":  starlark.NewBuiltin("http.get", r.httpGet),
+			"post": starlark.NewBuiltin("http.post", r.httpPost),
+		}},
+		"json": json.Module,
+	}
+}
+
+// launch(action, target="", args=[], wait=False) executes an action
+func (r *run) launch(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var action, target string
+	var list *starlark.List
+	var wait bool
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "action", &action, "target?", &target, "args?", &list, "wait?", &wait); err != nil {
+		return nil, err
+	}
+	if action == ActionType {
+		return nil, fmt.Errorf("%s: scripts cannot start scripts", b.Name())
+	}
+	strs, err := stringArgs(b.Name(), list)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.host.Launch(r.ctx, action, target, strs, wait); err != nil {
+		return nil, fmt.Errorf("%s: %w", b.Name(), err)
+	}
+	return starlark.None, nil
+}
+
+// run_tile(name) runs a tile by ID or name
+func (r *run) runTile(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var ref string
+	if err := starlark.UnpackPositionalArgs(b.Name(), args, kwargs, 1, &ref); err != nil {
+		return nil, err
+	}
+	t, ok := r.findTile(ref)
+	if !ok {
+		return nil, fmt.Errorf("%s: tile %q not found", b.Name(), ref)
+	}
+	if t.Action == ActionType {
+		return nil, fmt.Errorf("%s: scripts cannot start scripts", b.Name())
+	}
+	if err := r.host.RunTile(t.ID); err != nil {
+		return nil, fmt.Errorf("%s: %w", b.Name(), err)
+	}
+	return starlark.None, nil
+}
+
+// tile(name=None) returns a tile by ID or name, or the running tile
+func (r *run) tile(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var ref string
+	if err := starlark.UnpackPositionalArgs(b.Name(), args, kwargs, 0, &ref); err != nil {
+		return nil, err
+	}
+	if ref == "" {
+		return tileValue(r.req.Tile), nil
+	}
+	t, ok := r.findTile(ref)
+	if !ok {
+		return starlark.None, nil
+	}
+	return tileValue(t), nil
+}
+
+// tiles() returns all tiles
+func (r *run) tiles(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	if err := starlark.UnpackPositionalArgs(b.Name(), args, kwargs, 0); err != nil {
+		return nil, err
+	}
+	var list []starlark.Value
+	for _, t := range r.host.Tiles() {
+		list = append(list, tileValue(t))
+	}
+	return starlark.NewList(list), nil
+}
+
+// findTile finds a tile by ID, or else by name ignoring case
+func (r *run) findTile(ref string) (config.Tile, bool) {
+	tiles := r.host.Tiles()
+	for _, t := range tiles {
+		if t.ID == ref {
+			return t, true
+		}
+	}
+	for _, t := range tiles {
+		if strings.EqualFold(t.Name, ref) {
+			return t, true
+		}
+	}
+	return config.Tile{}, false
+}
+
+// tileValue exposes the configuration of a tile to scripts
+func tileValue(t config.Tile) starlark.Value {
+	return starlarkstruct.FromStringDict(starlark.String("tile"), starlark.StringDict{
+		"id":       starlark.String(t.ID),
+		"name":     starlark.String(t.Name),
+		"action":   starlark.String(t.Action),
+		"target":   starlark.String(t.Target),
+		"args":     stringList(t.Args),
+		"work_dir": starlark.String(t.WorkDir),
+		"tags":     stringList(t.Tags),
+		"keyword":  starlark.String(t.Keyword),
+		"enabled":  starlark.Bool(t.Enabled),
+	})
+}
+
+// notify(title, message="") shows a notification
+func (r *run) notify(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var title, message string
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "title", &title, "message?", &message); err != nil {
+		return nil, err
+	}
+	if err := r.host.Notify(title, message); err != nil {
+		return nil, fmt.Errorf("%s: %w", b.Name(), err)
+	}
+	return starlark.None, nil
+}
+
+// sleep(seconds) waits, e.g. for a connection to come up
+func (r *run) sleep(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var seconds starlark.Value
+	if err := starlark.UnpackPositionalArgs(b.Name(), args, kwargs, 1, &seconds); err != nil {
+		return nil, err
+	}
+	d, err := duration(b.Name(), seconds)
+	if err != nil {
+		return nil, err
+	}
+	select {
+	case <-time.After(d):
+		return starlark.None, nil
+	case <-r.ctx.Done():
+		return nil, r.ctx.Err()
+	}
+}
+
+// clipboard.get() returns the text on the clipboard
+func (r *run) clipboardGet(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	if err := starlark.UnpackPositionalArgs(b.Name(), args, kwargs, 0); err != nil {
+		return nil, err
+	}
+	text, err := r.host.Clipboard()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", b.Name(), err)
+	}
+	return starlark.String(text), nil
+}
+
+// clipboard.set(text) replaces the text on the clipboard
+func (r *run) clipboardSet(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var text string
+	if err := starlark.UnpackPositionalArgs(b.Name(), args, kwargs, 1, &text); err != nil {
+		return nil, err
+	}
+	if err := r.host.SetClipboard(text); err != nil {
+		return nil, fmt.Errorf("%s: %w", b.Name(), err)
+	}
+	return starlark.None, nil
+}
+
+// stringArgs converts a list of strings, nil is an empty list
+func stringArgs(fnname string, list *starlark.List) ([]string, error) {
+	if list == nil {
+		return nil, nil
+	}
+	strs := make([]string, 0, list.Len())
+	for i := range list.Len() {
+		s, ok := starlark.AsString(list.Index(i))
+		if !ok {
+			return nil, fmt.Errorf("%s: args[%d] is %s, want string", fnname, i, list.Index(i).Type())
+		}
+		strs = append(strs, s)
+	}
+	return strs, nil
+}
+
+func stringList(strs []string) *starlark.List {
+	values := make([]starlark.Value, len(strs))
+	for i, s := range strs {
+		values[i] = starlark.String(s)
+	}
+	return starlark.NewList(values)
+}
+
+// duration converts a number of seconds
+func duration(fnname string, v starlark.Value) (time.Duration, error) {
+	f, ok := starlark.AsFloat(v)
+	if !ok || f < 0 {
+		return 0, fmt.Errorf("%s: want a number of seconds >= 0, got %s", fnname, v)
+	}
+	return time.Duration(f * float64(time.Second)), nil
+}
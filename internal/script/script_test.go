@@ -0,0 +1,210 @@
+package script
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"quicklaunch/internal/config"
+)
+
+// fakeHost records what scripts ask for
+type fakeHost struct {
+	launched  []string
+	ran       []string
+	notified  []string
+	clipboard string
+	tiles     []config.Tile
+	// running is closed when waited launches may return
+	running chan struct{}
+}
+
+func (h *fakeHost) Launch(ctx context.Context, action, target string, args []string, wait bool) error {
+	h.launched = append(h.launched, strings.Join(append([]string{action, target}, args...), " "))
+	if wait && h.running != nil {
+		select {
+		case <-h.running:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+func (h *fakeHost) RunTile(id string) error {
+	h.ran = append(h.ran, id)
+	return nil
+}
+
+func (h *fakeHost) Tiles() []config.Tile { return h.tiles }
+
+func (h *fakeHost) Clipboard() (string, error) { return h.clipboard, nil }
+
+func (h *fakeHost) SetClipboard(text string) error {
+	h.clipboard = text
+	return nil
+}
+
+func (h *fakeHost) Notify(title, message string) error {
+	h.notified = append(h.notified, title+": "+message)
+	return nil
+}
+
+func runScript(h *fakeHost, src string, opts Options) error {
+	return Run(context.Background(), h, Request{Name: "test.star", Source: []byte(src), Tile: config.Tile{ID: "tile-1", Name: "Intranet"}}, opts)
+}
+
+func TestRunConditional(t *testing.T) {
+	// The intranet is reachable once the VPN is up, after two failures
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) <= 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer srv.Close()
+
+	h := &fakeHost{}
+	err := runScript(h, `
+url = "`+srv.URL+`"
+if not http.get(url).ok:
+    launch("app", "vpn-client", ["--connect"])
+    while not http.get(url, timeout=1).ok:
+        sleep(0.01)
+launch("url", url)
+`, DefaultOptions)
+	if err != nil {
+		t.Fatalf("Run returned error: %v", err)
+	}
+	want := []string{"app vpn-client --connect", "url " + srv.URL}
+	if !reflect.DeepEqual(h.launched, want) {
+		t.Errorf("launched %q, want %q", h.launched, want)
+	}
+}
+
+func TestRunAPI(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"user": "` + r.Header.Get("X-User") + `"}`))
+	}))
+	defer srv.Close()
+
+	h := &fakeHost{
+		clipboard: "ticket 42",
+		tiles: []config.Tile{
+			{ID: "tile-1", Name: "Intranet", Action: "script"},
+			{ID: "tile-2", Name: "Mail", Action: "app", Target: "thunderbird", Args: []string{"-mail"}},
+		},
+	}
+	var printed []string
+	err := Run(context.Background(), h, Request{
+		Name: "test.star",
+		Source: []byte(`
+clipboard.set(clipboard.get().upper())
+resp = http.get("` + srv.URL + `", headers={"X-User": "anna"})
+user = json.decode(resp.body)["user"]
+print(user, resp.headers["content-type"])
+notify(tile().name, "Hallo " + user)
+mail = tile("mail")
+if mail.action == "app" and mail.args == ["-mail"]:
+    run_tile(mail.name)
+print(len(tiles()), tile("nope"), path)
+`),
+		Tile:  h.tiles[0],
+		Path:  "/tmp",
+		Print: func(msg string) { printed = append(printed, msg) },
+	}, DefaultOptions)
+	if err != nil {
+		t.Fatalf("Run returned error: %v", err)
+	}
+
+	if h.clipboard != "TICKET 42" {
+		t.Errorf("clipboard = %q", h.clipboard)
+	}
+	if want := []string{"Intranet: Hallo anna"}; !reflect.DeepEqual(h.notified, want) {
+		t.Errorf("notified %q, want %q", h.notified, want)
+	}
+	if want := []string{"tile-2"}; !reflect.DeepEqual(h.ran, want) {
+		t.Errorf("ran %q, want %q", h.ran, want)
+	}
+	if want := []string{"anna application/json", "2 None /tmp"}; !reflect.DeepEqual(printed, want) {
+		t.Errorf("printed %q, want %q", printed, want)
+	}
+}
+
+func TestRunHTTPErrors(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(strings.Repeat("x", 100)))
+	}))
+	url := srv.URL
+	srv.Close()
+
+	h := &fakeHost{}
+	// Unreachable hosts are not errors, the script decides
+	if err := runScript(h, `
+resp = http.get("`+url+`")
+if resp.ok or resp.status != 0 or not resp.error:
+    fail("unexpected response", resp)
+`, DefaultOptions); err != nil {
+		t.Errorf("Run returned error for an unreachable host: %v", err)
+	}
+
+	err := runScript(h, `http.get("file:///etc/passwd")`, DefaultOptions)
+	if err == nil || !strings.Contains(err.Error(), "not an http or https URL") {
+		t.Errorf("expected an error for a file URL, got %v", err)
+	}
+}
+
+func TestRunRefusesScripts(t *testing.T) {
+	h := &fakeHost{tiles: []config.Tile{{ID: "tile-1", Name: "Other", Action: "script"}}}
+	for _, src := range []string{`launch("script", "other.star")`, `run_tile("Other")`} {
+		err := runScript(h, src, DefaultOptions)
+		if err == nil || !strings.Contains(err.Error(), "scripts cannot start scripts") {
+			t.Errorf("%s: expected an error, got %v", src, err)
+		}
+	}
+	if len(h.launched)+len(h.ran) != 0 {
+		t.Errorf("launched %q and ran %q", h.launched, h.ran)
+	}
+}
+
+func TestRunErrors(t *testing.T) {
+	h := &fakeHost{}
+	err := runScript(h, "x = 1\nlaunch()\n", DefaultOptions)
+	if err == nil || !strings.HasPrefix(err.Error(), "test.star:2:7: ") {
+		t.Errorf("expected an error at line 2, got %v", err)
+	}
+
+	opts := DefaultOptions
+	opts.MaxSteps = 10000
+	if err := runScript(h, "while True:\n    pass\n", opts); err == nil || !strings.Contains(err.Error(), "too many steps") {
+		t.Errorf("expected the step limit, got %v", err)
+	}
+
+	opts = DefaultOptions
+	opts.Timeout = 50 * time.Millisecond
+	started := time.Now()
+	if err := runScript(h, "sleep(10)\n", opts); !errors.Is(err, ErrTimeout) {
+		t.Errorf("expected ErrTimeout, got %v", err)
+	}
+	if time.Since(started) > 5*time.Second {
+		t.Error("sleep was not interrupted by the timeout")
+	}
+
+	// Waiting for a launched program counts towards the timeout
+	h.running = make(chan struct{})
+	defer close(h.running)
+	started = time.Now()
+	if err := runScript(h, `launch("app", "backup", wait=True)`, opts); !errors.Is(err, ErrTimeout) {
+		t.Errorf("expected ErrTimeout while waiting, got %v", err)
+	}
+	if time.Since(started) > 5*time.Second {
+		t.Error("the wait was not interrupted by the timeout")
+	}
+}
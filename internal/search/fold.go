@@ -0,0 +1,141 @@
+package search
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// foldTable maps letters with diacritics and ligatures to their base
+// letters. Umlauts fold to the plain vowel, so "uber" finds "Über".
+var foldTable = map[rune]string{
+	'à': "a", 'á': "a", 'â': "a", 'ã': "a", 'ä': "a", 'å': "a", 'ā': "a", 'ă': "a", 'ą': "a",
+	'ç': "c", 'ć': "c", 'č': "c",
+	'ď': "d", 'đ': "d",
+	'è': "e", 'é': "e", 'ê': "e", 'ë': "e", 'ē': "e", 'ė': "e", 'ę': "e", 'ě': "e",
+	'ì': "i", 'í': "i", 'î': "i", 'ï': "i", 'ī': "i", 'į': "i", 'ı': "i",
+	'ł': "l", 'ľ': "l",
+	'ñ': "n", 'ń': "n", 'ň': "n",
+	'ò': "o", 'ó': "o", 'ô': "o", 'õ': "o", 'ö': "o", 'ø': "o", 'ō': "o", 'ő': "o",
+	'ř': "r",
+	'ś': "s", 'š': "s", 'ş': "s",
+	'ť': "t", 'ţ': "t",
+	'ù': "u", 'ú': "u", 'û': "u", 'ü': "u", 'ū': "u", 'ů': "u", 'ű': "u",
+	'ý': "y", 'ÿ': "y",
+	'ź': "z", 'ż': "z", 'ž': "z",
+	'ß': "ss", 'æ': "ae", 'œ': "oe",
+}
+
+// folded is a text prepared for matching: lower-case runes without
+// diacritics, each with the index of the rune in the original text
+type folded struct {
+	runes []rune
+	orig  []int
+	// boundary marks runes that start a word in the original text
+	boundary []bool
+}
+
+// fold prepares text for matching
+func fold(text string) *folded {
+	f := &folded{
+		runes:    make([]rune, 0, len(text)),
+		orig:     make([]int, 0, len(text)),
+		boundary: make([]bool, 0, len(text)),
+	}
+	var prev rune
+	i := 0
+	for _, r := range text {
+		start := i == 0 || isSeparator(prev) ||
+			(isUpper(r) && isLower(prev)) ||
+			(isDigit(r) && !isDigit(prev))
+
+		lower := toLower(r)
+		if lower < utf8.RuneSelf {
+			f.runes = append(f.runes, lower)
+			f.orig = append(f.orig, i)
+			f.boundary = append(f.boundary, start)
+		} else if s, ok := foldTable[lower]; ok {
+			for j, fr := range s {
+				f.runes = append(f.runes, fr)
+				f.orig = append(f.orig, i)
+				f.boundary = append(f.boundary, start && j == 0)
+			}
+		} else {
+			f.runes = append(f.runes, lower)
+			f.orig = append(f.orig, i)
+			f.boundary = append(f.boundary, start)
+		}
+		prev = r
+		i++
+	}
+	return f
+}
+
+// The helpers below avoid the Unicode tables for ASCII, most tile names
+// and paths are plain ASCII
+
+// isSeparator reports whether r separates words, e.g. in paths
+func isSeparator(r rune) bool {
+	if r < utf8.RuneSelf {
+		return r != '\'' && !isLower(r) && !isUpper(r) && !isDigit(r) && r != 0
+	}
+	return unicode.IsSpace(r) || unicode.IsPunct(r) || unicode.IsSymbol(r)
+}
+
+func isUpper(r rune) bool {
+	if r < utf8.RuneSelf {
+		return 'A' <= r && r <= 'Z'
+	}
+	return unicode.IsUpper(r)
+}
+
+func isLower(r rune) bool {
+	if r < utf8.RuneSelf {
+		return 'a' <= r && r <= 'z'
+	}
+	return unicode.IsLower(r)
+}
+
+func isDigit(r rune) bool {
+	if r < utf8.RuneSelf {
+		return '0' <= r && r <= '9'
+	}
+	return unicode.IsDigit(r)
+}
+
+func toLower(r rune) rune {
+	if r < utf8.RuneSelf {
+		if isUpper(r) {
+			r += 'a' - 'A'
+		}
+		return r
+	}
+	return unicode.ToLower(r)
+}
+
+// contains reports whether the folded runes of term appear in order in
+// text. It folds text on the fly without allocating, to rule out entries
+// cheaply before they are prepared.
+func contains(term []rune, text string) bool {
+	ti := 0
+	for _, r := range text {
+		lower := toLower(r)
+		if lower >= utf8.RuneSelf {
+			if s, ok := foldTable[lower]; ok {
+				for _, fr := range s {
+					if fr == term[ti] {
+						if ti++; ti == len(term) {
+							return true
+						}
+					}
+				}
+				continue
+			}
+		}
+		if lower == term[ti] {
+			if ti++; ti == len(term) {
+				return true
+			}
+		}
+	}
+	return false
+}
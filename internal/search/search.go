@@ -0,0 +1,339 @@
+package search
+
+import (
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Scoring of a fuzzy match. Every matched rune scores, runes at word
+// boundaries and runs of consecutive runes score extra, gaps cost. Long
+// gaps cost no more than short ones, so acronyms like "vsc" still win.
+const (
+	scoreMatch       = 16
+	bonusFirst       = 12
+	bonusBoundary    = 8
+	bonusConsecutive = 6
+	bonusPrefix      = 10
+	bonusExact       = 20
+	penaltyGapStart  = 3
+	penaltyGap       = 1
+	maxGapExtension  = 3
+)
+
+// Field is a searchable text of an entry. Weight scales the score of
+// matches in the field, in percent.
+type Field struct {
+	Name   string
+	Text   string
+	Weight int
+}
+
+// Entry is a searchable item with one or more fields. Boost is added to
+// the score of a matching entry, e.g. for frequently used items.
+type Entry struct {
+	Fields []Field
+	Boost  int
+}
+
+// Range is a matched part of a field as rune offsets, End is exclusive.
+// Rune offsets correspond to code points, e.g. Array.from(text) in JS.
+type Range struct {
+	Start int `json:"start"`
+	End   int `json:"end"`
+}
+
+// Highlight lists the matched ranges of one field
+type Highlight struct {
+	Field  string  `json:"field"`
+	Ranges []Range `json:"ranges"`
+}
+
+// Hit is a matching entry. Index is the position of the entry in the
+// slice passed to Rank.
+type Hit struct {
+	Index      int
+	Score      int
+	Highlights []Highlight
+}
+
+// Match matches query fuzzily against text: the runes of every word in
+// the query must appear in text in order, ignoring case and diacritics.
+// It returns the score and the matched ranges of text.
+func Match(query, text string) (int, []Range, bool) {
+	terms := strings.Fields(query)
+	if len(terms) == 0 {
+		return 0, nil, false
+	}
+
+	t := fold(text)
+	total := 0
+	var positions []int
+	for _, term := range terms {
+		score, pos, ok := matchTerm(fold(term).runes, t)
+		if !ok {
+			return 0, nil, false
+		}
+		total += score
+		positions = append(positions, pos...)
+	}
+	return total, ranges(t, positions), true
+}
+
+// Rank matches query against entries and returns up to limit hits, best
+// first. Every word of the query must match some field of an entry; each
+// word counts with its best field. A limit <= 0 returns all hits.
+func Rank(query string, entries []Entry, limit int) []Hit {
+	return NewIndex().Rank(query, entries, limit)
+}
+
+// Index ranks entries like Rank and caches the prepared field texts, so
+// searching the same entries on every keystroke only prepares texts that
+// changed
+type Index struct {
+	mu    sync.Mutex
+	texts map[string]*folded
+}
+
+// NewIndex creates an empty Index
+func NewIndex() *Index {
+	return &Index{texts: make(map[string]*folded)}
+}
+
+// Rank ranks entries like the package-level Rank
+func (x *Index) Rank(query string, entries []Entry, limit int) []Hit {
+	var terms [][]rune
+	for _, term := range strings.Fields(query) {
+		terms = append(terms, fold(term).runes)
+	}
+	if len(terms) == 0 {
+		return nil
+	}
+
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	fields := 0
+	prepare := func(text string) *folded {
+		fields++
+		f, ok := x.texts[text]
+		if !ok {
+			f = fold(text)
+			x.texts[text] = f
+		}
+		return f
+	}
+	defer func() { x.prune(entries, fields) }()
+
+	var hits []Hit
+	for i, e := range entries {
+		if hit, ok := rankEntry(terms, e, prepare, false); ok {
+			hit.Index = i
+			hits = append(hits, hit)
+		}
+	}
+
+	sort.SliceStable(hits, func(i, j int) bool {
+		return hits[i].Score > hits[j].Score
+	})
+	if limit > 0 && len(hits) > limit {
+		hits = hits[:limit]
+	}
+
+	// Highlights are only worth computing for the hits returned
+	for i := range hits {
+		hit, _ := rankEntry(terms, entries[hits[i].Index], prepare, true)
+		hits[i].Highlights = hit.Highlights
+	}
+	return hits
+}
+
+// prune drops cached texts of entries that are gone once the cache has
+// grown to twice the number of searched fields
+func (x *Index) prune(entries []Entry, fields int) {
+	if len(x.texts) <= 2*fields {
+		return
+	}
+	texts := make(map[string]*folded, fields)
+	for _, e := range entries {
+		for _, f := range e.Fields {
+			if t, ok := x.texts[f.Text]; ok {
+				texts[f.Text] = t
+			}
+		}
+	}
+	x.texts = texts
+}
+
+// rankEntry scores all terms against the fields of an entry, with
+// highlights if asked for
+func rankEntry(terms [][]rune, e Entry, prepare func(string) *folded, highlight bool) (Hit, bool) {
+	for _, term := range terms {
+		found := false
+		for _, f := range e.Fields {
+			if found = contains(term, f.Text); found {
+				break
+			}
+		}
+		if !found {
+			return Hit{}, false
+		}
+	}
+
+	texts := make([]*folded, len(e.Fields))
+	for i, f := range e.Fields {
+		texts[i] = prepare(f.Text)
+	}
+
+	hit := Hit{Score: e.Boost}
+	var positions [][]int
+	if highlight {
+		positions = make([][]int, len(e.Fields))
+	}
+	for _, term := range terms {
+		best, bestField := 0, -1
+		var bestPos []int
+		for i, f := range e.Fields {
+			score, pos, ok := matchTerm(term, texts[i])
+			if !ok {
+				continue
+			}
+			if score = score * f.Weight / 100; bestField < 0 || score > best {
+				best, bestField, bestPos = score, i, pos
+			}
+		}
+		if bestField < 0 {
+			return Hit{}, false
+		}
+		hit.Score += best
+		if highlight {
+			positions[bestField] = append(positions[bestField], bestPos...)
+		}
+	}
+
+	for i, pos := range positions {
+		if len(pos) > 0 {
+			hit.Highlights = append(hit.Highlights, Highlight{Field: e.Fields[i].Name, Ranges: ranges(texts[i], pos)})
+		}
+	}
+	return hit, true
+}
+
+// matchTerm finds term in t and returns the score and the matched
+// positions in t. Like fzf's first algorithm it finds the first
+// occurrence, then shrinks it from the end to the shortest match.
+func matchTerm(term []rune, t *folded) (int, []int, bool) {
+	if len(term) == 0 || len(term) > len(t.runes) {
+		return 0, nil, false
+	}
+
+	// Forward: end of the first occurrence
+	ti, end := 0, -1
+	for i, r := range t.runes {
+		if r == term[ti] {
+			ti++
+			if ti == len(term) {
+				end = i
+				break
+			}
+		}
+	}
+	if end < 0 {
+		return 0, nil, false
+	}
+
+	// Backward: latest start that still contains the term
+	ti, start := len(term)-1, 0
+	for i := end; i >= 0; i-- {
+		if t.runes[i] == term[ti] {
+			ti--
+			if ti < 0 {
+				start = i
+				break
+			}
+		}
+	}
+
+	// Within the window take consecutive runes where possible and prefer
+	// word boundaries over the first occurrence of a rune
+	positions := make([]int, 0, len(term))
+	i := start
+	for ti := range term {
+		pos := -1
+		for j := i; j <= end; j++ {
+			if t.runes[j] != term[ti] || !canFinish(term[ti+1:], t.runes[j+1:end+1]) {
+				continue
+			}
+			if pos < 0 {
+				pos = j
+			}
+			if t.boundary[j] || (j == i && ti > 0) {
+				pos = j
+				break
+			}
+		}
+		positions = append(positions, pos)
+		i = pos + 1
+	}
+
+	score := 0
+	for k, pos := range positions {
+		score += scoreMatch
+		switch {
+		case pos == 0:
+			score += bonusFirst
+		case t.boundary[pos]:
+			score += bonusBoundary
+		}
+		if k > 0 {
+			if gap := pos - positions[k-1] - 1; gap == 0 {
+				score += bonusConsecutive
+			} else {
+				score -= penaltyGapStart + min(gap-1, maxGapExtension)*penaltyGap
+			}
+		}
+	}
+	if positions[0] == 0 && positions[len(positions)-1] == len(term)-1 {
+		score += bonusPrefix
+		if len(term) == len(t.runes) {
+			score += bonusExact
+		}
+	}
+	return score, positions, true
+}
+
+// canFinish reports whether term appears in order in runes
+func canFinish(term, runes []rune) bool {
+	ti := 0
+	for _, r := range runes {
+		if ti == len(term) {
+			break
+		}
+		if r == term[ti] {
+			ti++
+		}
+	}
+	return ti == len(term)
+}
+
+// ranges converts matched positions of a folded text to merged rune
+// ranges of the original text
+func ranges(t *folded, positions []int) []Range {
+	orig := make([]int, 0, len(positions))
+	for _, p := range positions {
+		orig = append(orig, t.orig[p])
+	}
+	sort.Ints(orig)
+
+	var rs []Range
+	for _, o := range orig {
+		if n := len(rs); n > 0 && o <= rs[n-1].End {
+			if o == rs[n-1].End {
+				rs[n-1].End++
+			}
+			continue
+		}
+		rs = append(rs, Range{Start: o, End: o + 1})
+	}
+	return rs
+}
@@ -0,0 +1,118 @@
+package search
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		query, text string
+		want        []Range
+	}{
+		{"vsc", "Visual Studio Code", []Range{{0, 1}, {7, 8}, {14, 15}}},
+		{"code", "Visual Studio Code", []Range{{14, 18}}},
+		{"uber", "Über uns", []Range{{0, 4}}},
+		{"strasse", "Straße", []Range{{0, 6}}},
+		{"gross", "Größe", []Range{{0, 4}}},
+		{"grosz", "Größe", nil},
+		{"ssh server", "Server per SSH", []Range{{0, 6}, {11, 14}}},
+		{"qlr", "quicklaunch/README.md", []Range{{0, 1}, {5, 6}, {12, 13}}},
+		{"xyz", "Visual Studio Code", nil},
+	}
+	for _, tt := range tests {
+		_, got, ok := Match(tt.query, tt.text)
+		if ok != (tt.want != nil) || !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Match(%q, %q) = %v, %v, want %v", tt.query, tt.text, got, ok, tt.want)
+		}
+	}
+}
+
+func TestContains(t *testing.T) {
+	tests := []struct {
+		term, text string
+		want       bool
+	}{
+		{"ubs", "Übersicht", true},
+		{"strasse", "Straße", true},
+		{"srs", "Straße", true},
+		{"sst", "Straße", false},
+		{"code", "Visual Studio Code", true},
+		{"xyz", "Visual Studio Code", false},
+	}
+	for _, tt := range tests {
+		if got := contains(fold(tt.term).runes, tt.text); got != tt.want {
+			t.Errorf("contains(%q, %q) = %v, want %v", tt.term, tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestMatchScores(t *testing.T) {
+	better := [][3]string{
+		// query, better text, worse text
+		{"term", "Terminal", "Interim Manager"},
+		{"vsc", "Visual Studio Code", "vs-arcade"},
+		{"doc", "Docs", "Dokumente Ordner Cache"},
+		{"git", "Git", "GitHub Desktop"},
+		{"ges", "Geschäftlich", "Ungesehen"},
+	}
+	for _, b := range better {
+		s1, _, ok1 := Match(b[0], b[1])
+		s2, _, ok2 := Match(b[0], b[2])
+		if !ok1 || !ok2 || s1 <= s2 {
+			t.Errorf("%q: %q scored %d, %q scored %d", b[0], b[1], s1, b[2], s2)
+		}
+	}
+}
+
+func TestRank(t *testing.T) {
+	entries := []Entry{
+		{Fields: []Field{{Name: "name", Text: "Downloads", Weight: 100}, {Name: "target", Text: "~/Downloads", Weight: 60}}},
+		{Fields: []Field{{Name: "name", Text: "Rechnungen", Weight: 100}, {Name: "tags", Text: "büro finanzen", Weight: 80}}},
+		{Fields: []Field{{Name: "name", Text: "Browser", Weight: 100}, {Name: "target", Text: "https://duckduckgo.com", Weight: 60}}},
+	}
+
+	hits := Rank("buro", entries, 0)
+	if len(hits) != 1 || hits[0].Index != 1 {
+		t.Fatalf("expected the tagged entry, got %+v", hits)
+	}
+	if h := hits[0].Highlights; len(h) != 1 || h[0].Field != "tags" || !reflect.DeepEqual(h[0].Ranges, []Range{{0, 4}}) {
+		t.Errorf("unexpected highlights %+v", h)
+	}
+
+	hits = Rank("do", entries, 0)
+	if len(hits) != 2 || hits[0].Index != 0 {
+		t.Fatalf("name matches should rank first, got %+v", hits)
+	}
+
+	if hits := Rank("re fin", entries, 0); len(hits) != 1 || len(hits[0].Highlights) != 2 {
+		t.Errorf("words matching different fields should combine, got %+v", hits)
+	}
+	entries[2].Boost = 100
+	if hits := Rank("do", entries, 0); hits[0].Index != 2 {
+		t.Errorf("boosted entry should rank first, got %+v", hits)
+	}
+	if hits := Rank("do", entries, 1); len(hits) != 1 {
+		t.Errorf("limit not applied: %+v", hits)
+	}
+	if hits := Rank("  ", entries, 0); hits != nil {
+		t.Errorf("empty query should not match, got %+v", hits)
+	}
+}
+
+func BenchmarkRank(b *testing.B) {
+	entries := make([]Entry, 5000)
+	for i := range entries {
+		entries[i] = Entry{Fields: []Field{
+			{Name: "name", Text: fmt.Sprintf("Projekt Übersicht %d", i), Weight: 100},
+			{Name: "target", Text: fmt.Sprintf("C:\\Users\\jörg\\Documents\\Projekte\\kunde-%d\\README.md", i), Weight: 60},
+		}}
+	}
+	x := NewIndex()
+	queries := []string{"p", "pr", "pro", "proj", "proj r", "proj re", "proj rea", "proj read"}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		x.Rank(queries[i%len(queries)], entries, 50)
+	}
+}
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"bytes"
+	"sync"
+	"time"
+)
+
+// Streams a log line was written to
+const (
+	StreamStdout = "stdout"
+	StreamStderr = "stderr"
+)
+
+// maxLineLength cuts off overly long lines without a line break
+const maxLineLength = 4 << 10
+
+// LogLine is a line of service output
+type LogLine struct {
+	Time   time.Time `json:"time"`
+	Stream string    `json:"stream"`
+	Text   string    `json:"text"`
+}
+
+// logBuffer keeps the last lines written by a service
+type logBuffer struct {
+	mu      sync.Mutex
+	buf     []LogLine
+	max     int
+	partial map[string]*bytes.Buffer
+}
+
+func newLogBuffer(max int) *logBuffer {
+	return &logBuffer{max: max, partial: make(map[string]*bytes.Buffer)}
+}
+
+// writer returns a writer adding complete lines for stream
+func (b *logBuffer) writer(stream string) *streamWriter {
+	return &streamWriter{buf: b, stream: stream}
+}
+
+func (b *logBuffer) write(stream string, p []byte) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	pending := b.partial[stream]
+	if pending == nil {
+		pending = &bytes.Buffer{}
+		b.partial[stream] = pending
+	}
+	pending.Write(p)
+
+	for {
+		line, err := pending.ReadBytes('\n')
+		if err != nil {
+			// Keep the incomplete line for the next write
+			pending.Reset()
+			pending.Write(line)
+			if pending.Len() > maxLineLength {
+				b.add(stream, pending.String())
+				pending.Reset()
+			}
+			return
+		}
+		b.add(stream, string(bytes.TrimRight(line, "\r\n")))
+	}
+}
+
+// flush adds incomplete lines, e.g. once the process has exited
+func (b *logBuffer) flush() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for stream, pending := range b.partial {
+		if pending.Len() > 0 {
+			b.add(stream, pending.String())
+			pending.Reset()
+		}
+	}
+}
+
+func (b *logBuffer) add(stream, text string) {
+	b.buf = append(b.buf, LogLine{Time: time.Now(), Stream: stream, Text: text})
+	if len(b.buf) > b.max {
+		b.buf = b.buf[len(b.buf)-b.max:]
+	}
+}
+
+func (b *logBuffer) lines() []LogLine {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]LogLine{}, b.buf...)
+}
+
+// streamWriter writes to one stream of a logBuffer
+type streamWriter struct {
+	buf    *logBuffer
+	stream string
+}
+
+func (w *streamWriter) Write(p []byte) (int, error) {
+	w.buf.write(w.stream, p)
+	return len(p), nil
+}
@@ -0,0 +1,350 @@
+package service
+
+import (
+	"errors"
+	"os/exec"
+	"sort"
+	"sync"
+	"time"
+
+	"quicklaunch/internal/procs"
+)
+
+// Statuses of a supervised service
+const (
+	StatusRunning    = "running"
+	StatusRestarting = "restarting"
+	StatusStopped    = "stopped"
+)
+
+// Service is a long-running command kept alive by a Supervisor
+type Service struct {
+	// ID identifies the service, usually the tile ID
+	ID   string
+	Name string
+	// Command builds the command for every start; it must not set
+	// Stdout or Stderr
+	Command func() (*exec.Cmd, error)
+}
+
+// State is the current state of a service
+type State struct {
+	ID      string    `json:"id"`
+	Name    string    `json:"name"`
+	Status  string    `json:"status"`
+	PID     int       `json:"pid,omitempty"`
+	Started time.Time `json:"started,omitempty"`
+	// Restarts counts the restarts since the service was started
+	Restarts    int       `json:"restarts"`
+	ExitCode    *int      `json:"exitCode,omitempty"`
+	LastError   string    `json:"lastError,omitempty"`
+	NextRestart time.Time `json:"nextRestart,omitempty"`
+}
+
+// Options configure the restart behaviour and log size of a Supervisor
+type Options struct {
+	// MinBackoff is the delay before the first restart; it doubles with
+	// every crash up to MaxBackoff
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+	// StableAfter resets the backoff once a service ran this long
+	StableAfter time.Duration
+	// LogLines is the number of output lines kept per service
+	LogLines int
+}
+
+// DefaultOptions restart crashed services after 1s, backing off up to a
+// minute, and keep the last 1000 lines of output
+var DefaultOptions = Options{
+	MinBackoff:  time.Second,
+	MaxBackoff:  time.Minute,
+	StableAfter: 30 * time.Second,
+	LogLines:    1000,
+}
+
+// waitDelay is how long output is still read after a service exited
+const waitDelay = time.Second
+
+// ErrUnknownService is returned for services that were never started
+var ErrUnknownService = errors.New("unknown service")
+
+// supervised is a service and its supervision state
+type supervised struct {
+	svc   Service
+	state State
+	logs  *logBuffer
+	// stop is closed when the service should stop, done once its
+	// supervision loop has ended
+	stop chan struct{}
+	done chan struct{}
+}
+
+// Supervisor starts services, restarts them with backoff when they exit
+// and keeps their recent output
+type Supervisor struct {
+	mu       sync.Mutex
+	services map[string]*supervised
+	tracker  *procs.Tracker
+	opts     Options
+	onChange func(State)
+}
+
+// New creates a Supervisor. Service processes are registered with
+// tracker, which also stops them. onChange is called without locks held
+// whenever the state of a service changed, it may be nil.
+func New(tracker *procs.Tracker, opts Options, onChange func(State)) *Supervisor {
+	if opts.MinBackoff <= 0 {
+		opts.MinBackoff = DefaultOptions.MinBackoff
+	}
+	if opts.MaxBackoff < opts.MinBackoff {
+		opts.MaxBackoff = opts.MinBackoff
+	}
+	if opts.LogLines <= 0 {
+		opts.LogLines = DefaultOptions.LogLines
+	}
+	return &Supervisor{
+		services: make(map[string]*supervised),
+		tracker:  tracker,
+		opts:     opts,
+		onChange: onChange,
+	}
+}
+
+// Start starts a service unless it is already supervised. Errors of the
+// first start are returned; later crashes lead to restarts.
+func (s *Supervisor) Start(svc Service) error {
+	s.mu.Lock()
+	if sv, ok := s.services[svc.ID]; ok && sv.state.Status != StatusStopped {
+		s.mu.Unlock()
+		return nil
+	}
+
+	sv := &supervised{
+		svc:   svc,
+		state: State{ID: svc.ID, Name: svc.Name, Status: StatusStopped},
+		logs:  newLogBuffer(s.opts.LogLines),
+		stop:  make(chan struct{}),
+		done:  make(chan struct{}),
+	}
+	if old, ok := s.services[svc.ID]; ok {
+		// Keep the output of earlier runs
+		sv.logs = old.logs
+	}
+
+	cmd, exited, err := s.launch(sv)
+	if err != nil {
+		s.mu.Unlock()
+		return err
+	}
+	s.services[svc.ID] = sv
+	state := sv.state
+	s.mu.Unlock()
+
+	s.changed(state)
+	go s.supervise(sv, cmd, exited)
+	return nil
+}
+
+// launch starts the service process. The caller holds s.mu, so Stop
+// either sees the tracked process or launch sees the closed stop channel.
+func (s *Supervisor) launch(sv *supervised) (*exec.Cmd, func(), error) {
+	cmd, err := sv.svc.Command()
+	if err != nil {
+		return nil, nil, err
+	}
+	cmd.Stdout = sv.logs.writer(StreamStdout)
+	cmd.Stderr = sv.logs.writer(StreamStderr)
+	// Children that inherited the output must not keep a crashed
+	// service from being restarted
+	cmd.WaitDelay = waitDelay
+
+	procs.Prepare(cmd)
+	if err := cmd.Start(); err != nil {
+		return nil, nil, err
+	}
+	exited := s.tracker.Add(sv.svc.ID, cmd)
+
+	sv.state.Status = StatusRunning
+	sv.state.PID = cmd.Process.Pid
+	sv.state.Started = time.Now()
+	sv.state.NextRestart = time.Time{}
+	return cmd, exited, nil
+}
+
+// supervise waits for the service process and restarts it until the
+// service is stopped
+func (s *Supervisor) supervise(sv *supervised, cmd *exec.Cmd, exited func()) {
+	defer close(sv.done)
+	backoff := s.opts.MinBackoff
+
+	for {
+		err := cmd.Wait()
+		exited()
+		sv.logs.flush()
+
+		s.mu.Lock()
+		code := -1
+		if cmd.ProcessState != nil {
+			code = cmd.ProcessState.ExitCode()
+		}
+		sv.state.ExitCode = &code
+		sv.state.LastError = ""
+		if err != nil {
+			sv.state.LastError = err.Error()
+		}
+		sv.state.PID = 0
+		if time.Since(sv.state.Started) >= s.opts.StableAfter {
+			backoff = s.opts.MinBackoff
+		}
+		s.mu.Unlock()
+
+		for {
+			if s.stopped(sv) {
+				return
+			}
+
+			s.mu.Lock()
+			sv.state.Status = StatusRestarting
+			sv.state.NextRestart = time.Now().Add(backoff)
+			state := sv.state
+			s.mu.Unlock()
+			s.changed(state)
+
+			select {
+			case <-sv.stop:
+				s.stopped(sv)
+				return
+			case <-time.After(backoff):
+			}
+			backoff = min(backoff*2, s.opts.MaxBackoff)
+
+			s.mu.Lock()
+			select {
+			case <-sv.stop:
+				s.mu.Unlock()
+				s.stopped(sv)
+				return
+			default:
+			}
+			cmd, exited, err = s.launch(sv)
+			sv.state.Restarts++
+			if err != nil {
+				sv.state.LastError = err.Error()
+			}
+			state = sv.state
+			s.mu.Unlock()
+			s.changed(state)
+
+			if err == nil {
+				break
+			}
+		}
+	}
+}
+
+// stopped marks the service as stopped if it should stop and reports
+// whether it did
+func (s *Supervisor) stopped(sv *supervised) bool {
+	select {
+	case <-sv.stop:
+	default:
+		return false
+	}
+
+	s.mu.Lock()
+	sv.state.Status = StatusStopped
+	sv.state.PID = 0
+	sv.state.NextRestart = time.Time{}
+	state := sv.state
+	s.mu.Unlock()
+	s.changed(state)
+	return true
+}
+
+// Stop stops a service. Its processes are asked to terminate and killed
+// after timeout; Stop returns once supervision has ended.
+func (s *Supervisor) Stop(id string, timeout time.Duration) error {
+	s.mu.Lock()
+	sv, ok := s.services[id]
+	if !ok {
+		s.mu.Unlock()
+		return ErrUnknownService
+	}
+	select {
+	case <-sv.stop:
+	default:
+		close(sv.stop)
+	}
+	s.mu.Unlock()
+
+	err := s.tracker.Stop(id, timeout)
+	if errors.Is(err, procs.ErrNotRunning) {
+		// Waiting for a restart
+		err = nil
+	}
+	<-sv.done
+	return err
+}
+
+// StopAll stops all services in parallel
+func (s *Supervisor) StopAll(timeout time.Duration) {
+	s.mu.Lock()
+	ids := make([]string, 0, len(s.services))
+	for id := range s.services {
+		ids = append(ids, id)
+	}
+	s.mu.Unlock()
+
+	var wg sync.WaitGroup
+	for _, id := range ids {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.Stop(id, timeout)
+		}()
+	}
+	wg.Wait()
+}
+
+// Supervises reports whether a service was started and not stopped
+func (s *Supervisor) Supervises(id string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sv, ok := s.services[id]
+	return ok && sv.state.Status != StatusStopped
+}
+
+// States returns the states of all services ever started, by name
+func (s *Supervisor) States() []State {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	states := make([]State, 0, len(s.services))
+	for _, sv := range s.services {
+		states = append(states, sv.state)
+	}
+	sort.Slice(states, func(i, j int) bool {
+		if states[i].Name != states[j].Name {
+			return states[i].Name < states[j].Name
+		}
+		return states[i].ID < states[j].ID
+	})
+	return states
+}
+
+// Logs returns the recent output of a service, oldest line first
+func (s *Supervisor) Logs(id string) ([]LogLine, error) {
+	s.mu.Lock()
+	sv, ok := s.services[id]
+	s.mu.Unlock()
+	if !ok {
+		return nil, ErrUnknownService
+	}
+	return sv.logs.lines(), nil
+}
+
+func (s *Supervisor) changed(state State) {
+	if s.onChange != nil {
+		s.onChange(state)
+	}
+}
@@ -0,0 +1,91 @@
+//go:build !windows
+
+package service
+
+import (
+	"os/exec"
+	"testing"
+	"time"
+
+	"quicklaunch/internal/procs"
+)
+
+// testOptions restart quickly
+var testOptions = Options{MinBackoff: 10 * time.Millisecond, MaxBackoff: 40 * time.Millisecond, StableAfter: time.Minute, LogLines: 3}
+
+// waitState waits until the service reaches a state accepted by ok
+func waitState(t *testing.T, s *Supervisor, id string, ok func(State) bool) State {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		for _, st := range s.States() {
+			if st.ID == id && ok(st) {
+				return st
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("service %s did not reach the expected state: %+v", id, s.States())
+	return State{}
+}
+
+func TestSupervisorRestartsCrashedService(t *testing.T) {
+	s := New(procs.NewTracker(nil), testOptions, nil)
+	svc := Service{ID: "db", Name: "DB", Command: func() (*exec.Cmd, error) {
+		return exec.Command("sh", "-c", "echo starting; echo oops >&2; exit 2"), nil
+	}}
+	if err := s.Start(svc); err != nil {
+		t.Fatalf("Start returned error: %v", err)
+	}
+
+	st := waitState(t, s, "db", func(st State) bool { return st.Restarts >= 2 })
+	if st.ExitCode == nil || *st.ExitCode != 2 {
+		t.Errorf("ExitCode = %v, want 2", st.ExitCode)
+	}
+
+	if err := s.Stop("db", time.Second); err != nil {
+		t.Fatalf("Stop returned error: %v", err)
+	}
+	if st := s.States()[0]; st.Status != StatusStopped {
+		t.Errorf("Status = %q after Stop", st.Status)
+	}
+
+	logs, _ := s.Logs("db")
+	if len(logs) != 3 {
+		t.Fatalf("expected the last 3 lines, got %+v", logs)
+	}
+	stderr := false
+	for _, l := range logs {
+		stderr = stderr || (l.Stream == StreamStderr && l.Text == "oops")
+	}
+	if !stderr {
+		t.Errorf("stderr output missing from %+v", logs)
+	}
+}
+
+func TestSupervisorStopsRunningService(t *testing.T) {
+	tracker := procs.NewTracker(nil)
+	s := New(tracker, testOptions, nil)
+	svc := Service{ID: "proxy", Command: func() (*exec.Cmd, error) {
+		return exec.Command("sleep", "30"), nil
+	}}
+	s.Start(svc)
+	if err := s.Start(svc); err != nil {
+		t.Fatalf("starting a running service returned %v", err)
+	}
+	if len(tracker.List("proxy")) != 1 {
+		t.Fatal("a running service must not be started twice")
+	}
+	st := waitState(t, s, "proxy", func(st State) bool { return st.Status == StatusRunning })
+	if st.PID == 0 || !s.Supervises("proxy") {
+		t.Errorf("unexpected state %+v", st)
+	}
+
+	s.StopAll(5 * time.Second)
+	if s.Supervises("proxy") || len(tracker.List("")) != 0 {
+		t.Error("StopAll left the service running")
+	}
+	if err := s.Stop("nope", time.Second); err != ErrUnknownService {
+		t.Errorf("expected ErrUnknownService, got %v", err)
+	}
+}
@@ -9,6 +9,8 @@ type Manager struct {
 	onShow func()
 	onQuit func()
 	icon   []byte
+	hotkey string
+	ready  bool
 }
 
 // NewManager creates a new tray manager
@@ -17,6 +19,7 @@ func NewManager(icon []byte, onShow, onQuit func()) *Manager {
 		icon:   icon,
 		onShow: onShow,
 		onQuit: onQuit,
+		hotkey: "Ctrl+Space",
 	}
 }
 
@@ -30,10 +33,23 @@ func (m *Manager) Quit() {
 	systray.Quit()
 }
 
+// SetHotkey updates the hotkey shown in the tray tooltip
+func (m *Manager) SetHotkey(combo string) {
+	m.hotkey = combo
+	if m.ready {
+		systray.SetTooltip(m.tooltip())
+	}
+}
+
+func (m *Manager) tooltip() string {
+	return "QuickLaunch - Drücke " + m.hotkey + " zum Öffnen"
+}
+
 func (m *Manager) onReady() {
+	m.ready = true
 	systray.SetIcon(m.icon)
 	systray.SetTitle("QuickLaunch")
-	systray.SetTooltip("QuickLaunch - Drücke Ctrl+Space zum Öffnen")
+	systray.SetTooltip(m.tooltip())
 
 	// Menu items
 	mShow := systray.AddMenuItem("Öffnen", "QuickLaunch öffnen")
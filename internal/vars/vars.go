@@ -0,0 +1,221 @@
+package vars
+
+import (
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// DateLayout is the format of the {date} placeholder
+const DateLayout = "2006-01-02"
+
+// Context supplies the values of the placeholders
+type Context struct {
+	// Path is the folder chosen in the submenu, used for {path}
+	Path string
+	// Home is the user's home directory, used for {home}
+	Home string
+	// Now is used for {date}; the zero value means time.Now
+	Now time.Time
+	// Getenv looks up {env:VAR}, may be nil
+	Getenv func(string) string
+	// Clipboard returns the clipboard text for {clipboard}, may be nil
+	Clipboard func() (string, error)
+	// Inputs holds the values entered for {input:Prompt} by prompt
+	Inputs map[string]string
+	// Query is the text typed after a search keyword, used for {query}
+	Query string
+}
+
+// MissingInputError is returned when {input:...} placeholders have no
+// value yet. The frontend asks for Prompts and executes again.
+type MissingInputError struct {
+	Prompts []string
+}
+
+func (e *MissingInputError) Error() string {
+	return fmt.Sprintf("missing input for %s", strings.Join(e.Prompts, ", "))
+}
+
+// Quote escapes the value substituted for the placeholder with the given
+// name, e.g. for a shell or URL
+type Quote func(name, value string) (string, error)
+
+// IsPath reports whether the placeholder with the given name expands to a
+// file path
+func IsPath(name string) bool {
+	return name == "path" || name == "home"
+}
+
+// URLEscape escapes a value for use in any URL component. Spaces become
+// %20 which, unlike "+", also works in paths.
+func URLEscape(s string) (string, error) {
+	return strings.ReplaceAll(url.QueryEscape(s), "+", "%20"), nil
+}
+
+// URLPathEscape escapes a file path for use in a URL path. Its segments
+// are escaped one by one, so "file://{path}" stays a valid URL.
+func URLPathEscape(s string) string {
+	segments := strings.Split(filepath.ToSlash(s), "/")
+	for i, seg := range segments {
+		segments[i] = url.PathEscape(seg)
+	}
+	return strings.Join(segments, "/")
+}
+
+// placeholder is a parsed "{name}" or "{name:arg}"
+type placeholder struct {
+	name string
+	arg  string
+}
+
+// known reports whether the placeholder is expanded by this package.
+// Everything else, e.g. "{dir}" in terminal profiles or braces in shell
+// code, is left as it is.
+func (p placeholder) known() bool {
+	switch p.name {
+	case "path", "home", "date", "clipboard", "query":
+		return p.arg == ""
+	case "env", "input":
+		return p.arg != ""
+	}
+	return false
+}
+
+// scan calls fn for every known placeholder in s with the text between
+// the placeholders, so callers can rebuild or inspect the string
+func scan(s string, text func(string), fn func(placeholder)) {
+	for {
+		start := strings.IndexByte(s, '{')
+		if start < 0 {
+			text(s)
+			return
+		}
+		end := strings.IndexByte(s[start+1:], '}')
+		if end < 0 {
+			text(s)
+			return
+		}
+		end += start + 1
+
+		inner := s[start+1 : end]
+		if strings.ContainsRune(inner, '{') {
+			// "{{path}" - the first brace is plain text
+			text(s[:start+1])
+			s = s[start+1:]
+			continue
+		}
+
+		name, arg, _ := strings.Cut(inner, ":")
+		p := placeholder{name: name, arg: arg}
+		if !p.known() {
+			text(s[:end+1])
+		} else {
+			text(s[:start])
+			fn(p)
+		}
+		s = s[end+1:]
+	}
+}
+
+// IsPlaceholder reports whether s consists of a single known placeholder
+func IsPlaceholder(s string) bool {
+	count, other := 0, false
+	scan(s, func(t string) { other = other || t != "" }, func(placeholder) { count++ })
+	return count == 1 && !other
+}
+
+// Prompts returns the prompts of all {input:...} placeholders in the given
+// strings in order of appearance and without duplicates
+func Prompts(values ...string) []string {
+	var prompts []string
+	seen := make(map[string]bool)
+	for _, v := range values {
+		scan(v, func(string) {}, func(p placeholder) {
+			if p.name == "input" && !seen[p.arg] {
+				seen[p.arg] = true
+				prompts = append(prompts, p.arg)
+			}
+		})
+	}
+	return prompts
+}
+
+// Expand replaces the known placeholders in s. quote, if not nil, is
+// applied to every substituted value. A missing input returns a
+// *MissingInputError.
+func Expand(s string, c Context, quote Quote) (string, error) {
+	var b strings.Builder
+	var missing []string
+	var firstErr error
+
+	scan(s, func(t string) { b.WriteString(t) }, func(p placeholder) {
+		value, ok, err := c.value(p)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			return
+		}
+		if !ok {
+			missing = append(missing, p.arg)
+			return
+		}
+		if quote != nil {
+			if value, err = quote(p.name, value); err != nil {
+				if firstErr == nil {
+					firstErr = fmt.Errorf("{%s}: %w", p.name, err)
+				}
+				return
+			}
+		}
+		b.WriteString(value)
+	})
+
+	if firstErr != nil {
+		return "", firstErr
+	}
+	if len(missing) > 0 {
+		return "", &MissingInputError{Prompts: missing}
+	}
+	return b.String(), nil
+}
+
+// value returns the value of a placeholder; ok is false for inputs
+// without a value
+func (c Context) value(p placeholder) (value string, ok bool, err error) {
+	switch p.name {
+	case "path":
+		return c.Path, true, nil
+	case "home":
+		return c.Home, true, nil
+	case "query":
+		return c.Query, true, nil
+	case "date":
+		now := c.Now
+		if now.IsZero() {
+			now = time.Now()
+		}
+		return now.Format(DateLayout), true, nil
+	case "env":
+		if c.Getenv == nil {
+			return "", true, nil
+		}
+		return c.Getenv(p.arg), true, nil
+	case "clipboard":
+		if c.Clipboard == nil {
+			return "", true, nil
+		}
+		text, err := c.Clipboard()
+		if err != nil {
+			return "", false, fmt.Errorf("read clipboard: %w", err)
+		}
+		return text, true, nil
+	case "input":
+		v, ok := c.Inputs[p.arg]
+		return v, ok, nil
+	}
+	return "", false, fmt.Errorf("unknown placeholder %q", p.name)
+}
@@ -0,0 +1,127 @@
+package vars
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testContext() Context {
+	return Context{
+		Path: "/home/me/projects/ql",
+		Home: "/home/me",
+		Now:  time.Date(2025, 3, 7, 9, 30, 0, 0, time.UTC),
+		Getenv: func(key string) string {
+			if key == "EDITOR" {
+				return "vim"
+			}
+			return ""
+		},
+		Clipboard: func() (string, error) { return "copied", nil },
+		Inputs:    map[string]string{"Ticket": "QL-42"},
+		Query:     "wails v2",
+	}
+}
+
+func TestExpand(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"{path}", "/home/me/projects/ql"},
+		{"{home}/notes", "/home/me/notes"},
+		{"log-{date}.txt", "log-2025-03-07.txt"},
+		{"{env:EDITOR} {env:MISSING}x", "vim x"},
+		{"{clipboard}", "copied"},
+		{"https://jira/browse/{input:Ticket}", "https://jira/browse/QL-42"},
+		{"https://github.com/search?q={query}", "https://github.com/search?q=wails v2"},
+		// Unknown placeholders and shell braces stay untouched
+		{"wt -d {dir}", "wt -d {dir}"},
+		{"awk '{print $1}'", "awk '{print $1}'"},
+		{"find . -exec rm {} +", "find . -exec rm {} +"},
+		{"{{path}", "{/home/me/projects/ql"},
+		{"{path", "{path"},
+		{"{env:}", "{env:}"},
+		{"{home:x}", "{home:x}"},
+	}
+
+	for _, tt := range tests {
+		got, err := Expand(tt.input, testContext(), nil)
+		if err != nil {
+			t.Errorf("Expand(%q) returned error: %v", tt.input, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("Expand(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestExpandQuote(t *testing.T) {
+	c := testContext()
+	c.Inputs["Query"] = "a'b"
+	quote := func(_, s string) (string, error) { return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'", nil }
+
+	got, err := Expand("grep {input:Query} {path}", c, quote)
+	if err != nil {
+		t.Fatalf("Expand returned error: %v", err)
+	}
+	if want := `grep 'a'\''b' '/home/me/projects/ql'`; got != want {
+		t.Errorf("Expand = %q, want %q", got, want)
+	}
+
+	failing := func(string, string) (string, error) { return "", errors.New("unsafe") }
+	if _, err := Expand("echo {clipboard}", c, failing); err == nil {
+		t.Error("expected quote error to be returned")
+	}
+}
+
+func TestExpandMissingInput(t *testing.T) {
+	_, err := Expand("{input:Ticket} {input:Project} {input:Branch}", Context{Inputs: map[string]string{"Ticket": "x"}}, nil)
+	var missing *MissingInputError
+	if !errors.As(err, &missing) {
+		t.Fatalf("expected MissingInputError, got %v", err)
+	}
+	if len(missing.Prompts) != 2 || missing.Prompts[0] != "Project" || missing.Prompts[1] != "Branch" {
+		t.Errorf("Prompts = %v", missing.Prompts)
+	}
+}
+
+func TestExpandClipboardError(t *testing.T) {
+	c := Context{Clipboard: func() (string, error) { return "", errors.New("no clipboard") }}
+	if _, err := Expand("{clipboard}", c, nil); err == nil {
+		t.Error("expected clipboard error")
+	}
+}
+
+func TestPrompts(t *testing.T) {
+	got := Prompts("https://jira/browse/{input:Ticket}", "{input:Project} {input:Ticket}", "{dir}")
+	if len(got) != 2 || got[0] != "Ticket" || got[1] != "Project" {
+		t.Errorf("Prompts = %v", got)
+	}
+}
+
+func TestIsPlaceholder(t *testing.T) {
+	for s, want := range map[string]bool{
+		"{clipboard}":     true,
+		"{input:URL}":     true,
+		"x{clipboard}":    false,
+		"{path}{home}":    false,
+		"{dir}":           false,
+		"https://example": false,
+	} {
+		if got := IsPlaceholder(s); got != want {
+			t.Errorf("IsPlaceholder(%q) = %v, want %v", s, got, want)
+		}
+	}
+}
+
+func TestURLEscape(t *testing.T) {
+	if got, _ := URLEscape("QL 42&x=1+y"); got != "QL%2042%26x%3D1%2By" {
+		t.Errorf("URLEscape = %q", got)
+	}
+	if got := URLPathEscape("/home/me/a b?/c%d"); got != "/home/me/a%20b%3F/c%25d" {
+		t.Errorf("URLPathEscape = %q", got)
+	}
+}
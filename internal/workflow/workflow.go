@@ -0,0 +1,94 @@
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Status values of a Progress event
+const (
+	// StatusWaiting is reported while the delay before a step elapses
+	StatusWaiting = "waiting"
+	// StatusRunning is reported when a step starts
+	StatusRunning = "running"
+	// StatusDone is reported when a step succeeded
+	StatusDone = "done"
+	// StatusFailed is reported when a step failed
+	StatusFailed = "failed"
+	// StatusFinished is reported once after all steps ran
+	StatusFinished = "finished"
+	// StatusAborted is reported once when a failure or cancellation
+	// stopped the workflow
+	StatusAborted = "aborted"
+)
+
+// Step is a single step of a workflow
+type Step struct {
+	Name string
+	// Delay is waited before the step starts
+	Delay time.Duration
+	// ContinueOnError keeps the workflow going when the step fails
+	ContinueOnError bool
+	// Run executes the step. Steps that should wait for their process
+	// to exit block until it has.
+	Run func(ctx context.Context) error
+}
+
+// Progress reports the state of a workflow step. Step is 1-based; the
+// final event has Step == Total and a finished or aborted status.
+type Progress struct {
+	Step   int    `json:"step"`
+	Total  int    `json:"total"`
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+	// Failed counts the failed steps so far
+	Failed int `json:"failed"`
+}
+
+// Run executes the steps in order and reports progress to report, which
+// may be nil. It stops at the first failing step unless that step has
+// ContinueOnError set and returns the error that stopped the workflow.
+func Run(ctx context.Context, steps []Step, report func(Progress)) error {
+	if report == nil {
+		report = func(Progress) {}
+	}
+	total := len(steps)
+	failed := 0
+
+	abort := func(i int, name string, err error) error {
+		report(Progress{Step: i + 1, Total: total, Name: name, Status: StatusAborted, Error: err.Error(), Failed: failed})
+		return fmt.Errorf("step %d (%s): %w", i+1, name, err)
+	}
+
+	for i, step := range steps {
+		if step.Delay > 0 {
+			report(Progress{Step: i + 1, Total: total, Name: step.Name, Status: StatusWaiting, Failed: failed})
+			timer := time.NewTimer(step.Delay)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return abort(i, step.Name, ctx.Err())
+			case <-timer.C:
+			}
+		}
+		if err := ctx.Err(); err != nil {
+			return abort(i, step.Name, err)
+		}
+
+		report(Progress{Step: i + 1, Total: total, Name: step.Name, Status: StatusRunning, Failed: failed})
+		if err := step.Run(ctx); err != nil {
+			failed++
+			report(Progress{Step: i + 1, Total: total, Name: step.Name, Status: StatusFailed, Error: err.Error(), Failed: failed})
+			if !step.ContinueOnError {
+				return abort(i, step.Name, err)
+			}
+			continue
+		}
+		report(Progress{Step: i + 1, Total: total, Name: step.Name, Status: StatusDone, Failed: failed})
+	}
+
+	report(Progress{Step: total, Total: total, Status: StatusFinished, Failed: failed})
+	return nil
+}
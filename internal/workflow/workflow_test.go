@@ -0,0 +1,116 @@
+package workflow
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// recorder collects progress events and the order steps ran in
+type recorder struct {
+	events []Progress
+	ran    []string
+}
+
+func (r *recorder) step(name string, err error) Step {
+	return Step{
+		Name: name,
+		Run: func(ctx context.Context) error {
+			r.ran = append(r.ran, name)
+			return err
+		},
+	}
+}
+
+func (r *recorder) statuses() []string {
+	var s []string
+	for _, e := range r.events {
+		s = append(s, e.Status)
+	}
+	return s
+}
+
+func equal(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestRunInOrder(t *testing.T) {
+	r := &recorder{}
+	steps := []Step{r.step("code", nil), r.step("server", nil), r.step("dashboard", nil)}
+
+	if err := Run(context.Background(), steps, func(p Progress) { r.events = append(r.events, p) }); err != nil {
+		t.Fatalf("Run returned error: %v", err)
+	}
+	if !equal(r.ran, []string{"code", "server", "dashboard"}) {
+		t.Errorf("steps ran as %v", r.ran)
+	}
+	want := []string{StatusRunning, StatusDone, StatusRunning, StatusDone, StatusRunning, StatusDone, StatusFinished}
+	if !equal(r.statuses(), want) {
+		t.Errorf("statuses = %v, want %v", r.statuses(), want)
+	}
+}
+
+func TestRunStopsOnFailure(t *testing.T) {
+	boom := errors.New("boom")
+	r := &recorder{}
+	steps := []Step{r.step("a", nil), r.step("b", boom), r.step("c", nil)}
+
+	err := Run(context.Background(), steps, func(p Progress) { r.events = append(r.events, p) })
+	if !errors.Is(err, boom) {
+		t.Fatalf("expected boom, got %v", err)
+	}
+	if !equal(r.ran, []string{"a", "b"}) {
+		t.Errorf("steps ran as %v", r.ran)
+	}
+	last := r.events[len(r.events)-1]
+	if last.Status != StatusAborted || last.Step != 2 || last.Failed != 1 {
+		t.Errorf("unexpected final event %+v", last)
+	}
+}
+
+func TestRunContinueOnError(t *testing.T) {
+	r := &recorder{}
+	failing := r.step("b", errors.New("boom"))
+	failing.ContinueOnError = true
+	steps := []Step{r.step("a", nil), failing, r.step("c", nil)}
+
+	if err := Run(context.Background(), steps, func(p Progress) { r.events = append(r.events, p) }); err != nil {
+		t.Fatalf("Run returned error: %v", err)
+	}
+	if !equal(r.ran, []string{"a", "b", "c"}) {
+		t.Errorf("steps ran as %v", r.ran)
+	}
+	last := r.events[len(r.events)-1]
+	if last.Status != StatusFinished || last.Failed != 1 {
+		t.Errorf("unexpected final event %+v", last)
+	}
+}
+
+func TestRunDelayAndCancel(t *testing.T) {
+	r := &recorder{}
+	delayed := r.step("late", nil)
+	delayed.Delay = time.Hour
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+
+	err := Run(ctx, []Step{r.step("a", nil), delayed}, nil)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	if !equal(r.ran, []string{"a"}) {
+		t.Errorf("delayed step should not run, ran %v", r.ran)
+	}
+}
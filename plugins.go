@@ -0,0 +1,104 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+
+	"quicklaunch/internal/actions"
+	"quicklaunch/internal/config"
+	"quicklaunch/internal/plugins"
+	"quicklaunch/internal/version"
+)
+
+// pluginTimeout is the search timeout of plugins, which answer over a
+// pipe
+const pluginTimeout = 500 * time.Millisecond
+
+// newPluginHost creates the host of the plugins in the config directory.
+// Without a config directory there are no plugins.
+func (a *App) newPluginHost() *plugins.Host {
+	dir := ""
+	if base, err := config.GetConfigDir(); err == nil {
+		dir = filepath.Join(base, plugins.DirName)
+	}
+	opts := plugins.DefaultOptions
+	opts.Version = version.Version
+	opts.Open = a.openPluginTarget
+	opts.Copy = a.setClipboard
+
+	host, err := plugins.NewHost(dir, opts, a.pluginsChanged)
+	if err != nil {
+		println("Failed to load plugins:", err.Error())
+	}
+	return host
+}
+
+// registerPlugins registers the plugins of the current host as search
+// providers
+func (a *App) registerPlugins() {
+	for _, p := range a.pluginHost().Plugins() {
+		a.providers.Register(p, pluginTimeout)
+	}
+}
+
+// pluginsChanged tells the frontend that a plugin started, crashed or
+// stopped
+func (a *App) pluginsChanged() {
+	if a.ctx != nil {
+		runtime.EventsEmit(a.ctx, "plugins:changed")
+	}
+}
+
+// pluginHost returns the current plugin host
+func (a *App) pluginHost() *plugins.Host {
+	a.pluginsMu.Lock()
+	defer a.pluginsMu.Unlock()
+	return a.plugins
+}
+
+// GetPlugins returns the status of the installed plugins
+func (a *App) GetPlugins() []plugins.Status {
+	return a.pluginHost().Statuses()
+}
+
+// ReloadPlugins stops all plugins and starts those now in the plugins
+// directory, e.g. after one was added or updated
+func (a *App) ReloadPlugins() []plugins.Status {
+	a.pluginsMu.Lock()
+	for _, p := range a.plugins.Plugins() {
+		a.providers.Unregister(p.Name())
+	}
+	a.plugins.Close()
+	a.plugins = a.newPluginHost()
+	a.plugins.Start()
+	a.pluginsMu.Unlock()
+
+	a.registerPlugins()
+	a.pluginsChanged()
+	return a.GetPlugins()
+}
+
+// OpenPluginsFolder opens the plugins directory, creating it if needed
+func (a *App) OpenPluginsFolder() error {
+	dir := a.pluginHost().Dir()
+	if dir == "" {
+		return errors.New("config directory not available")
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	return actions.Default.Execute("folder", actions.Request{Target: dir})
+}
+
+// openPluginTarget opens a URL or file a plugin answered an execute with
+func (a *App) openPluginTarget(target string) error {
+	if strings.Contains(target, "://") || strings.HasPrefix(target, "mailto:") {
+		return actions.Default.Execute("url", actions.Request{Target: target})
+	}
+	return a.OpenFile(target)
+}
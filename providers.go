@@ -0,0 +1,258 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+
+	"quicklaunch/internal/actions"
+	"quicklaunch/internal/calc"
+	"quicklaunch/internal/config"
+	"quicklaunch/internal/providers"
+)
+
+// Names of the built-in search providers, also used as keys of
+// config.Config.SearchProviders
+const (
+	providerCalc   = "calc"
+	providerTiles  = "tiles"
+	providerRecent = "recent"
+	providerApps   = "apps"
+	providerFiles  = "files"
+)
+
+// calcScore puts the result of a calculation above all other results
+const calcScore = 1 << 20
+
+// filesTimeout gives the file search more time, large indexes take a
+// while to rank
+const filesTimeout = 500 * time.Millisecond
+
+// registerProviders registers the built-in search providers
+func (a *App) registerProviders() {
+	a.providers.Register(calcProvider{app: a}, 0)
+	a.providers.Register(tileProvider{app: a}, 0)
+	a.providers.Register(tileProvider{app: a, recent: true}, 0)
+	a.providers.Register(appProvider{app: a}, 0)
+	a.providers.Register(fileProvider{app: a}, filesTimeout)
+	a.registerPlugins()
+}
+
+// providerSettings converts the configured provider settings
+func providerSettings(cfg map[string]config.SearchProvider) map[string]providers.Settings {
+	settings := make(map[string]providers.Settings, len(cfg))
+	for name, p := range cfg {
+		settings[name] = providers.Settings{
+			Disabled: p.Disabled,
+			Timeout:  time.Duration(p.TimeoutMs) * time.Millisecond,
+		}
+	}
+	return settings
+}
+
+// setClipboard replaces the text on the clipboard
+func (a *App) setClipboard(text string) error {
+	if a.ctx == nil {
+		return errors.New("clipboard not available")
+	}
+	return runtime.ClipboardSetText(a.ctx, text)
+}
+
+// SearchAll queries all enabled search providers and returns their
+// merged results, best first. A search started while another one is
+// still running cancels the older one, which returns an error the
+// frontend can ignore.
+func (a *App) SearchAll(query string) (providers.Response, error) {
+	ctx := a.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return a.providers.Search(ctx, query, searchLimit)
+}
+
+// ExecuteResult runs a result returned by SearchAll. Tile results may
+// need the values for {input:...} placeholders and, for tiles with
+// RequireConfirm, a token from RequestConfirmation; without them they
+// return a *vars.MissingInputError or guard.ErrConfirmationRequired like
+// ExecuteTile. Other results ignore inputs and token.
+func (a *App) ExecuteResult(result providers.Result, inputs map[string]string, token string) error {
+	result.Inputs, result.ConfirmToken = inputs, token
+	return a.providers.Execute(result)
+}
+
+// GetSearchProviders returns the registered search providers
+func (a *App) GetSearchProviders() []providers.Info {
+	return a.providers.Providers()
+}
+
+// SetSearchProviderEnabled enables or disables a search provider
+func (a *App) SetSearchProviderEnabled(name string, enabled bool) error {
+	known := false
+	for _, info := range a.providers.Providers() {
+		known = known || info.Name == name
+	}
+	if !known {
+		return fmt.Errorf("search provider %q not found", name)
+	}
+	if a.config == nil {
+		return nil
+	}
+
+	if a.config.SearchProviders == nil {
+		a.config.SearchProviders = make(map[string]config.SearchProvider)
+	}
+	p := a.config.SearchProviders[name]
+	p.Disabled = !enabled
+	a.config.SearchProviders[name] = p
+	a.providers.Configure(providerSettings(a.config.SearchProviders))
+	return a.config.Save()
+}
+
+// calcProvider computes expressions and unit or base conversions typed
+// into the search bar
+type calcProvider struct {
+	app *App
+}
+
+func (calcProvider) Name() string { return providerCalc }
+
+func (calcProvider) Query(ctx context.Context, text string) ([]providers.Result, error) {
+	r, err := calc.Evaluate(text)
+	if err != nil {
+		// Most input is not meant as a calculation, errors are not shown
+		return nil, nil
+	}
+	return []providers.Result{{
+		Kind:     SearchKindCalc,
+		ID:       r.Value,
+		Title:    r.String(),
+		Subtitle: r.Input,
+		Icon:     "Calculator",
+		Score:    calcScore,
+	}}, nil
+}
+
+// Execute copies the value without its unit to the clipboard
+func (p calcProvider) Execute(r providers.Result) error {
+	return p.app.setClipboard(r.ID)
+}
+
+// tileProvider finds enabled tiles or, if recent is set, their recent
+// items
+type tileProvider struct {
+	app    *App
+	recent bool
+}
+
+func (p tileProvider) Name() string {
+	if p.recent {
+		return providerRecent
+	}
+	return providerTiles
+}
+
+func (p tileProvider) Query(ctx context.Context, text string) ([]providers.Result, error) {
+	found := p.app.searchTiles(text, !p.recent, p.recent)
+	results := make([]providers.Result, 0, len(found))
+	for _, r := range found {
+		tile, _ := p.app.findTile(r.TileID)
+		res := providers.Result{
+			Kind:       r.Kind,
+			ID:         r.TileID,
+			Title:      r.Name,
+			Path:       r.Path,
+			Icon:       tile.Icon,
+			Score:      r.Score,
+			Highlights: r.Highlights,
+		}
+		if p.recent {
+			res.Subtitle = tile.Name
+		}
+		results = append(results, res)
+	}
+	return results, nil
+}
+
+// Execute runs the tile, with the recent item's path for recent items
+func (p tileProvider) Execute(r providers.Result) error {
+	return p.app.ExecuteTileConfirmed(r.ID, r.Path, r.Inputs, r.ConfirmToken)
+}
+
+// appProvider finds installed desktop applications
+type appProvider struct {
+	app *App
+}
+
+func (appProvider) Name() string { return providerApps }
+
+func (p appProvider) Query(ctx context.Context, text string) ([]providers.Result, error) {
+	if strings.TrimSpace(text) == "" {
+		return nil, nil
+	}
+	found := p.app.SearchApplications(text)
+	results := make([]providers.Result, 0, len(found))
+	for _, r := range found {
+		subtitle := r.App.GenericName
+		if subtitle == "" {
+			subtitle = r.App.Comment
+		}
+		results = append(results, providers.Result{
+			Kind:       SearchKindApp,
+			ID:         r.App.ID,
+			Title:      r.App.Name,
+			Subtitle:   subtitle,
+			Icon:       applicationTileIcon,
+			Score:      r.Score,
+			Highlights: r.Highlights,
+		})
+	}
+	return results, nil
+}
+
+// Execute launches the application like a tile of it would
+func (p appProvider) Execute(r providers.Result) error {
+	app, err := actions.Applications.Find(r.ID)
+	if err != nil {
+		return fmt.Errorf("application %q: %w", r.ID, err)
+	}
+	return p.app.executeTile(applicationTile(app), launchInput{})
+}
+
+// fileProvider finds indexed files and folders
+type fileProvider struct {
+	app *App
+}
+
+func (fileProvider) Name() string { return providerFiles }
+
+func (p fileProvider) Query(ctx context.Context, text string) ([]providers.Result, error) {
+	found := p.app.SearchFiles(text)
+	results := make([]providers.Result, 0, len(found))
+	for _, r := range found {
+		kind, icon := SearchKindFile, "File"
+		if r.Dir {
+			kind, icon = SearchKindFolder, "Folder"
+		}
+		results = append(results, providers.Result{
+			Kind:       kind,
+			ID:         r.Path,
+			Title:      r.Name,
+			Subtitle:   filepath.Dir(r.Path),
+			Path:       r.Path,
+			Icon:       icon,
+			Score:      r.Score,
+			Highlights: r.Highlights,
+		})
+	}
+	return results, nil
+}
+
+// Execute opens the file or folder
+func (p fileProvider) Execute(r providers.Result) error {
+	return p.app.OpenFile(r.ID)
+}
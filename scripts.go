@@ -0,0 +1,164 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+
+	"quicklaunch/internal/actions"
+	"quicklaunch/internal/config"
+	"quicklaunch/internal/runlog"
+	"quicklaunch/internal/script"
+)
+
+// scriptsDir is the directory in the config directory that relative
+// script paths start from
+const scriptsDir = "scripts"
+
+func (r appRunner) RunScript(req actions.Request) error {
+	return r.app.runScript(req)
+}
+
+// scriptPath resolves the target of a script tile
+func scriptPath(target string) (string, error) {
+	if filepath.IsAbs(target) {
+		return target, nil
+	}
+	dir, err := config.GetConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, scriptsDir, target), nil
+}
+
+// runScript runs the script of a request in the background, or until it
+// ended if req.Wait is set. The outcome of background scripts is logged
+// and failures are shown as a notification.
+func (a *App) runScript(req actions.Request) error {
+	path, err := scriptPath(req.Target)
+	if err != nil {
+		return err
+	}
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	tile, ok := a.findTile(req.TileID)
+	if !ok {
+		tile = config.Tile{ID: req.TileID, Name: filepath.Base(path), Action: script.ActionType, Target: req.Target}
+	}
+
+	run := func() error {
+		ctx := a.ctx
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		return script.Run(ctx, scriptHost{app: a, tile: tile}, script.Request{
+			Name:   filepath.Base(path),
+			Source: src,
+			Tile:   tile,
+			Path:   req.Path,
+			Print: func(msg string) {
+				println(tile.Name+":", msg)
+			},
+		}, script.DefaultOptions)
+	}
+	if req.Wait {
+		return run()
+	}
+
+	go func() {
+		started := time.Now()
+		err := run()
+		a.scriptFinished(tile, req, started, err)
+	}()
+	return nil
+}
+
+// scriptFinished logs the outcome of a background script and reports
+// failures
+func (a *App) scriptFinished(tile config.Tile, req actions.Request, started time.Time, err error) {
+	entry := runlog.Entry{
+		TileID:     tile.ID,
+		TileName:   tile.Name,
+		Action:     script.ActionType,
+		Target:     req.Target,
+		Path:       req.Path,
+		Started:    started,
+		DurationMs: time.Since(started).Milliseconds(),
+		Status:     runlog.StatusSucceeded,
+	}
+	if err != nil {
+		entry.Status = runlog.StatusFailed
+		entry.Error = err.Error()
+	}
+	a.appendRunLog(entry)
+
+	if err == nil {
+		return
+	}
+	println("Script", tile.Name, "failed:", err.Error())
+	if err := a.toast.ShowMessage("Skript fehlgeschlagen", tile.Name+": "+err.Error()); err != nil {
+		println("Failed to show script notification:", err.Error())
+	}
+}
+
+// scriptHost gives the scripts of a tile access to the app
+type scriptHost struct {
+	app  *App
+	tile config.Tile
+}
+
+// Launch executes an action on behalf of the script's tile, so the command
+// policy applies and the launch shows up in the run log. A wait ends with
+// the script, the launched process keeps running.
+func (h scriptHost) Launch(ctx context.Context, action, target string, args []string, wait bool) error {
+	started := time.Now()
+	err := actions.Default.Execute(action, actions.Request{
+		TileID:  h.tile.ID,
+		Target:  target,
+		Args:    args,
+		Profile: h.app.terminalProfile(""),
+		Wait:    wait,
+		Context: ctx,
+	})
+	launched := config.Tile{ID: h.tile.ID, Name: h.tile.Name, Action: action, Target: target}
+	h.app.logLaunch(launched, launchInput{}, started, err)
+	return err
+}
+
+// RunTile runs a tile like a click on it would; tiles that need inputs
+// or a confirmation fail
+func (h scriptHost) RunTile(id string) error {
+	tile, ok := h.app.findTile(id)
+	if !ok {
+		return errors.New("tile not found")
+	}
+	return h.app.executeTile(tile, launchInput{})
+}
+
+func (h scriptHost) Tiles() []config.Tile {
+	if h.app.config == nil {
+		return nil
+	}
+	return append([]config.Tile{}, h.app.config.Tiles...)
+}
+
+func (h scriptHost) Clipboard() (string, error) {
+	if h.app.ctx == nil {
+		return "", errors.New("clipboard not available")
+	}
+	return runtime.ClipboardGetText(h.app.ctx)
+}
+
+func (h scriptHost) SetClipboard(text string) error {
+	return h.app.setClipboard(text)
+}
+
+func (h scriptHost) Notify(title, message string) error {
+	return h.app.toast.ShowMessage(title, message)
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"strings"
+
+	"quicklaunch/internal/config"
+	"quicklaunch/internal/plugins"
+	"quicklaunch/internal/search"
+)
+
+// searchLimit is the maximum number of search results
+const searchLimit = 50
+
+// Kinds of search results
+const (
+	SearchKindTile   = "tile"
+	SearchKindRecent = "recent"
+	SearchKindApp    = "app"
+	SearchKindFile   = "file"
+	SearchKindFolder = "folder"
+	SearchKindCalc   = "calc"
+	SearchKindPlugin = plugins.ResultKind
+)
+
+// Weights of the searched fields in percent, names count most
+var searchWeights = map[string]int{
+	"name":    100,
+	"keyword": 90,
+	"tags":    80,
+	"target":  60,
+	"path":    50,
+}
+
+// SearchResult is a tile or a recent item of a tile matching a search.
+// Highlights give the matched ranges per field as code point offsets.
+type SearchResult struct {
+	Kind       string             `json:"kind"`
+	TileID     string             `json:"tileId"`
+	Name       string             `json:"name"`
+	Path       string             `json:"path,omitempty"`
+	Score      int                `json:"score"`
+	Highlights []search.Highlight `json:"highlights"`
+}
+
+// Search matches query fuzzily against the names, keywords, tags and
+// targets of the enabled tiles and their recent items. Results are
+// ranked best first; case and diacritics are ignored, frequently used
+// tiles and paths rank higher. An empty query returns all enabled tiles
+// in their default order.
+func (a *App) Search(query string) []SearchResult {
+	return a.searchTiles(query, true, true)
+}
+
+// searchTiles searches the enabled tiles, their recent items or both
+func (a *App) searchTiles(query string, withTiles, withRecent bool) []SearchResult {
+	if a.config == nil {
+		return []SearchResult{}
+	}
+
+	tiles := make([]config.Tile, 0, len(a.config.Tiles))
+	for _, tile := range a.config.Tiles {
+		if tile.Enabled {
+			tiles = append(tiles, tile)
+		}
+	}
+	a.sortTiles(tiles)
+
+	if strings.TrimSpace(query) == "" {
+		results := make([]SearchResult, 0, len(tiles))
+		if !withTiles {
+			return results
+		}
+		for _, tile := range tiles {
+			results = append(results, SearchResult{Kind: SearchKindTile, TileID: tile.ID, Name: tile.Name, Highlights: []search.Highlight{}})
+		}
+		return results
+	}
+
+	var entries []search.Entry
+	var results []SearchResult
+	add := func(r SearchResult, key string, fields ...search.Field) {
+		for i := range fields {
+			fields[i].Weight = searchWeights[fields[i].Name]
+		}
+		boost := 0
+		if key != "" {
+			boost = frecencyBoost(a.frecency.Score(key))
+		}
+		entries = append(entries, search.Entry{Fields: fields, Boost: boost})
+		results = append(results, r)
+	}
+
+	// With a manual tile order only recent paths are boosted
+	keepOrder := a.config.KeepTileOrder
+	for _, tile := range tiles {
+		key := tileKey(tile.ID)
+		if keepOrder {
+			key = ""
+		}
+		if withTiles {
+			add(SearchResult{Kind: SearchKindTile, TileID: tile.ID, Name: tile.Name}, key,
+				search.Field{Name: "name", Text: tile.Name},
+				search.Field{Name: "keyword", Text: tile.Keyword},
+				search.Field{Name: "tags", Text: strings.Join(tile.Tags, " ")},
+				search.Field{Name: "target", Text: tile.Target},
+			)
+		}
+		if !withRecent {
+			continue
+		}
+		for _, item := range tile.SubMenuItems {
+			add(SearchResult{Kind: SearchKindRecent, TileID: tile.ID, Name: item.Name, Path: item.Path}, pathKey(tile.ID, item.Path),
+				search.Field{Name: "name", Text: item.Name},
+				search.Field{Name: "path", Text: item.Path},
+			)
+		}
+	}
+
+	hits := a.searchIndex.Rank(query, entries, searchLimit)
+	ranked := make([]SearchResult, 0, len(hits))
+	for _, hit := range hits {
+		r := results[hit.Index]
+		r.Score = hit.Score
+		r.Highlights = hit.Highlights
+		ranked = append(ranked, r)
+	}
+	return ranked
+}